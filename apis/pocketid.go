@@ -21,12 +21,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	pocketidv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	pocketidv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
 )
 
 func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
 	AddToSchemes = append(AddToSchemes,
 		pocketidv1alpha1.SchemeBuilder.AddToScheme,
+		pocketidv1beta1.SchemeBuilder.AddToScheme,
 	)
 }
 
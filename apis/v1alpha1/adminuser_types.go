@@ -34,13 +34,14 @@ type AdminUserParameters struct {
 	Username string `json:"username"`
 
 	// Email is the admin user's email address.
-	// This is required for authentication and communication purposes.
-	// +kubebuilder:validation:Required
-	Email string `json:"email"`
+	// It is required unless the admin user is only observed.
+	// +optional
+	Email string `json:"email,omitempty"`
 
 	// FirstName is the admin user's given name.
-	// +kubebuilder:validation:Required
-	FirstName string `json:"firstName"`
+	// It is required unless the admin user is only observed.
+	// +optional
+	FirstName string `json:"firstName,omitempty"`
 
 	// LastName is the admin user's family name.
 	// +optional
@@ -57,7 +58,9 @@ type AdminUserParameters struct {
 	Disabled bool `json:"disabled"`
 
 	// CustomClaims are additional key-value pairs that will be included in JWT tokens.
-	// These can be used to pass custom information to OIDC clients.
+	// These can be used to pass custom information to OIDC clients. The
+	// crossplane_owner claim is reserved: admin users created by the provider
+	// carry the UID of their AdminUser in it.
 	// +optional
 	CustomClaims map[string]string `json:"customClaims"`
 }
@@ -98,6 +101,7 @@ type AdminUserObservation struct {
 }
 
 // An AdminUserSpec defines the desired state of an AdminUser.
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || (has(self.forProvider.email) && has(self.forProvider.firstName))",message="forProvider.email and forProvider.firstName are required unless the AdminUser is only observed."
 type AdminUserSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       AdminUserParameters `json:"forProvider"`
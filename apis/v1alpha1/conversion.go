@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+// ConvertTo converts this User to the Hub version.
+func (src *User) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.User)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the Hub version to this User.
+func (dst *User) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.User)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertTo converts this Group to the Hub version.
+func (src *Group) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Group)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the Hub version to this Group.
+func (dst *Group) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Group)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertTo converts this OIDCClient to the Hub version.
+func (src *OIDCClient) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.OIDCClient)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the Hub version to this OIDCClient.
+func (dst *OIDCClient) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.OIDCClient)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertTo converts this UserGroupBinding to the Hub version.
+func (src *UserGroupBinding) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.UserGroupBinding)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the Hub version to this UserGroupBinding.
+func (dst *UserGroupBinding) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.UserGroupBinding)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertTo converts this OIDCClientGroupBinding to the Hub version.
+func (src *OIDCClientGroupBinding) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.OIDCClientGroupBinding)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the Hub version to this OIDCClientGroupBinding.
+func (dst *OIDCClientGroupBinding) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.OIDCClientGroupBinding)
+	dst.ObjectMeta = src.ObjectMeta
+	return convert(src.Spec, &dst.Spec, src.Status, &dst.Status)
+}
+
+// convert copies each source to the destination following it. v1alpha1 and
+// v1beta1 only differ in their validation, so their specs and statuses share
+// the same JSON representation.
+func convert(pairs ...any) error {
+	for i := 0; i < len(pairs); i += 2 {
+		b, err := json.Marshal(pairs[i])
+		if err != nil {
+			return errors.Wrap(err, "cannot marshal source")
+		}
+		if err := json.Unmarshal(b, pairs[i+1]); err != nil {
+			return errors.Wrap(err, "cannot unmarshal into destination")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+func TestConvertRoundTrip(t *testing.T) {
+	want := &UserGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-admins"},
+		Spec: UserGroupBindingSpec{
+			ResourceSpec: xpv1.ResourceSpec{DeletionPolicy: xpv1.DeletionOrphan},
+			ForProvider: UserGroupBindingParameters{
+				Username:   "alice",
+				GroupIDRef: &xpv1.Reference{Name: "admins"},
+			},
+		},
+		Status: UserGroupBindingStatus{AtProvider: UserGroupBindingObservation{ResolvedUserID: "u1", ResolvedGroupID: "g1"}},
+	}
+
+	hub := &v1beta1.UserGroupBinding{}
+	if err := want.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo(...): %v", err)
+	}
+	if hub.Spec.ForProvider.Username != "alice" || hub.Status.AtProvider.ResolvedGroupID != "g1" {
+		t.Errorf("ConvertTo(...): got %+v", hub)
+	}
+
+	got := &UserGroupBinding{}
+	if err := got.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom(...): %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertFrom(ConvertTo(...)): -want, +got:\n%s\n", diff)
+	}
+}
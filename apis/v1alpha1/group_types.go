@@ -34,8 +34,9 @@ type GroupParameters struct {
 
 	// FriendlyName is the display name for the group.
 	// This is shown to users and administrators in the Pocket ID interface.
-	// +kubebuilder:validation:Required
-	FriendlyName string `json:"friendlyName"`
+	// It is required unless the group is only observed.
+	// +optional
+	FriendlyName string `json:"friendlyName,omitempty"`
 
 	// CustomClaims are additional key-value pairs that will be included in JWT tokens
 	// for users who belong to this group. These can be used to pass custom
@@ -63,6 +64,7 @@ type GroupObservation struct {
 }
 
 // A GroupSpec defines the desired state of a Group.
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || (has(self.forProvider.friendlyName))",message="forProvider.friendlyName is required unless the Group is only observed."
 type GroupSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       GroupParameters `json:"forProvider"`
@@ -37,11 +37,12 @@ type OIDCClientParameters struct {
 	ID string `json:"id"`
 
 	// CallbackURLs are the allowed redirect URIs after successful authentication.
-	// These must be exact matches for security purposes.
-	// +kubebuilder:validation:Required
+	// These must be exact matches for security purposes. They are required
+	// unless the client is only observed.
+	// +optional
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:items:Format=uri
-	CallbackURLs []string `json:"callbackURLs"`
+	CallbackURLs []string `json:"callbackURLs,omitempty"`
 
 	// LogoutCallbackURLs are the allowed redirect URIs after logout.
 	// +optional
@@ -66,8 +67,12 @@ type OIDCClientParameters struct {
 	RequiresReauthentication bool `json:"requiresReauthentication"`
 
 	// LogoURL is the URL to an image file that will be used as the client's logo.
-	// The provider will download this image and upload it to Pocket ID.
+	// The provider will download this image and upload it to Pocket ID. Besides
+	// http(s) URLs, the image may be embedded as a data: URI, such as
+	// data:image/png;base64,..., or read from the key of a ConfigMap
+	// referenced as cm://namespace/name/key, for air-gapped clusters.
 	// Supported formats: PNG, JPEG, GIF, SVG. Maximum size: 2MB.
+	// Leaving it empty deletes any logo the client has.
 	// +optional
 	// +kubebuilder:validation:Format=uri
 	LogoURL string `json:"logoUrl"`
@@ -144,6 +149,7 @@ type OIDCClientObservation struct {
 }
 
 // An OIDCClientSpec defines the desired state of an OIDCClient.
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || (has(self.forProvider.callbackURLs))",message="forProvider.callbackURLs is required unless the OIDCClient is only observed."
 type OIDCClientSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       OIDCClientParameters `json:"forProvider"`
@@ -26,41 +26,69 @@ import (
 )
 
 // OIDCClientGroupBindingParameters are the configurable fields of an OIDCClientGroupBinding.
-// +kubebuilder:validation:XValidation:rule="(has(self.clientId) ? 1 : 0) + (self.clientIdRef != null ? 1 : 0) + (self.clientIdSelector != null ? 1 : 0) == 1",message="Exactly one of clientId, clientIdRef or clientIdSelector must be specified."
-// +kubebuilder:validation:XValidation:rule="(has(self.groupId) ? 1 : 0) + (self.groupIdRef != null ? 1 : 0) + (self.groupIdSelector != null ? 1 : 0) == 1",message="Exactly one of groupId, groupIdRef or groupIdSelector must be specified."
+// +kubebuilder:validation:XValidation:rule="(has(self.clientId) || has(self.clientIdRef) || has(self.clientIdSelector)) != has(self.clientName)",message="Exactly one of clientName or clientId, set directly or through clientIdRef or clientIdSelector, must be specified."
+// +kubebuilder:validation:XValidation:rule="(has(self.groupId) || has(self.groupIdRef) || has(self.groupIdSelector)) != has(self.groupName)",message="Exactly one of groupName or groupId, set directly or through groupIdRef or groupIdSelector, must be specified."
 type OIDCClientGroupBindingParameters struct {
 	// ClientID is the ID of the OIDC client to bind to a group.
-	// The client must already exist in Pocket ID.
+	// The client must already exist in Pocket ID. It is set from the
+	// OIDCClient resource selected by clientIdRef or clientIdSelector, if any.
+	// +crossplane:generate:reference:type=OIDCClient
+	// +crossplane:generate:reference:extractor=ID()
 	// +optional
-	ClientID string `json:"clientId"`
+	ClientID string `json:"clientId,omitempty"`
+
+	// ClientName is the name of the OIDC client to bind to a group. It is
+	// resolved to an ID through the Pocket ID API, which allows binding clients
+	// that are not managed by Crossplane.
+	// +optional
+	ClientName string `json:"clientName,omitempty"`
 
 	// ClientIDRef is a reference to an OIDCClient resource to bind to a group.
 	// This creates a dependency on the referenced OIDCClient resource.
 	// +optional
-	ClientIDRef *xpv1.Reference `json:"clientIdRef"`
+	ClientIDRef *xpv1.Reference `json:"clientIdRef,omitempty"`
 
 	// ClientIDSelector selects an OIDCClient resource to bind to a group.
 	// +optional
-	ClientIDSelector *xpv1.Selector `json:"clientIdSelector"`
+	ClientIDSelector *xpv1.Selector `json:"clientIdSelector,omitempty"`
 
 	// GroupID is the ID of the group to bind the OIDC client to.
-	// The group must already exist in Pocket ID.
+	// The group must already exist in Pocket ID. It is set from the Group
+	// resource selected by groupIdRef or groupIdSelector, if any.
+	// +crossplane:generate:reference:type=Group
+	// +crossplane:generate:reference:extractor=ID()
 	// +optional
 	// +kubebuilder:validation:MinLength=1
-	GroupID string `json:"groupId"`
+	GroupID string `json:"groupId,omitempty"`
+
+	// GroupName is the name of the group to bind the OIDC client to. It is
+	// resolved to an ID through the Pocket ID API, which allows binding groups
+	// that are not managed by Crossplane.
+	// +optional
+	GroupName string `json:"groupName,omitempty"`
 
 	// GroupIDRef is a reference to a Group resource to bind the client to.
 	// This creates a dependency on the referenced Group resource.
 	// +optional
-	GroupIDRef *xpv1.Reference `json:"groupIdRef"`
+	GroupIDRef *xpv1.Reference `json:"groupIdRef,omitempty"`
 
 	// GroupIDSelector selects a Group resource to bind the client to.
 	// +optional
-	GroupIDSelector *xpv1.Selector `json:"groupIdSelector"`
+	GroupIDSelector *xpv1.Selector `json:"groupIdSelector,omitempty"`
 }
 
 // OIDCClientGroupBindingObservation are the observable fields of an OIDCClientGroupBinding.
 type OIDCClientGroupBindingObservation struct {
+	// ResolvedClientID is the ID of the Pocket ID OIDC client this binding
+	// resolved to, whether it was set directly or through clientName,
+	// clientIdRef or clientIdSelector.
+	ResolvedClientID string `json:"resolvedClientID,omitempty"`
+
+	// ResolvedGroupID is the ID of the Pocket ID group this binding resolved
+	// to, whether it was set directly or through groupName, groupIdRef or
+	// groupIdSelector.
+	ResolvedGroupID string `json:"resolvedGroupID,omitempty"`
+
 	// Client contains the full OIDC client information.
 	Client OIDCClientObservation `json:"client"`
 
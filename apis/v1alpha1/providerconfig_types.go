@@ -26,28 +26,351 @@ import (
 )
 
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
+// +kubebuilder:validation:XValidation:rule="!(has(self.endpoint) && has(self.endpointRef))",message="Only one of endpoint or endpointRef may be specified."
+// +kubebuilder:validation:XValidation:rule="!has(self.pollJitter) || !has(self.pollInterval) || duration(self.pollJitter) < duration(self.pollInterval)",message="pollJitter must be shorter than pollInterval."
 type ProviderConfigSpec struct {
-	// Endpoint is the Pocket ID server endpoint.
-	// +kubebuilder:validation:Required
+	// Endpoint is the Pocket ID server endpoint. It may instead be read from
+	// JSON credentials. An endpoint such as unix:///var/run/pocket-id.sock
+	// reaches a Pocket ID sharing the pod or node of the provider through a
+	// Unix domain socket.
+	// +optional
 	// +kubebuilder:validation:Format=uri
-	Endpoint string `json:"endpoint"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// FailoverEndpoints of the same Pocket ID instance, e.g. replicas behind
+	// ingresses of other clusters. Requests go to the endpoint that last
+	// answered, and fail over to the next one when it cannot be reached.
+	// +optional
+	// +kubebuilder:validation:items:Format=uri
+	FailoverEndpoints []string `json:"failoverEndpoints,omitempty"`
+
+	// EndpointRef references the Kubernetes Service of an in-cluster Pocket
+	// ID server, addressed through its cluster DNS name instead of Endpoint.
+	// +optional
+	EndpointRef *ServiceReference `json:"endpointRef,omitempty"`
 
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// TLS configures how the Pocket ID server certificate is verified.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Timeout of each request to the Pocket ID API. Defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// ReadTimeout overrides Timeout for requests reading objects.
+	// +optional
+	ReadTimeout *metav1.Duration `json:"readTimeout,omitempty"`
+
+	// WriteTimeout overrides Timeout for requests creating, updating or
+	// deleting objects.
+	// +optional
+	WriteTimeout *metav1.Duration `json:"writeTimeout,omitempty"`
+
+	// UploadTimeout overrides Timeout for requests uploading files, such as
+	// the logos of OIDC clients.
+	// +optional
+	UploadTimeout *metav1.Duration `json:"uploadTimeout,omitempty"`
+
+	// MaxRetries is how many times a request that failed with a network error
+	// or a 429, 502, 503 or 504 response is retried. Only idempotent requests
+	// are retried, so creating an object never is unless the server could not
+	// be reached at all. Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries int `json:"maxRetries,omitempty"`
+
+	// RetryBackoff is the delay before the first retry, doubled for every
+	// subsequent one. Each delay is randomly shortened by up to half so that
+	// resources do not retry in lockstep. Defaults to 1s.
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+
+	// MaxRetryBackoff caps the delay between retries. Defaults to 30s.
+	// +optional
+	MaxRetryBackoff *metav1.Duration `json:"maxRetryBackoff,omitempty"`
+
+	// PollInterval is how often resources using this ProviderConfig are
+	// checked for drift, overriding the --poll flag of the provider. Each
+	// resource may override it in turn with a duration in its
+	// pocketid.crossplane.io/poll-interval annotation.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// PollJitter randomly shifts each poll of resources using this
+	// ProviderConfig by up to this duration either way, spreading the load
+	// on the Pocket ID API. It overrides the --poll-jitter flag of the
+	// provider, and must be shorter than PollInterval.
+	// +optional
+	PollJitter *metav1.Duration `json:"pollJitter,omitempty"`
+
+	// RateLimit caps the rate of requests sent to the Pocket ID API by all the
+	// resources using this ProviderConfig. Unlimited if unset.
+	// +optional
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty"`
+
+	// ReadCacheTTL is how long the responses of the Pocket ID API to reads are
+	// reused by resources using this ProviderConfig, cutting the requests
+	// sent when many resources are reconciled at once. Responses are dropped
+	// as soon as the provider changes anything. Identical reads in flight are
+	// always sent once. Responses are not reused if unset.
+	// +optional
+	ReadCacheTTL *metav1.Duration `json:"readCacheTTL,omitempty"`
+
+	// Headers are added to every request sent to the Pocket ID API, e.g. to
+	// get through a zero-trust proxy. A Host header overrides the host the
+	// requests are addressed to.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// UserAgent sent to the Pocket ID API. Defaults to provider-pocketid/
+	// followed by the version of the provider and the name of its pod.
+	// Requests are also sent with an X-Request-Source header naming the
+	// managed resource they are made for, as <kind>/<name>.
+	// +optional
+	UserAgent string `json:"userAgent,omitempty"`
+
+	// HeadersFrom are headers added to every request sent to the Pocket ID
+	// API whose values are read from secrets, e.g. Cloudflare Access service
+	// tokens.
+	// +optional
+	HeadersFrom []HeaderFromSecret `json:"headersFrom,omitempty"`
+
+	// Proxy configures the egress proxy used to reach the Pocket ID server.
+	// When unset, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables of the provider are used.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// DefaultLocale is given to the users using this ProviderConfig that set
+	// no locale, such as en-US, when they are created or updated.
+	// +optional
+	DefaultLocale string `json:"defaultLocale,omitempty"`
+
+	// Mode of the resources using this ProviderConfig. In ReadOnly mode they
+	// are only observed: what would be created, updated or deleted in Pocket
+	// ID is reported through events instead. Defaults to ReadWrite.
+	// +optional
+	// +kubebuilder:validation:Enum=ReadWrite;ReadOnly
+	// +kubebuilder:default=ReadWrite
+	Mode ProviderConfigMode `json:"mode,omitempty"`
+
+	// OrphanPolicy is what is done with the users, groups and OIDC clients
+	// created by the provider whose managed resource no longer exists, e.g.
+	// because its deletion was interrupted, when orphan detection is enabled.
+	// They are reported through events and metrics, and deleted too with the
+	// Delete policy unless in ReadOnly mode. Objects left behind on purpose
+	// by resources with the Orphan deletion policy, or managed by providers
+	// in other clusters, are detected as well: only use Delete when there are
+	// none. Defaults to Report.
+	// +optional
+	// +kubebuilder:validation:Enum=Report;Delete
+	// +kubebuilder:default=Report
+	OrphanPolicy OrphanPolicy `json:"orphanPolicy,omitempty"`
+}
+
+// OrphanPolicy is what is done with orphaned Pocket ID objects.
+type OrphanPolicy string
+
+// Orphan policies.
+const (
+	// OrphanPolicyReport only reports orphaned objects.
+	OrphanPolicyReport OrphanPolicy = "Report"
+
+	// OrphanPolicyDelete deletes orphaned objects.
+	OrphanPolicyDelete OrphanPolicy = "Delete"
+)
+
+// ProviderConfigMode is how resources using a ProviderConfig are reconciled.
+type ProviderConfigMode string
+
+// ProviderConfig modes.
+const (
+	// ProviderConfigModeReadWrite reconciles resources with Pocket ID.
+	ProviderConfigModeReadWrite ProviderConfigMode = "ReadWrite"
+
+	// ProviderConfigModeReadOnly only observes resources in Pocket ID.
+	ProviderConfigModeReadOnly ProviderConfigMode = "ReadOnly"
+)
+
+// RateLimitConfig configures a token bucket rate limiter.
+type RateLimitConfig struct {
+	// RPS is the sustained number of requests per second.
+	// +kubebuilder:validation:Minimum=1
+	RPS int32 `json:"rps"`
+
+	// Burst is the number of requests that may be sent at once. Defaults to
+	// RPS.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Burst int32 `json:"burst,omitempty"`
 }
 
+// A HeaderFromSecret is an HTTP header whose value is read from a secret.
+type HeaderFromSecret struct {
+	// Name of the header.
+	Name string `json:"name"`
+
+	// SecretRef references the secret key holding the value of the header.
+	SecretRef xpv1.SecretKeySelector `json:"secretRef"`
+}
+
+// A ServiceReference references a port of a Kubernetes Service.
+type ServiceReference struct {
+	// Name of the Service.
+	Name string `json:"name"`
+
+	// Namespace of the Service.
+	Namespace string `json:"namespace"`
+
+	// Port of the Service.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Scheme used to reach the Service. Defaults to http.
+	// +optional
+	// +kubebuilder:validation:Enum=http;https
+	// +kubebuilder:default=http
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// ProxyConfig configures an egress proxy.
+type ProxyConfig struct {
+	// URL of the proxy. The http, https and socks5 schemes are supported.
+	// +kubebuilder:validation:Pattern=`^(http|https|socks5)://`
+	URL string `json:"url"`
+
+	// NoProxy lists hosts, domains (e.g. .example.com) and CIDR ranges that
+	// are reached directly rather than through the proxy.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// TLSConfig configures the TLS connection to the Pocket ID server.
+// +kubebuilder:validation:XValidation:rule="!(has(self.caBundle) && has(self.caBundleSecretRef))",message="Only one of caBundle or caBundleSecretRef may be specified."
+type TLSConfig struct {
+	// CABundle is a PEM encoded bundle of certificate authorities trusted in
+	// addition to the system roots, for servers using an internal CA.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// CABundleSecretRef references a secret key holding a PEM encoded bundle
+	// of certificate authorities trusted in addition to the system roots.
+	// +optional
+	CABundleSecretRef *xpv1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
+	// InsecureSkipTLSVerify disables verification of the Pocket ID server
+	// certificate. It is only meant for lab environments, and ProviderConfigs
+	// that set it report an InsecureTLS condition.
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted. Defaults to 1.2.
+	// +optional
+	// +kubebuilder:validation:Enum="1.2";"1.3"
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// CipherSuites restricts the TLS 1.2 cipher suites offered to the server,
+	// using their IANA names (e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256). TLS
+	// 1.3 cipher suites are not configurable. Defaults to Go's secure suites.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// ClientCertificateSecretRef references a kubernetes.io/tls secret whose
+	// tls.crt and tls.key are presented as a client certificate, for Pocket ID
+	// instances behind an ingress that enforces mutual TLS.
+	// +optional
+	ClientCertificateSecretRef *xpv1.SecretReference `json:"clientCertificateSecretRef,omitempty"`
+}
+
+// CredentialsSourceOAuth authenticates with short-lived tokens obtained
+// through the OAuth 2.0 client credentials grant instead of an API key.
+const CredentialsSourceOAuth xpv1.CredentialsSource = "OAuth"
+
 // ProviderCredentials required to authenticate.
+// +kubebuilder:validation:XValidation:rule="self.source != 'OAuth' || has(self.oauth)",message="oauth must be specified when the source is OAuth."
 type ProviderCredentials struct {
-	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
+	// Source of the provider credentials. The API key is read from a secret
+	// key (Secret), an environment variable of the provider (Environment) or
+	// a file mounted in the provider, e.g. by Vault Agent (Filesystem). The
+	// credentials are either the API key itself or a JSON object such as
+	// {"endpoint": "https://id.example.com", "apiKey": "..."}. Pocket ID
+	// servers accepting OAuth access tokens on their API may instead be
+	// authenticated with tokens obtained as configured by oauth (OAuth).
+	// +kubebuilder:validation:Enum=None;Secret;Environment;Filesystem;OAuth
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// PreviousSecretRef references a secret key holding the API key being
+	// rotated out, e.g. previousApiKey in the same secret as the current one.
+	// Requests rejected with the current API key are retried with it, so the
+	// keys can be swapped without downtime. ProviderConfigs report a
+	// PreviousAPIKeyInUse condition while only the previous key is accepted.
+	// +optional
+	PreviousSecretRef *xpv1.SecretKeySelector `json:"previousSecretRef,omitempty"`
+
+	// OAuth configures how access tokens are obtained when the source is
+	// OAuth. Tokens are refreshed shortly before they expire.
+	// +optional
+	OAuth *OAuthCredentials `json:"oauth,omitempty"`
+}
+
+// OAuthCredentials are exchanged for access tokens through the OAuth 2.0
+// client credentials grant.
+// +kubebuilder:validation:XValidation:rule="has(self.clientSecretRef) != has(self.federatedTokenFile)",message="Exactly one of clientSecretRef or federatedTokenFile must be specified."
+type OAuthCredentials struct {
+	// TokenURL is the token endpoint of the authorization server.
+	// +kubebuilder:validation:Format=uri
+	TokenURL string `json:"tokenURL"`
+
+	// ClientID of the provider at the authorization server.
+	ClientID string `json:"clientId"`
+
+	// ClientSecretRef references the secret key holding the client secret.
+	// +optional
+	ClientSecretRef *xpv1.SecretKeySelector `json:"clientSecretRef,omitempty"`
+
+	// FederatedTokenFile is the path of a JWT presented as client assertion
+	// instead of a client secret, such as a projected service account token
+	// of the provider. It is read again whenever a token is requested.
+	// +optional
+	FederatedTokenFile string `json:"federatedTokenFile,omitempty"`
+
+	// Scopes requested for the access tokens.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// ServerVersion is the version of the Pocket ID server, if it reports it.
+	// Resources using fields the server does not support yet report an
+	// UnsupportedField condition.
+	// +optional
+	ServerVersion string `json:"serverVersion,omitempty"`
+
+	// UsagesByKind counts the resources using this ProviderConfig by kind,
+	// i.e. what is affected by rotating its credentials or deleting it.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	UsagesByKind []KindUsage `json:"usagesByKind,omitempty"`
+}
+
+// A KindUsage counts the resources of a kind using a ProviderConfig.
+type KindUsage struct {
+	// Kind of the resources.
+	Kind string `json:"kind"`
+
+	// Count of the resources of this kind using the ProviderConfig.
+	Count int64 `json:"count"`
 }
 
 // +kubebuilder:object:root=true
@@ -55,7 +378,9 @@ type ProviderConfigStatus struct {
 // A ProviderConfig configures a PocketId provider.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="ENDPOINT",type="string",JSONPath=".spec.endpoint"
+// +kubebuilder:printcolumn:name="HEALTHY",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.serverVersion",priority=1
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster
 type ProviderConfig struct {
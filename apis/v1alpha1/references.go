@@ -0,0 +1,39 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// ID returns an extractor of the Pocket ID identifier that referenced Users,
+// Groups and OIDCClients report once they exist. Their external names are
+// usernames and display names, which the API does not accept in place of IDs.
+func ID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		switch r := mg.(type) {
+		case *User:
+			return r.Status.AtProvider.ID
+		case *Group:
+			return r.Status.AtProvider.ID
+		case *OIDCClient:
+			return r.Status.AtProvider.ID
+		}
+		return ""
+	}
+}
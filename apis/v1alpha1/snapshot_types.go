@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A SnapshotSpec defines the Pocket ID server a Snapshot captures and where it
+// is stored.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="The spec of a Snapshot is immutable."
+type SnapshotSpec struct {
+	// ProviderConfigReference specifies the ProviderConfig of the Pocket ID
+	// server that is captured.
+	// +kubebuilder:default={"name": "default"}
+	ProviderConfigReference xpv1.Reference `json:"providerConfigRef"`
+
+	// Target the snapshot is stored in.
+	Target SnapshotTarget `json:"target"`
+
+	// SkipLDAP leaves out the users and groups synchronised from LDAP, and
+	// their memberships, as LDAP remains their source of truth.
+	// +optional
+	SkipLDAP bool `json:"skipLDAP,omitempty"`
+}
+
+// A SnapshotTarget is where a snapshot is stored. Exactly one of its fields
+// must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.secretRef), has(self.configMapRef), has(self.s3)].filter(x, x).size() == 1",message="Exactly one of secretRef, configMapRef or s3 must be specified."
+type SnapshotTarget struct {
+	// SecretRef references the Secret the snapshot is written to. It is
+	// created, and may not already exist unless written by this Snapshot.
+	// +optional
+	SecretRef *SnapshotObjectReference `json:"secretRef,omitempty"`
+
+	// ConfigMapRef references the ConfigMap the snapshot is written to. It is
+	// created, and may not already exist unless written by this Snapshot.
+	// +optional
+	ConfigMapRef *SnapshotObjectReference `json:"configMapRef,omitempty"`
+
+	// S3 configures the bucket of an S3-compatible object storage the
+	// snapshot is uploaded to.
+	// +optional
+	S3 *S3Target `json:"s3,omitempty"`
+}
+
+// A SnapshotObjectReference references a Secret or ConfigMap.
+type SnapshotObjectReference struct {
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Namespace of the object.
+	Namespace string `json:"namespace"`
+}
+
+// An S3Target is an object of an S3-compatible object storage. Objects are
+// addressed path-style, as <endpoint>/<bucket>/<key>.
+type S3Target struct {
+	// Endpoint of the object storage, such as https://s3.eu-west-3.amazonaws.com.
+	// +kubebuilder:validation:Format=uri
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// Region of the bucket. Defaults to us-east-1.
+	// +optional
+	// +kubebuilder:default=us-east-1
+	Region string `json:"region,omitempty"`
+
+	// Bucket the snapshot is uploaded to.
+	Bucket string `json:"bucket"`
+
+	// Key of the uploaded object. Defaults to the name of the Snapshot
+	// followed by .yaml.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// AccessKeyIDSecretRef references the secret key holding the access key
+	// ID the requests are signed with.
+	AccessKeyIDSecretRef xpv1.SecretKeySelector `json:"accessKeyIDSecretRef"`
+
+	// SecretAccessKeySecretRef references the secret key holding the secret
+	// access key the requests are signed with.
+	SecretAccessKeySecretRef xpv1.SecretKeySelector `json:"secretAccessKeySecretRef"`
+}
+
+// A SnapshotStatus represents the observed state of a Snapshot.
+type SnapshotStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// TakenAt is when the snapshot was taken. A Snapshot is taken once.
+	// +optional
+	TakenAt *metav1.Time `json:"takenAt,omitempty"`
+
+	// PocketIDVersion is the version of the captured Pocket ID server, if it
+	// reports it.
+	// +optional
+	PocketIDVersion string `json:"pocketIdVersion,omitempty"`
+
+	// Location the snapshot was stored at, as Secret/<namespace>/<name>,
+	// ConfigMap/<namespace>/<name> or s3://<bucket>/<key>.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Users is the number of users captured, administrators included.
+	// +optional
+	Users int `json:"users,omitempty"`
+
+	// Groups is the number of groups captured.
+	// +optional
+	Groups int `json:"groups,omitempty"`
+
+	// OIDCClients is the number of OIDC clients captured.
+	// +optional
+	OIDCClients int `json:"oidcClients,omitempty"`
+
+	// Bindings is the number of group memberships captured.
+	// +optional
+	Bindings int `json:"bindings,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Snapshot captures the users, groups, OIDC clients and group memberships of
+// a Pocket ID server once, when it is created, as the manifests of the managed
+// resources adopting them. The secrets of OIDC clients are not captured.
+// Snapshots are stored in a Secret, a ConfigMap or an S3-compatible object
+// storage, providing point-in-time backups of the configuration of Pocket ID
+// independent of its database. Deleting a Snapshot leaves what it stored.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="TAKEN-AT",type="date",JSONPath=".status.takenAt"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".status.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,pocketid}
+// +kubebuilder:subresource:status
+type Snapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SnapshotSpec   `json:"spec"`
+	Status SnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SnapshotList contains a list of Snapshot
+type SnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Snapshot `json:"items"`
+}
+
+// GetCondition of this Snapshot.
+func (in *Snapshot) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return in.Status.GetCondition(ct)
+}
+
+// SetConditions of this Snapshot.
+func (in *Snapshot) SetConditions(c ...xpv1.Condition) {
+	in.Status.SetConditions(c...)
+}
+
+// Snapshot type metadata.
+var (
+	SnapshotKind             = reflect.TypeOf(Snapshot{}).Name()
+	SnapshotGroupKind        = schema.GroupKind{Group: CRDGroup, Kind: SnapshotKind}.String()
+	SnapshotKindAPIVersion   = SnapshotKind + "." + SchemeGroupVersion.String()
+	SnapshotGroupVersionKind = SchemeGroupVersion.WithKind(SnapshotKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Snapshot{}, &SnapshotList{})
+}
@@ -33,13 +33,14 @@ type UserParameters struct {
 	Username string `json:"username"`
 
 	// Email is the user's email address.
-	// This is required for authentication and communication purposes.
-	// +kubebuilder:validation:Required
-	Email string `json:"email"`
+	// It is required unless the user is only observed.
+	// +optional
+	Email string `json:"email,omitempty"`
 
 	// FirstName is the user's given name.
-	// +kubebuilder:validation:Required
-	FirstName string `json:"firstName"`
+	// It is required unless the user is only observed.
+	// +optional
+	FirstName string `json:"firstName,omitempty"`
 
 	// LastName is the user's family name.
 	// +optional
@@ -97,6 +98,7 @@ type UserObservation struct {
 }
 
 // A UserSpec defines the desired state of a User.
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || (has(self.forProvider.email) && has(self.forProvider.firstName))",message="forProvider.email and forProvider.firstName are required unless the User is only observed."
 type UserSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       UserParameters `json:"forProvider"`
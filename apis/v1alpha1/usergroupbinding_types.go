@@ -25,42 +25,99 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// MembershipOrigin describes where a group membership comes from.
+type MembershipOrigin string
+
+// Membership origins.
+const (
+	// MembershipOriginManual is a membership managed directly in Pocket ID.
+	MembershipOriginManual MembershipOrigin = "Manual"
+
+	// MembershipOriginLDAP is a membership synchronised from an LDAP directory.
+	MembershipOriginLDAP MembershipOrigin = "LDAP"
+)
+
 // UserGroupBindingParameters are the configurable fields of a UserGroupBinding.
-// +kubebuilder:validation:XValidation:rule="(has(self.userId) ? 1 : 0) + (self.userIdRef != null ? 1 : 0) + (self.userIdSelector != null ? 1 : 0) == 1",message="Exactly one of userId, userIdRef or userIdSelector must be specified."
-// +kubebuilder:validation:XValidation:rule="(has(self.groupId) ? 1 : 0) + (self.groupIdRef != null ? 1 : 0) + (self.groupIdSelector != null ? 1 : 0) == 1",message="Exactly one of groupId, groupIdRef or groupIdSelector must be specified."
+// +kubebuilder:validation:XValidation:rule="(has(self.userId) || has(self.userIdRef) || has(self.userIdSelector)) != has(self.username)",message="Exactly one of username or userId, set directly or through userIdRef or userIdSelector, must be specified."
+// +kubebuilder:validation:XValidation:rule="(has(self.groupId) || has(self.groupIdRef) || has(self.groupIdSelector)) != has(self.groupName)",message="Exactly one of groupName or groupId, set directly or through groupIdRef or groupIdSelector, must be specified."
 type UserGroupBindingParameters struct {
 	// UserID is the ID of the user to add to a group.
-	// The user must already exist in Pocket ID.
+	// The user must already exist in Pocket ID. It is set from the User
+	// resource selected by userIdRef or userIdSelector, if any.
+	// +crossplane:generate:reference:type=User
+	// +crossplane:generate:reference:extractor=ID()
+	// +optional
+	UserID string `json:"userId,omitempty"`
+
+	// Username is the username of the user to add to a group. It is resolved
+	// to an ID through the Pocket ID API, which allows binding users that are
+	// not managed by Crossplane.
 	// +optional
-	UserID string `json:"userId"`
+	Username string `json:"username,omitempty"`
 
 	// UserIDRef is a reference to a User resource to add to a group.
 	// This creates a dependency on the referenced User resource.
 	// +optional
-	UserIDRef *xpv1.Reference `json:"userIdRef"`
+	UserIDRef *xpv1.Reference `json:"userIdRef,omitempty"`
 
 	// UserIDSelector selects a User resource to add to a group.
 	// +optional
-	UserIDSelector *xpv1.Selector `json:"userIdSelector"`
+	UserIDSelector *xpv1.Selector `json:"userIdSelector,omitempty"`
 
 	// GroupID is the ID of the group to add the user to.
-	// The group must already exist in Pocket ID.
+	// The group must already exist in Pocket ID. It is set from the Group
+	// resource selected by groupIdRef or groupIdSelector, if any.
+	// +crossplane:generate:reference:type=Group
+	// +crossplane:generate:reference:extractor=ID()
+	// +optional
+	GroupID string `json:"groupId,omitempty"`
+
+	// GroupName is the name of the group to add the user to. It is resolved
+	// to an ID through the Pocket ID API, which allows binding groups that are
+	// not managed by Crossplane.
 	// +optional
-	GroupID string `json:"groupId"`
+	GroupName string `json:"groupName,omitempty"`
 
 	// GroupIDRef is a reference to a Group resource to add the user to.
 	// This creates a dependency on the referenced Group resource.
 	// +optional
-	GroupIDRef *xpv1.Reference `json:"groupIdRef"`
+	GroupIDRef *xpv1.Reference `json:"groupIdRef,omitempty"`
 
 	// GroupIDSelector selects a Group resource to add the user to.
 	// +optional
-	GroupIDSelector *xpv1.Selector `json:"groupIdSelector"`
+	GroupIDSelector *xpv1.Selector `json:"groupIdSelector,omitempty"`
+
+	// RemoveLDAPMembership controls whether deleting this binding removes a
+	// membership synchronised from LDAP. The next LDAP sync re-adds such
+	// memberships, so they are left in place by default.
+	// +optional
+	RemoveLDAPMembership bool `json:"removeLdapMembership,omitempty"`
 }
 
 // UserGroupBindingObservation are the observable fields of a UserGroupBinding.
 type UserGroupBindingObservation struct {
-	// User contains the full user information.
+	// ResolvedUserID is the ID of the Pocket ID user this binding resolved to,
+	// whether it was set directly or through username, userIdRef or
+	// userIdSelector.
+	ResolvedUserID string `json:"resolvedUserID,omitempty"`
+
+	// ResolvedGroupID is the ID of the Pocket ID group this binding resolved
+	// to, whether it was set directly or through groupName, groupIdRef or
+	// groupIdSelector.
+	ResolvedGroupID string `json:"resolvedGroupID,omitempty"`
+
+	// MembershipOrigin reports whether the membership is managed in Pocket ID
+	// (Manual) or synchronised from an LDAP directory (LDAP).
+	MembershipOrigin MembershipOrigin `json:"membershipOrigin,omitempty"`
+
+	// EstablishedAt is when the provider first observed the membership.
+	// Pocket ID does not record when a user was added to a group, so for
+	// memberships created outside Crossplane this is when the binding first
+	// found them.
+	EstablishedAt *metav1.Time `json:"establishedAt,omitempty"`
+
+	// User contains the full user information, including every group the
+	// user belongs to.
 	User UserObservation `json:"user"`
 
 	// Group contains the full group information.
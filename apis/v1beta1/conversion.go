@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// v1beta1 is the conversion hub of the managed resources it defines, and the
+// version they are stored as.
+
+// Hub marks this type as a conversion hub.
+func (*User) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Group) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*OIDCClient) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*UserGroupBinding) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*OIDCClientGroupBinding) Hub() {}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// MembershipOrigin describes where a group membership comes from.
+type MembershipOrigin string
+
+// Membership origins.
+const (
+	// MembershipOriginManual is a membership managed directly in Pocket ID.
+	MembershipOriginManual MembershipOrigin = "Manual"
+
+	// MembershipOriginLDAP is a membership synchronised from an LDAP directory.
+	MembershipOriginLDAP MembershipOrigin = "LDAP"
+)
+
+// UserGroupBindingParameters are the configurable fields of a UserGroupBinding.
+// +kubebuilder:validation:XValidation:rule="(has(self.userId) || has(self.userIdRef) || has(self.userIdSelector)) != has(self.username)",message="Exactly one of username or userId, set directly or through userIdRef or userIdSelector, must be specified."
+// +kubebuilder:validation:XValidation:rule="(has(self.groupId) || has(self.groupIdRef) || has(self.groupIdSelector)) != has(self.groupName)",message="Exactly one of groupName or groupId, set directly or through groupIdRef or groupIdSelector, must be specified."
+type UserGroupBindingParameters struct {
+	// UserID is the ID of the user to add to a group.
+	// The user must already exist in Pocket ID. It is set from the User
+	// resource selected by userIdRef or userIdSelector, if any, and cannot
+	// be changed once set.
+	// +crossplane:generate:reference:type=User
+	// +crossplane:generate:reference:extractor=ID()
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="userId is immutable"
+	UserID string `json:"userId,omitempty"`
+
+	// Username is the username of the user to add to a group. It is resolved
+	// to an ID through the Pocket ID API, which allows binding users that are
+	// not managed by Crossplane, and cannot be changed once set.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="username is immutable"
+	Username string `json:"username,omitempty"`
+
+	// UserIDRef is a reference to a User resource to add to a group.
+	// This creates a dependency on the referenced User resource.
+	// +optional
+	UserIDRef *xpv1.Reference `json:"userIdRef,omitempty"`
+
+	// UserIDSelector selects a User resource to add to a group.
+	// +optional
+	UserIDSelector *xpv1.Selector `json:"userIdSelector,omitempty"`
+
+	// GroupID is the ID of the group to add the user to.
+	// The group must already exist in Pocket ID. It is set from the Group
+	// resource selected by groupIdRef or groupIdSelector, if any, and cannot
+	// be changed once set.
+	// +crossplane:generate:reference:type=Group
+	// +crossplane:generate:reference:extractor=ID()
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="groupId is immutable"
+	GroupID string `json:"groupId,omitempty"`
+
+	// GroupName is the name of the group to add the user to. It is resolved
+	// to an ID through the Pocket ID API, which allows binding groups that are
+	// not managed by Crossplane, and cannot be changed once set.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="groupName is immutable"
+	GroupName string `json:"groupName,omitempty"`
+
+	// GroupIDRef is a reference to a Group resource to add the user to.
+	// This creates a dependency on the referenced Group resource.
+	// +optional
+	GroupIDRef *xpv1.Reference `json:"groupIdRef,omitempty"`
+
+	// GroupIDSelector selects a Group resource to add the user to.
+	// +optional
+	GroupIDSelector *xpv1.Selector `json:"groupIdSelector,omitempty"`
+
+	// RemoveLDAPMembership controls whether deleting this binding removes a
+	// membership synchronised from LDAP. The next LDAP sync re-adds such
+	// memberships, so they are left in place by default.
+	// +optional
+	RemoveLDAPMembership bool `json:"removeLdapMembership,omitempty"`
+}
+
+// UserGroupBindingObservation are the observable fields of a UserGroupBinding.
+type UserGroupBindingObservation struct {
+	// ResolvedUserID is the ID of the Pocket ID user this binding resolved to,
+	// whether it was set directly or through username, userIdRef or
+	// userIdSelector.
+	ResolvedUserID string `json:"resolvedUserID,omitempty"`
+
+	// ResolvedGroupID is the ID of the Pocket ID group this binding resolved
+	// to, whether it was set directly or through groupName, groupIdRef or
+	// groupIdSelector.
+	ResolvedGroupID string `json:"resolvedGroupID,omitempty"`
+
+	// MembershipOrigin reports whether the membership is managed in Pocket ID
+	// (Manual) or synchronised from an LDAP directory (LDAP).
+	MembershipOrigin MembershipOrigin `json:"membershipOrigin,omitempty"`
+
+	// EstablishedAt is when the provider first observed the membership.
+	// Pocket ID does not record when a user was added to a group, so for
+	// memberships created outside Crossplane this is when the binding first
+	// found them.
+	EstablishedAt *metav1.Time `json:"establishedAt,omitempty"`
+
+	// User contains the full user information, including every group the
+	// user belongs to.
+	User UserObservation `json:"user"`
+
+	// Group contains the full group information.
+	Group GroupObservation `json:"group"`
+}
+
+// A UserGroupBindingSpec defines the desired state of a UserGroupBinding.
+type UserGroupBindingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       UserGroupBindingParameters `json:"forProvider"`
+}
+
+// A UserGroupBindingStatus represents the observed state of a UserGroupBinding.
+type UserGroupBindingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          UserGroupBindingObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A UserGroupBinding adds a user to a group in Pocket ID.
+// Groups are used to organize users and control access to OIDC applications.
+// Users can belong to multiple groups, and groups can be used in OIDC client
+// configurations to restrict access based on group membership.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="USERNAME",type="string",JSONPath=".status.atProvider.user.username"
+// +kubebuilder:printcolumn:name="GROUP-NAME",type="string",JSONPath=".status.atProvider.group.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,pocketid}
+// +kubebuilder:storageversion
+type UserGroupBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UserGroupBindingSpec   `json:"spec"`
+	Status UserGroupBindingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UserGroupBindingList contains a list of UserGroupBinding
+type UserGroupBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UserGroupBinding `json:"items"`
+}
+
+// UserGroupBinding type metadata.
+var (
+	UserGroupBindingKind             = reflect.TypeOf(UserGroupBinding{}).Name()
+	UserGroupBindingGroupKind        = schema.GroupKind{Group: CRDGroup, Kind: UserGroupBindingKind}.String()
+	UserGroupBindingKindAPIVersion   = UserGroupBindingKind + "." + SchemeGroupVersion.String()
+	UserGroupBindingGroupVersionKind = SchemeGroupVersion.WithKind(UserGroupBindingKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&UserGroupBinding{}, &UserGroupBindingList{})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command pocketid-import prints the manifests of the managed resources
+// adopting the users, groups, OIDC clients and group memberships of an
+// existing Pocket ID instance.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/importer"
+	"github.com/crossplane/provider-pocketid/internal/version"
+)
+
+func main() {
+	var (
+		app = kingpin.New(filepath.Base(os.Args[0]), "Print the managed resources adopting the objects of a Pocket ID instance.").DefaultEnvars()
+
+		endpoint           = app.Flag("endpoint", "URL of the Pocket ID instance.").Required().String()
+		apiKey             = app.Flag("api-key", "API key of the Pocket ID instance.").Envar("POCKETID_API_KEY").Required().String()
+		caBundle           = app.Flag("ca-bundle", "PEM encoded certificate authorities trusted in addition to the system roots.").ExistingFile()
+		insecureSkipVerify = app.Flag("insecure-skip-tls-verify", "Do not verify the certificate of the Pocket ID instance.").Default("false").Bool()
+		headers            = app.Flag("header", "Header sent with every request as name=value. May be repeated.").PlaceHolder("NAME=VALUE").StringMap()
+		timeout            = app.Flag("timeout", "Timeout of each request.").Default("30s").Duration()
+
+		providerConfig = app.Flag("provider-config", "Name of the ProviderConfig the managed resources use.").Default("default").String()
+		deletionPolicy = app.Flag("deletion-policy", "Deletion policy of the managed resources. Orphan keeps the objects in Pocket ID when their managed resource is deleted.").Default(string(xpv1.DeletionDelete)).Enum(string(xpv1.DeletionDelete), string(xpv1.DeletionOrphan))
+		skipLDAP       = app.Flag("skip-ldap", "Skip the users and groups synchronised from LDAP, and their memberships.").Default("true").Bool()
+		output         = app.Flag("output", "File the manifests are written to, standard output if unset.").Short('o').String()
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	cfg := pocketid.Config{
+		Endpoint:           *endpoint,
+		APIKey:             *apiKey,
+		Timeout:            *timeout,
+		UserAgent:          "pocketid-import/" + version.Version,
+		Headers:            *headers,
+		InsecureSkipVerify: *insecureSkipVerify,
+	}
+	if *caBundle != "" {
+		b, err := os.ReadFile(*caBundle)
+		kingpin.FatalIfError(err, "Cannot read CA bundle")
+		cfg.CABundle = b
+	}
+	svc, err := pocketid.NewClientFromConfig(cfg)
+	kingpin.FatalIfError(err, "Cannot create Pocket ID client")
+
+	mgs, err := importer.Import(context.Background(), svc, importer.Options{
+		ProviderConfigName: *providerConfig,
+		DeletionPolicy:     xpv1.DeletionPolicy(*deletionPolicy),
+		SkipLDAP:           *skipLDAP,
+	})
+	kingpin.FatalIfError(err, "Cannot import Pocket ID objects")
+
+	if *output == "" {
+		kingpin.FatalIfError(importer.Write(os.Stdout, mgs), "Cannot write manifests")
+		return
+	}
+	f, err := os.Create(*output)
+	kingpin.FatalIfError(err, "Cannot create output file")
+	kingpin.FatalIfError(importer.Write(f, mgs), "Cannot write manifests")
+	kingpin.FatalIfError(f.Close(), "Cannot close output file")
+}
@@ -22,7 +22,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -34,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	changelogsv1alpha1 "github.com/crossplane/crossplane-runtime/apis/changelogs/proto/v1alpha1"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -47,30 +47,71 @@ import (
 
 	"github.com/crossplane/provider-pocketid/apis"
 	"github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
+	pocketidclient "github.com/crossplane/provider-pocketid/internal/clients/pocketid"
 	pocketid "github.com/crossplane/provider-pocketid/internal/controller"
+	"github.com/crossplane/provider-pocketid/internal/controller/config"
+	"github.com/crossplane/provider-pocketid/internal/controller/orphans"
+	"github.com/crossplane/provider-pocketid/internal/events"
 	"github.com/crossplane/provider-pocketid/internal/features"
+	"github.com/crossplane/provider-pocketid/internal/readonly"
 	"github.com/crossplane/provider-pocketid/internal/version"
+	pocketidwebhook "github.com/crossplane/provider-pocketid/internal/webhook"
 )
 
 func main() {
 	var (
 		app            = kingpin.New(filepath.Base(os.Args[0]), "PocketId support for Crossplane.").DefaultEnvars()
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
+		debugRequests  = app.Flag("debug-requests", "Log the requests sent to Pocket ID and their responses, with secrets redacted. Requires --debug.").Default("false").Envar("DEBUG_REQUESTS").Bool()
 		leaderElection = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").Envar("LEADER_ELECTION").Bool()
+		dryRun         = app.Flag("dry-run", "Observe Pocket ID without changing it, logging and emitting events for what would have been created, updated or deleted.").Default("false").Envar("DRY_RUN").Bool()
+		certsDir       = app.Flag("certs-dir", "The directory that contains the server key and certificate of the conversion webhook.").Default("/tls/server").Envar("CERTS_DIR").String()
 
-		syncInterval            = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
+		leaderElectionNamespace     = app.Flag("leader-election-namespace", "Namespace of the lease used for leader election. Defaults to the namespace of the provider.").Envar("LEADER_ELECTION_NAMESPACE").String()
+		leaderElectionLeaseDuration = app.Flag("leader-election-lease-duration", "How long replicas wait before taking over leadership from a leader that stopped renewing its lease.").Default("60s").Envar("LEADER_ELECTION_LEASE_DURATION").Duration()
+		leaderElectionRenewDeadline = app.Flag("leader-election-renew-deadline", "How long the leader keeps retrying to renew its lease before giving up leadership. Must be shorter than the lease duration.").Default("50s").Envar("LEADER_ELECTION_RENEW_DEADLINE").Duration()
+		leaderElectionRetryPeriod   = app.Flag("leader-election-retry-period", "How long replicas wait between attempts to acquire or renew the lease.").Default("2s").Envar("LEADER_ELECTION_RETRY_PERIOD").Duration()
+
+		syncInterval            = app.Flag("sync-interval", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Envar("SYNC_INTERVAL").Duration()
+		syncDeprecated          = app.Flag("sync", "Deprecated, use --sync-interval.").Hidden().Envar("SYNC").Duration()
 		pollInterval            = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
+		pollJitter              = app.Flag("poll-jitter", "Randomly shift each poll of resources by up to this duration either way, spreading the load on the Pocket ID API. ProviderConfigs may override it.").Envar("POLL_JITTER").Duration()
 		pollStateMetricInterval = app.Flag("poll-state-metric", "State metric recording interval").Default("5s").Duration()
 
-		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		eventsAddress  = app.Flag("events-address", "Address at which Pocket ID events are received on "+events.Path+", e.g. :8088, reconciling the resources they are about without waiting for their next poll. Disabled if unset.").Envar("EVENTS_ADDRESS").String()
+		eventsToken    = app.Flag("events-token", "Bearer token Pocket ID events must be sent with. Required by --events-address unless --events-insecure is set.").Envar("EVENTS_TOKEN").String()
+		eventsInsecure = app.Flag("events-insecure", "Accept Pocket ID events sent without a token when --events-token is unset. Anyone reaching --events-address can then have resources reconciled at will.").Default("false").Envar("EVENTS_INSECURE").Bool()
+
+		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Envar("MAX_RECONCILE_RATE").Int()
+
+		enableControllers    = app.Flag("enable-controllers", "Comma-separated kinds whose controllers are run, e.g. oidcclient,group. All of them are run if unset.").Envar("ENABLE_CONTROLLERS").String()
+		maxReconcilesPerKind = app.Flag("max-reconciles-per-kind", "Maximum concurrent reconciles of a kind as kind=count, overriding --max-reconcile-rate. May be repeated.").PlaceHolder("KIND=COUNT").StringMap()
 
 		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
 		enableManagementPolicies   = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("false").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
+		enableOrphanDetection      = app.Flag("enable-orphan-detection", "Enable periodic detection of the Pocket ID objects created by the provider whose managed resource no longer exists.").Default("false").Envar("ENABLE_ORPHAN_DETECTION").Bool()
 		enableChangeLogs           = app.Flag("enable-changelogs", "Enable support for capturing change logs during reconciliation.").Default("false").Envar("ENABLE_CHANGE_LOGS").Bool()
+		enableValidationWebhooks   = app.Flag("enable-validation-webhooks", "Reject invalid managed resources at admission rather than when they are applied to Pocket ID.").Default("false").Envar("ENABLE_VALIDATION_WEBHOOKS").Bool()
 		changelogsSocketPath       = app.Flag("changelogs-socket-path", "Path for changelogs socket (if enabled)").Default("/var/run/changelogs/changelogs.sock").Envar("CHANGELOGS_SOCKET_PATH").String()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
+	if *syncDeprecated > 0 {
+		syncInterval = syncDeprecated
+	}
+	if *leaderElectionRenewDeadline >= *leaderElectionLeaseDuration {
+		kingpin.Fatalf("--leader-election-renew-deadline (%s) must be shorter than --leader-election-lease-duration (%s)", *leaderElectionRenewDeadline, *leaderElectionLeaseDuration)
+	}
+	if *pollJitter >= *pollInterval {
+		kingpin.Fatalf("--poll-jitter (%s) must be shorter than --poll (%s)", *pollJitter, *pollInterval)
+	}
+	if *eventsAddress != "" && *eventsToken == "" && !*eventsInsecure {
+		kingpin.Fatalf("--events-address requires --events-token, or --events-insecure to accept events sent without a token")
+	}
+	if *maxReconcileRate < 1 {
+		kingpin.Fatalf("--max-reconcile-rate must be at least 1, got %d", *maxReconcileRate)
+	}
 
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-pocketid"))
@@ -85,6 +126,19 @@ func main() {
 		ctrl.SetLogger(zap.New(zap.WriteTo(io.Discard)))
 	}
 
+	if *debugRequests {
+		clients.LogRequests(log.WithValues("component", "pocketid-api"))
+	}
+
+	if *pollJitter > 0 {
+		clients.JitterPolls(*pollJitter)
+	}
+
+	if *dryRun {
+		readonly.DryRun(log.WithValues("component", "dry-run"))
+		log.Info("Dry run, Pocket ID will not be changed")
+	}
+
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
@@ -101,21 +155,34 @@ func main() {
 		// renewal deadlines being exceeded when under high load - i.e.
 		// hundreds of reconciles per second and ~200rps to the API
 		// server. Switching to Leases only and longer leases appears to
-		// alleviate this.
+		// alleviate this, hence the defaults of the leader election flags.
 		LeaderElection:             *leaderElection,
 		LeaderElectionID:           "crossplane-leader-election-provider-pocketid",
+		LeaderElectionNamespace:    *leaderElectionNamespace,
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
-		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
-		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+		LeaseDuration:              leaderElectionLeaseDuration,
+		RenewDeadline:              leaderElectionRenewDeadline,
+		RetryPeriod:                leaderElectionRetryPeriod,
+
+		// The API server converts the v1alpha1 managed resources to and
+		// from their v1beta1 storage version, defaults them and optionally
+		// validates them through these webhooks.
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: *certsDir,
+		}),
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add PocketId APIs to scheme")
+	kingpin.FatalIfError(pocketidwebhook.Setup(mgr, pocketidwebhook.Options{Validate: *enableValidationWebhooks}), "Cannot setup PocketId webhooks")
 
 	metricRecorder := managed.NewMRMetricRecorder()
 	stateMetrics := statemetrics.NewMRStateMetrics()
 
 	metrics.Registry.MustRegister(metricRecorder)
 	metrics.Registry.MustRegister(stateMetrics)
+	metrics.Registry.MustRegister(pocketidclient.Collectors()...)
+	metrics.Registry.MustRegister(config.Collectors()...)
+	metrics.Registry.MustRegister(orphans.Collectors()...)
 
 	o := controller.Options{
 		Logger:                  log,
@@ -154,6 +221,11 @@ func main() {
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaManagementPolicies)
 	}
 
+	if *enableOrphanDetection {
+		o.Features.Enable(features.EnableAlphaOrphanDetection)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaOrphanDetection)
+	}
+
 	if *enableChangeLogs {
 		o.Features.Enable(feature.EnableAlphaChangeLogs)
 		log.Info("Alpha feature enabled", "flag", feature.EnableAlphaChangeLogs)
@@ -169,6 +241,18 @@ func main() {
 		o.ChangeLogOptions = &clo
 	}
 
-	kingpin.FatalIfError(pocketid.Setup(mgr, o), "Cannot setup PocketId controllers")
+	if *eventsAddress != "" {
+		var eo []events.ReceiverOption
+		if *eventsInsecure {
+			eo = append(eo, events.Insecure())
+			log.Info("Accepting Pocket ID events sent without a token", "address", *eventsAddress)
+		}
+		kingpin.FatalIfError(mgr.Add(events.NewServer(*eventsAddress, events.NewReceiver(mgr.GetClient(), *eventsToken, log.WithValues("component", "events"), eo...))), "Cannot add Pocket ID event receiver")
+		log.Info("Receiving Pocket ID events", "address", *eventsAddress, "path", events.Path)
+	}
+
+	ko, err := pocketid.ParseKindOptions(*enableControllers, *maxReconcilesPerKind)
+	kingpin.FatalIfError(err, "Cannot parse controller flags")
+	kingpin.FatalIfError(pocketid.Setup(mgr, o, ko), "Cannot setup PocketId controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
@@ -0,0 +1,27 @@
+#!/usr/bin/env bash
+
+# Copyright 2025 The Crossplane Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Converts the versions of the CRDs in the supplied directory that serve
+# several of them through the provider's conversion webhook. Crossplane fills in the webhook service when
+# it installs the provider. controller-gen has no marker for this.
+
+set -euo pipefail
+
+for crd in "${1}"/*.yaml; do
+  if [ "$(grep -c '^    - additionalPrinterColumns:\|^    - name: v' "${crd}")" -gt 1 ]; then
+    sed -i '0,/^spec:$/s//spec:\n  conversion:\n    strategy: Webhook/' "${crd}"
+  fi
+done
@@ -0,0 +1,294 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package async runs the slow side effects of reconciles, such as uploading
+// the logo of an OIDC client, in the background so that reconciles return
+// quickly. Side effects that fail are retried with their own backoff, apart
+// from the reconciles of their managed resource.
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	// defaultWorkers is the number of side effects run concurrently.
+	defaultWorkers = 2
+
+	// timeout of a side effect attempt.
+	timeout = 2 * time.Minute
+
+	// defaultBaseDelay and defaultMaxDelay bound the backoff of the side
+	// effects that fail.
+	defaultBaseDelay = 5 * time.Second
+	defaultMaxDelay  = 10 * time.Minute
+
+	// pausedDelay is how long the side effects of paused managed resources
+	// wait before checking whether they were resumed.
+	pausedDelay = 30 * time.Second
+)
+
+// A Func is a side effect. It is retried until it returns nil.
+type Func func(ctx context.Context) error
+
+// Status of a side effect.
+type Status struct {
+	// Pending is true until the side effect succeeds.
+	Pending bool
+
+	// Attempts of the side effect since it was last submitted.
+	Attempts int
+
+	// Err returned by the last attempt of the side effect, if any.
+	Err error
+}
+
+// A job is a side effect submitted to a queue.
+type job struct {
+	fn  Func
+	obj client.Object
+
+	// generation of the job, that of the queue when it was last submitted so
+	// that a superseded attempt does not complete it.
+	generation uint64
+}
+
+// A Queue runs the side effects submitted to it until they succeed. Side
+// effects are identified by the managed resource they are about and a name,
+// only the last one submitted for each being run.
+type Queue struct {
+	queue     workqueue.TypedRateLimitingInterface[string]
+	workers   int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	events    chan<- event.GenericEvent
+	paused    func(ctx context.Context, obj client.Object) bool
+	log       logging.Logger
+
+	mu         sync.Mutex
+	jobs       map[string]*job
+	status     map[string]Status
+	generation uint64
+}
+
+// A QueueOption configures a Queue.
+type QueueOption func(q *Queue)
+
+// WithWorkers sets the number of side effects run concurrently.
+func WithWorkers(n int) QueueOption {
+	return func(q *Queue) {
+		q.workers = n
+	}
+}
+
+// WithBackoff sets the delay before retrying a side effect that failed for
+// the first time, doubled on each failure up to the supplied maximum.
+func WithBackoff(base, maxDelay time.Duration) QueueOption {
+	return func(q *Queue) {
+		q.baseDelay = base
+		q.maxDelay = maxDelay
+	}
+}
+
+// WithEvents sends the managed resource of a side effect to the supplied
+// channel whenever one of its attempts completes, so that its controller may
+// reconcile it right away to report the status of the side effect.
+func WithEvents(ch chan<- event.GenericEvent) QueueOption {
+	return func(q *Queue) {
+		q.events = ch
+	}
+}
+
+// WithPauseCheck holds the side effects of the managed resources the supplied
+// function reports paused, without counting attempts, until they are resumed.
+func WithPauseCheck(fn func(ctx context.Context, obj client.Object) bool) QueueOption {
+	return func(q *Queue) {
+		q.paused = fn
+	}
+}
+
+// WithLogger sets the logger of the queue.
+func WithLogger(l logging.Logger) QueueOption {
+	return func(q *Queue) {
+		q.log = l
+	}
+}
+
+// NewQueue returns a queue retrying failed side effects with an exponential
+// backoff. It runs them once started.
+func NewQueue(o ...QueueOption) *Queue {
+	q := &Queue{
+		workers:   defaultWorkers,
+		baseDelay: defaultBaseDelay,
+		maxDelay:  defaultMaxDelay,
+		log:       logging.NewNopLogger(),
+		jobs:      map[string]*job{},
+		status:    map[string]Status{},
+	}
+	for _, fn := range o {
+		fn(q)
+	}
+	q.queue = workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[string](q.baseDelay, q.maxDelay),
+		workqueue.TypedRateLimitingQueueConfig[string]{},
+	)
+	return q
+}
+
+// key of the side effect with the supplied name of a managed resource.
+func key(obj client.Object, name string) string {
+	return string(obj.GetUID()) + "/" + name
+}
+
+// Submit runs the side effect with the supplied name of a managed resource
+// until it succeeds, superseding any side effect of the same name still
+// pending. A side effect waiting to be retried keeps waiting.
+func (q *Queue) Submit(obj client.Object, name string, fn Func) {
+	k := key(obj, name)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	j, pending := q.jobs[k]
+	if !pending {
+		j = &job{}
+		q.jobs[k] = j
+	}
+	j.fn = fn
+	j.obj = obj.DeepCopyObject().(client.Object)
+	q.generation++
+	j.generation = q.generation
+	q.status[k] = Status{Pending: true}
+
+	if !pending {
+		q.queue.Add(k)
+	}
+}
+
+// Status returns the status of the side effect with the supplied name of a
+// managed resource, and whether it was submitted at all.
+func (q *Queue) Status(obj client.Object, name string) (Status, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s, ok := q.status[key(obj, name)]
+	return s, ok
+}
+
+// Forget cancels the side effect with the supplied name of a managed resource
+// and its status, typically once the resource is deleted.
+func (q *Queue) Forget(obj client.Object, name string) {
+	k := key(obj, name)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.jobs, k)
+	delete(q.status, k)
+	q.queue.Forget(k)
+}
+
+// Start runs the submitted side effects until the supplied context is done.
+func (q *Queue) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		q.queue.ShutDown()
+	}()
+
+	var wg sync.WaitGroup
+	for range q.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for q.next(ctx) {
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// NeedLeaderElection returns true: side effects are run by the controllers
+// of the elected replica.
+func (q *Queue) NeedLeaderElection() bool {
+	return true
+}
+
+// next runs the next side effect of the queue, returning false once the queue
+// is shut down.
+func (q *Queue) next(ctx context.Context) bool {
+	k, shutdown := q.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer q.queue.Done(k)
+
+	q.mu.Lock()
+	j, ok := q.jobs[k]
+	if !ok {
+		// Forgotten while waiting to be run.
+		q.mu.Unlock()
+		return true
+	}
+	fn, obj, generation := j.fn, j.obj, j.generation
+	q.mu.Unlock()
+
+	if q.paused != nil && q.paused(ctx, obj) {
+		q.queue.AddAfter(k, pausedDelay)
+		return true
+	}
+
+	actx, cancel := context.WithTimeout(ctx, timeout)
+	err := fn(actx)
+	cancel()
+
+	q.mu.Lock()
+	j, ok = q.jobs[k]
+	switch {
+	case !ok:
+		// Forgotten while running.
+	case err != nil:
+		s := q.status[k]
+		s.Attempts++
+		s.Err = err
+		q.status[k] = s
+		q.queue.AddRateLimited(k)
+		q.log.Debug("Cannot run side effect, retrying", "key", k, "attempts", s.Attempts, "error", err)
+	case j.generation != generation:
+		// Submitted again while running, run the new side effect.
+		q.queue.Forget(k)
+		q.queue.Add(k)
+	default:
+		delete(q.jobs, k)
+		q.status[k] = Status{Attempts: q.status[k].Attempts + 1}
+		q.queue.Forget(k)
+	}
+	q.mu.Unlock()
+
+	if ok && q.events != nil {
+		select {
+		case q.events <- event.GenericEvent{Object: obj}:
+		case <-ctx.Done():
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+func TestQueue(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		calls  int
+		status Status
+	}
+
+	cases := map[string]struct {
+		reason   string
+		failures int
+		want     want
+	}{
+		"Succeeded": {
+			reason: "A side effect that succeeds should be run once.",
+			want:   want{calls: 1, status: Status{Attempts: 1}},
+		},
+		"Retried": {
+			reason:   "A side effect that fails should be retried until it succeeds.",
+			failures: 2,
+			want:     want{calls: 3, status: Status{Attempts: 3}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			events := make(chan event.GenericEvent)
+			q := NewQueue(WithEvents(events), WithBackoff(time.Millisecond, time.Millisecond))
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go q.Start(ctx) //nolint:errcheck // Start never fails.
+
+			cr := &apisv1beta1.OIDCClient{ObjectMeta: metav1.ObjectMeta{Name: "app", UID: "0b3e5a1c"}}
+			calls := 0
+			q.Submit(cr, "logo", func(_ context.Context) error {
+				calls++
+				if calls <= tc.failures {
+					return errBoom
+				}
+				return nil
+			})
+
+			// An event is sent once each attempt completes.
+			for i := 0; i < tc.want.calls; i++ {
+				e := <-events
+				if e.Object.GetName() != "app" {
+					t.Errorf("\n%s\nq.Submit(...): want an event about app, got %q", tc.reason, e.Object.GetName())
+				}
+				if i < tc.failures {
+					s, _ := q.Status(cr, "logo")
+					if diff := cmp.Diff(Status{Pending: true, Attempts: i + 1, Err: errBoom}, s, test.EquateErrors()); diff != "" {
+						t.Errorf("\n%s\nq.Status(...): -want, +got:\n%s\n", tc.reason, diff)
+					}
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nq.Submit(...): -want calls, +got:\n%s\n", tc.reason, diff)
+			}
+			s, ok := q.Status(cr, "logo")
+			if !ok {
+				t.Fatalf("\n%s\nq.Status(...): want the side effect to be known", tc.reason)
+			}
+			if diff := cmp.Diff(tc.want.status, s, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Status(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+
+			q.Forget(cr, "logo")
+			if _, ok := q.Status(cr, "logo"); ok {
+				t.Errorf("\n%s\nq.Forget(...): want the side effect to be forgotten", tc.reason)
+			}
+		})
+	}
+}
+
+func TestQueueSubmitPending(t *testing.T) {
+	q := NewQueue()
+	cr := &apisv1beta1.OIDCClient{ObjectMeta: metav1.ObjectMeta{Name: "app", UID: "0b3e5a1c"}}
+
+	// Side effects are not run before the queue is started.
+	ran := ""
+	q.Submit(cr, "logo", func(_ context.Context) error { ran = "first"; return nil })
+	q.Submit(cr, "logo", func(_ context.Context) error { ran = "second"; return nil })
+
+	if n := q.queue.Len(); n != 1 {
+		t.Errorf("q.Submit(...): want a single side effect queued, got %d", n)
+	}
+	if !q.next(context.Background()) {
+		t.Fatal("q.next(...): want the queue to be running")
+	}
+	if ran != "second" {
+		t.Errorf("q.Submit(...): want the last side effect submitted run, got the %s", ran)
+	}
+	if s, _ := q.Status(cr, "logo"); s.Pending {
+		t.Errorf("q.Status(...): want the side effect to be done, got %+v", s)
+	}
+}
+
+func TestQueuePaused(t *testing.T) {
+	paused := true
+	q := NewQueue(WithPauseCheck(func(context.Context, client.Object) bool { return paused }))
+	cr := &apisv1beta1.OIDCClient{ObjectMeta: metav1.ObjectMeta{Name: "app", UID: "0b3e5a1c"}}
+
+	calls := 0
+	q.Submit(cr, "logo", func(_ context.Context) error { calls++; return nil })
+
+	// The side effect of a paused resource is held until it is resumed.
+	if !q.next(context.Background()) {
+		t.Fatal("q.next(...): want the queue to be running")
+	}
+	if calls != 0 {
+		t.Errorf("q.next(...): want the side effect of a paused resource held, got %d calls", calls)
+	}
+	s, _ := q.Status(cr, "logo")
+	if diff := cmp.Diff(Status{Pending: true}, s); diff != "" {
+		t.Errorf("q.Status(...): -want, +got:\n%s\n", diff)
+	}
+
+	paused = false
+	q.queue.Add(key(cr, "logo"))
+	if !q.next(context.Background()) {
+		t.Fatal("q.next(...): want the queue to be running")
+	}
+	if calls != 1 {
+		t.Errorf("q.next(...): want the side effect run once resumed, got %d calls", calls)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package changelog describes the changes the controllers make to Pocket ID
+// objects, field by field, as the additional details of the change log
+// entries of their managed resources.
+package changelog
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"slices"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// KeyPrefix prefixes the additional details describing the change of a field,
+// e.g. diff.email.
+const KeyPrefix = "diff."
+
+// Redacted replaces the values that must not be logged.
+const Redacted = "REDACTED"
+
+// A Change of a field of a Pocket ID object.
+type Change struct {
+	// Field that changed, as named in the spec of its managed resource.
+	Field string `json:"-"`
+
+	// Before is the value of the field observed in Pocket ID.
+	Before any `json:"before"`
+
+	// After is the value of the field once updated.
+	After any `json:"after"`
+}
+
+// Field returns the change of the supplied field, nil if it did not change.
+func Field(name string, before, after any) []Change {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	return []Change{{Field: name, Before: before, After: after}}
+}
+
+// URL returns the change of the supplied URL field, nil if it did not change.
+// The passwords and query values of the URLs are redacted, as they may hold
+// credentials such as the signature of a presigned URL.
+func URL(name, before, after string) []Change {
+	if before == after {
+		return nil
+	}
+	return []Change{{Field: name, Before: redactURL(before), After: redactURL(after)}}
+}
+
+// URLs returns the change of the supplied list of URLs, nil if it did not
+// change. Their passwords and query values are redacted.
+func URLs(name string, before, after []string) []Change {
+	if slices.Equal(before, after) {
+		return nil
+	}
+	return []Change{{Field: name, Before: redactURLs(before), After: redactURLs(after)}}
+}
+
+// Claims returns a change for each custom claim that was added, changed or
+// removed. The owner claim set by the provider is ignored, as it is never
+// part of the spec of a managed resource.
+func Claims(name string, before, after map[string]string) []Change {
+	var changes []Change
+	for k, v := range before {
+		if k == pocketid.OwnerClaim {
+			continue
+		}
+		if a, ok := after[k]; !ok || a != v {
+			changes = append(changes, Change{Field: name + "." + k, Before: v, After: nullable(a, ok)})
+		}
+	}
+	for k, v := range after {
+		if _, ok := before[k]; ok || k == pocketid.OwnerClaim {
+			continue
+		}
+		changes = append(changes, Change{Field: name + "." + k, Before: nil, After: v})
+	}
+	return changes
+}
+
+// Details returns the supplied changes as the additional details of a change
+// log entry, each keyed by its field and encoded as JSON, e.g.
+// diff.email: {"before":"jdoe@example.com","after":"jane@example.com"}.
+// It returns nil if nothing changed.
+func Details(changes ...[]Change) managed.AdditionalDetails {
+	var ad managed.AdditionalDetails
+	for _, cs := range changes {
+		for _, c := range cs {
+			b, err := json.Marshal(c)
+			if err != nil {
+				// Only values of the types of the fields of managed resources
+				// are recorded, which always encode.
+				continue
+			}
+			if ad == nil {
+				ad = managed.AdditionalDetails{}
+			}
+			ad[KeyPrefix+c.Field] = string(b)
+		}
+	}
+	return ad
+}
+
+// nullable returns the supplied value, or nil if it is not set.
+func nullable(v string, ok bool) any {
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+// redactURL redacts the password and query values of the supplied URL.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		// A value that is not a URL may hold anything.
+		return Redacted
+	}
+	if q := u.Query(); len(q) > 0 {
+		for k := range q {
+			q[k] = []string{Redacted}
+		}
+		u.RawQuery = q.Encode()
+	}
+	return u.Redacted()
+}
+
+// redactURLs redacts the passwords and query values of the supplied URLs.
+func redactURLs(raw []string) []string {
+	if raw == nil {
+		return nil
+	}
+	redacted := make([]string, len(raw))
+	for i, r := range raw {
+		redacted[i] = redactURL(r)
+	}
+	return redacted
+}
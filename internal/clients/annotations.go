@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+// criticalAnnotationsBackoff bounds the retries of the updates of critical
+// annotations, each retrying conflicts a few times already. The annotations
+// are retried for about ten seconds overall.
+var criticalAnnotationsBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Cap:      5 * time.Second,
+}
+
+// A CriticalAnnotationUpdater persists the critical annotations of managed
+// resources, such as the external name set once their Pocket ID object is
+// created, retrying for longer than crossplane-runtime does on its own. An
+// external name that is lost leaves the provider unable to tell whether the
+// object was created, or to find it again if it is named after IDs such as
+// the clientID:groupID of an OIDCClientGroupBinding.
+type CriticalAnnotationUpdater struct {
+	updater managed.CriticalAnnotationUpdater
+	backoff wait.Backoff
+}
+
+// NewCriticalAnnotationUpdater returns a CriticalAnnotationUpdater retrying
+// the retrying updater of crossplane-runtime, which re-applies the annotations
+// to the latest version of a managed resource on conflict.
+func NewCriticalAnnotationUpdater(c client.Client) *CriticalAnnotationUpdater {
+	return &CriticalAnnotationUpdater{
+		updater: managed.NewRetryingCriticalAnnotationUpdater(c),
+		backoff: criticalAnnotationsBackoff,
+	}
+}
+
+// UpdateCriticalAnnotations persists the annotations of the supplied managed
+// resource. Errors are retried unless the resource is gone or the context is
+// done.
+func (u *CriticalAnnotationUpdater) UpdateCriticalAnnotations(ctx context.Context, o client.Object) error {
+	return retry.OnError(u.backoff, func(err error) bool {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !kerrors.IsNotFound(err)
+	}, func() error {
+		return u.updater.UpdateCriticalAnnotations(ctx, o)
+	})
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+func TestCriticalAnnotationUpdater(t *testing.T) {
+	conflict := kerrors.NewConflict(schema.GroupResource{}, "app-admins", nil)
+	unavailable := kerrors.NewServiceUnavailable("etcd is unavailable")
+	notFound := kerrors.NewNotFound(schema.GroupResource{}, "app-admins")
+
+	type want struct {
+		updates      int
+		externalName string
+		err          bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		errs   []error
+		want   want
+	}{
+		"Persisted": {
+			reason: "The external name should be persisted.",
+			want:   want{updates: 1, externalName: "client-id:group-id"},
+		},
+		"Conflicts": {
+			reason: "The external name should be re-applied to the latest version of the resource until it is persisted.",
+			errs:   []error{conflict, conflict, conflict, conflict, conflict, conflict, conflict},
+			want:   want{updates: 8, externalName: "client-id:group-id"},
+		},
+		"Unavailable": {
+			reason: "The external name should be persisted once the API server is available again.",
+			errs:   []error{unavailable, unavailable, unavailable, unavailable, unavailable, unavailable},
+			want:   want{updates: 7, externalName: "client-id:group-id"},
+		},
+		"Deleted": {
+			reason: "A resource that is gone should not be retried once crossplane-runtime gave up on it.",
+			errs:   []error{notFound, notFound, notFound, notFound, notFound},
+			want:   want{updates: 5, err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updates := 0
+			stored := &v1beta1.OIDCClientGroupBinding{ObjectMeta: metav1.ObjectMeta{Name: "app-admins"}}
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					stored.DeepCopyInto(obj.(*v1beta1.OIDCClientGroupBinding))
+					return nil
+				}),
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					updates++
+					if updates <= len(tc.errs) {
+						return tc.errs[updates-1]
+					}
+					obj.(*v1beta1.OIDCClientGroupBinding).DeepCopyInto(stored)
+					return nil
+				},
+			}
+			u := &CriticalAnnotationUpdater{
+				updater: managed.NewRetryingCriticalAnnotationUpdater(kube),
+				backoff: wait.Backoff{Steps: 5, Duration: time.Millisecond},
+			}
+
+			cr := &v1beta1.OIDCClientGroupBinding{ObjectMeta: metav1.ObjectMeta{Name: "app-admins"}}
+			meta.SetExternalName(cr, "client-id:group-id")
+			err := u.UpdateCriticalAnnotations(context.Background(), cr)
+
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nu.UpdateCriticalAnnotations(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.updates, updates); diff != "" {
+				t.Errorf("\n%s\nu.UpdateCriticalAnnotations(...): -want updates, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(stored)); diff != "" {
+				t.Errorf("\n%s\nu.UpdateCriticalAnnotations(...): -want persisted external name, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// AttributeRequests returns an ExternalClient sending the requests of the
+// supplied one on behalf of the managed resources it reconciles, identified
+// as <kind>/<name> through the X-Request-Source header. Managed resources
+// whose requests are rate limited or short-circuited are requeued by
+// HonorRetryAfter.
+func AttributeRequests(kind string, e managed.ExternalClient) managed.ExternalClient {
+	return &attributed{ExternalClient: e, kind: kind}
+}
+
+type attributed struct {
+	managed.ExternalClient
+	kind string
+}
+
+func (a *attributed) source(ctx context.Context, mg resource.Managed) context.Context {
+	return pocketid.WithRequestSource(ctx, a.kind+"/"+mg.GetName())
+}
+
+func (a *attributed) done(mg resource.Managed, err error) {
+	recordRateLimited(a.kind+"/"+mg.GetName(), err)
+	reportAvailability(mg, err)
+	reportProblems(mg, err)
+}
+
+func (a *attributed) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	res, err := a.ExternalClient.Observe(a.source(ctx, mg), mg)
+	a.done(mg, err)
+	return res, err
+}
+
+func (a *attributed) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	res, err := a.ExternalClient.Create(a.source(ctx, mg), mg)
+	a.done(mg, err)
+	return res, err
+}
+
+func (a *attributed) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	res, err := a.ExternalClient.Update(a.source(ctx, mg), mg)
+	a.done(mg, err)
+	return res, err
+}
+
+func (a *attributed) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	res, err := a.ExternalClient.Delete(a.source(ctx, mg), mg)
+	a.done(mg, err)
+	return res, err
+}
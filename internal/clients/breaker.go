@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// TypeProviderUnavailable is the condition reported by managed resources
+// that cannot be reconciled because the Pocket ID API keeps failing.
+const TypeProviderUnavailable xpv1.ConditionType = "ProviderUnavailable"
+
+// breakers holds the circuit breaker shared by all the clients of each
+// endpoint, whichever ProviderConfig they were built from.
+var breakers = struct {
+	sync.Mutex
+	m map[string]*pocketid.CircuitBreaker
+}{m: map[string]*pocketid.CircuitBreaker{}}
+
+// circuitBreaker returns the circuit breaker shared by the clients of the
+// supplied endpoint.
+func circuitBreaker(endpoint string) *pocketid.CircuitBreaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+
+	b, ok := breakers.m[endpoint]
+	if !ok {
+		b = pocketid.NewCircuitBreaker(pocketid.DefaultBreakerThreshold, pocketid.DefaultBreakerCooldown)
+		breakers.m[endpoint] = b
+	}
+	return b
+}
+
+// reportAvailability sets the ProviderUnavailable condition of the supplied
+// managed resource when its requests were short-circuited by an open circuit
+// breaker, and clears it once they are sent again.
+func reportAvailability(mg resource.Managed, err error) {
+	ue := &pocketid.UnavailableError{}
+	switch {
+	case errors.As(err, &ue):
+		mg.SetConditions(xpv1.Condition{
+			Type:               TypeProviderUnavailable,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "CircuitOpen",
+			Message:            ue.Error(),
+		})
+	case mg.GetCondition(TypeProviderUnavailable).Status == corev1.ConditionTrue:
+		mg.SetConditions(xpv1.Condition{
+			Type:               TypeProviderUnavailable,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "CircuitClosed",
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+func TestReportAvailability(t *testing.T) {
+	mg := &fake.Managed{}
+
+	reportAvailability(mg, errors.New("boom"))
+	if diff := cmp.Diff(corev1.ConditionUnknown, mg.GetCondition(TypeProviderUnavailable).Status); diff != "" {
+		t.Errorf("reportAvailability(...): -want no condition for other errors, +got:\n%s\n", diff)
+	}
+
+	reportAvailability(mg, &pocketid.UnavailableError{Endpoint: "https://id.example.org"})
+	if diff := cmp.Diff(corev1.ConditionTrue, mg.GetCondition(TypeProviderUnavailable).Status); diff != "" {
+		t.Errorf("reportAvailability(...): -want the condition while the circuit is open, +got:\n%s\n", diff)
+	}
+
+	reportAvailability(mg, nil)
+	if diff := cmp.Diff(corev1.ConditionFalse, mg.GetCondition(TypeProviderUnavailable).Status); diff != "" {
+		t.Errorf("reportAvailability(...): -want the condition cleared once requests succeed, +got:\n%s\n", diff)
+	}
+}
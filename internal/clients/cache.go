@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// clientTTL is how long a cached client is kept after it was last used.
+const clientTTL = 15 * time.Minute
+
+// A cachedClient is a client cached along with when it was last used.
+type cachedClient struct {
+	client *pocketid.Client
+	used   time.Time
+}
+
+// cache holds the clients built so far, keyed by a hash of their
+// configuration, so that reconciles share their connections to Pocket ID
+// instead of opening new ones. A client built from changed credentials or
+// settings has a different key, and the client it replaces expires once it
+// is no longer used.
+var cache = struct {
+	sync.Mutex
+	m map[string]*cachedClient
+}{m: map[string]*cachedClient{}}
+
+// NewClient returns a Pocket ID client for the supplied configuration, reusing
+// the one built for an identical configuration if any. Clients of the same
+// endpoint share its circuit breaker.
+func NewClient(cfg pocketid.Config) (*pocketid.Client, error) {
+	cfg.CircuitBreaker = circuitBreaker(cfg.Endpoint)
+	key, err := cacheKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+
+	now := time.Now()
+	for k, c := range cache.m {
+		if now.Sub(c.used) > clientTTL {
+			c.client.CloseIdleConnections()
+			delete(cache.m, k)
+		}
+	}
+
+	if c, ok := cache.m[key]; ok {
+		c.used = now
+		return c.client, nil
+	}
+
+	client, err := pocketid.NewClientFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cache.m[key] = &cachedClient{client: client, used: now}
+	return client, nil
+}
+
+// cacheKey returns a hash of the supplied configuration, so that the API keys
+// it contains are not kept in clear as map keys.
+func cacheKey(cfg pocketid.Config) (string, error) {
+	// The rate limiter is shared by the clients of a ProviderConfig, and only
+	// identified by its address.
+	limiter := fmt.Sprintf("%p", cfg.RateLimiter)
+	cfg.RateLimiter = nil
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("cannot hash client configuration: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(data)
+	h.Write([]byte(limiter))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+func TestNewClient(t *testing.T) {
+	cfg := pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key"}
+
+	first, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %v", err)
+	}
+
+	if second, _ := NewClient(cfg); second != first {
+		t.Errorf("NewClient(...): want the client of an identical configuration to be reused")
+	}
+
+	rotated := cfg
+	rotated.APIKey = "rotated"
+	if other, _ := NewClient(rotated); other == first {
+		t.Errorf("NewClient(...): want a new client when the credentials change")
+	}
+
+	key, _ := cacheKey(cfg)
+	cache.Lock()
+	cache.m[key].used = time.Now().Add(-2 * clientTTL)
+	cache.Unlock()
+
+	if expired, _ := NewClient(cfg); expired == first {
+		t.Errorf("NewClient(...): want a new client once the cached one expired")
+	}
+
+	if _, err := NewClient(pocketid.Config{}); err == nil {
+		t.Errorf("NewClient(...): want an error for an invalid configuration")
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clients builds Pocket ID API clients from ProviderConfigs.
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/version"
+)
+
+const (
+	errGetPreviousAPIKey  = "cannot get previous API key"
+	errGetClientSecret    = "cannot get OAuth client secret"
+	errGetHeader          = "cannot get header"
+	errGetCABundle        = "cannot get CA bundle"
+	errGetClientCert      = "cannot get client certificate"
+	errUnknownTLSVersion  = "unknown TLS version"
+	errUnknownCipherSuite = "unknown or insecure TLS cipher suite"
+)
+
+// tlsVersions maps the TLS versions accepted by a ProviderConfig to their
+// crypto/tls identifiers.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// userAgent identifies the provider and its pod, whose host name is the name
+// of the pod.
+var userAgent = func() string {
+	ua := "provider-pocketid/" + version.Version
+	if host, err := os.Hostname(); err == nil {
+		ua += " (" + host + ")"
+	}
+	return ua
+}()
+
+// Credentials are the JSON form of the credentials of a ProviderConfig, for
+// secrets shared with other consumers of the Pocket ID API.
+type Credentials struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	APIKey   string `json:"apiKey"`
+}
+
+// ParseCredentials parses credentials that are either a bare API key or a
+// JSON object. Surrounding whitespace, such as the trailing newline of API
+// keys written to files or environment variables by an external agent, is
+// ignored.
+func ParseCredentials(data []byte) Credentials {
+	data = bytes.TrimSpace(data)
+	creds := Credentials{}
+	if bytes.HasPrefix(data, []byte("{")) && json.Unmarshal(data, &creds) == nil && creds.APIKey != "" {
+		return creds
+	}
+	return Credentials{APIKey: string(data)}
+}
+
+// NewConfig returns the configuration of a Pocket ID client connecting to the
+// server described by the supplied ProviderConfig with the supplied
+// credentials. The endpoint of JSON credentials is only used when the
+// ProviderConfig does not specify one.
+func NewConfig(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig, data []byte) (pocketid.Config, error) {
+	creds := ParseCredentials(data)
+	cfg := pocketid.Config{
+		Endpoint:          pc.Spec.Endpoint,
+		FailoverEndpoints: pc.Spec.FailoverEndpoints,
+		APIKey:            creds.APIKey,
+		UserAgent:         pc.Spec.UserAgent,
+		MaxRetries:        pc.Spec.MaxRetries,
+		RateLimiter:       rateLimiter(pc),
+		Logger:            requestLogger,
+	}
+	if ref := pc.Spec.EndpointRef; ref != nil {
+		cfg.Endpoint = serviceEndpoint(ref)
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = creds.Endpoint
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = userAgent
+	}
+	if pc.Spec.Timeout != nil {
+		cfg.Timeout = pc.Spec.Timeout.Duration
+	}
+	if pc.Spec.ReadTimeout != nil {
+		cfg.ReadTimeout = pc.Spec.ReadTimeout.Duration
+	}
+	if pc.Spec.WriteTimeout != nil {
+		cfg.WriteTimeout = pc.Spec.WriteTimeout.Duration
+	}
+	if pc.Spec.UploadTimeout != nil {
+		cfg.UploadTimeout = pc.Spec.UploadTimeout.Duration
+	}
+	if pc.Spec.RetryBackoff != nil {
+		cfg.RetryBackoff = pc.Spec.RetryBackoff.Duration
+	}
+	if pc.Spec.MaxRetryBackoff != nil {
+		cfg.MaxRetryBackoff = pc.Spec.MaxRetryBackoff.Duration
+	}
+	if pc.Spec.ReadCacheTTL != nil {
+		cfg.ReadCacheTTL = pc.Spec.ReadCacheTTL.Duration
+	}
+
+	if ref := pc.Spec.Credentials.PreviousSecretRef; ref != nil {
+		key, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: ref})
+		if err != nil {
+			return pocketid.Config{}, errors.Wrap(err, errGetPreviousAPIKey)
+		}
+		cfg.PreviousAPIKey = ParseCredentials(key).APIKey
+	}
+
+	if o := pc.Spec.Credentials.OAuth; pc.Spec.Credentials.Source == apisv1alpha1.CredentialsSourceOAuth && o != nil {
+		cfg.OAuth = &pocketid.OAuthConfig{
+			TokenURL:           o.TokenURL,
+			ClientID:           o.ClientID,
+			Scopes:             o.Scopes,
+			FederatedTokenFile: o.FederatedTokenFile,
+		}
+		if o.ClientSecretRef != nil {
+			secret, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: o.ClientSecretRef})
+			if err != nil {
+				return pocketid.Config{}, errors.Wrap(err, errGetClientSecret)
+			}
+			cfg.OAuth.ClientSecret = strings.TrimSpace(string(secret))
+		}
+	}
+
+	if len(pc.Spec.Headers) > 0 || len(pc.Spec.HeadersFrom) > 0 {
+		cfg.Headers = make(map[string]string, len(pc.Spec.Headers)+len(pc.Spec.HeadersFrom))
+	}
+	for k, v := range pc.Spec.Headers {
+		cfg.Headers[k] = v
+	}
+	for _, h := range pc.Spec.HeadersFrom {
+		v, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: &h.SecretRef})
+		if err != nil {
+			return pocketid.Config{}, errors.Wrapf(err, "%s %s", errGetHeader, h.Name)
+		}
+		cfg.Headers[h.Name] = strings.TrimSpace(string(v))
+	}
+
+	if p := pc.Spec.Proxy; p != nil {
+		cfg.ProxyURL = p.URL
+		cfg.NoProxy = p.NoProxy
+	}
+
+	t := pc.Spec.TLS
+	if t == nil {
+		return cfg, nil
+	}
+
+	if t.CABundle != "" {
+		cfg.CABundle = []byte(t.CABundle)
+	}
+	if t.CABundleSecretRef != nil {
+		ca, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: t.CABundleSecretRef})
+		if err != nil {
+			return pocketid.Config{}, errors.Wrap(err, errGetCABundle)
+		}
+		cfg.CABundle = ca
+	}
+
+	if ref := t.ClientCertificateSecretRef; ref != nil {
+		cert, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{SecretReference: *ref, Key: corev1.TLSCertKey}})
+		if err != nil {
+			return pocketid.Config{}, errors.Wrap(err, errGetClientCert)
+		}
+		key, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{SecretReference: *ref, Key: corev1.TLSPrivateKeyKey}})
+		if err != nil {
+			return pocketid.Config{}, errors.Wrap(err, errGetClientCert)
+		}
+		cfg.ClientCertificate, cfg.ClientKey = cert, key
+	}
+
+	cfg.InsecureSkipVerify = t.InsecureSkipTLSVerify
+
+	if t.MinVersion != "" {
+		v, ok := tlsVersions[t.MinVersion]
+		if !ok {
+			return pocketid.Config{}, errors.Errorf("%s %q", errUnknownTLSVersion, t.MinVersion)
+		}
+		cfg.MinTLSVersion = v
+	}
+
+	for _, name := range t.CipherSuites {
+		id, ok := cipherSuite(name)
+		if !ok {
+			return pocketid.Config{}, errors.Errorf("%s %q", errUnknownCipherSuite, name)
+		}
+		cfg.CipherSuites = append(cfg.CipherSuites, id)
+	}
+
+	return cfg, nil
+}
+
+// serviceEndpoint returns the URL of the supplied Service port, using its
+// cluster DNS name without the cluster domain so it does not depend on it.
+func serviceEndpoint(ref *apisv1alpha1.ServiceReference) string {
+	scheme := ref.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%d", scheme, ref.Name, ref.Namespace, ref.Port)
+}
+
+// cipherSuite returns the identifier of the named cipher suite. Only the
+// suites Go considers secure are accepted.
+func cipherSuite(name string) (uint16, bool) {
+	for _, s := range tls.CipherSuites() {
+		if s.Name == name {
+			return s.ID, true
+		}
+	}
+	return 0, false
+}
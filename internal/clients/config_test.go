@@ -0,0 +1,341 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+var errBoom = errors.New("boom")
+
+func TestNewConfig(t *testing.T) {
+	caRef := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "ca", Namespace: "crossplane-system"},
+		Key:             "ca.crt",
+	}
+
+	type args struct {
+		kube   client.Client
+		pc     *apisv1alpha1.ProviderConfig
+		apiKey []byte
+	}
+
+	type want struct {
+		cfg pocketid.Config
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoTLS": {
+			reason: "A ProviderConfig without TLS settings should only configure the endpoint and API key.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Endpoint: "https://id.example.com"}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent},
+			},
+		},
+		"TrailingNewline": {
+			reason: "The trailing newline of an API key read from a file should be ignored.",
+			args: args{
+				pc:     &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Endpoint: "https://id.example.com"}},
+				apiKey: []byte("key\n"),
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent},
+			},
+		},
+		"JSONCredentials": {
+			reason: "The endpoint and API key of JSON credentials should be used when the ProviderConfig has no endpoint.",
+			args: args{
+				pc:     &apisv1alpha1.ProviderConfig{},
+				apiKey: []byte(`{"endpoint": "https://id.example.com", "apiKey": "key"}`),
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent},
+			},
+		},
+		"JSONCredentialsEndpointOverridden": {
+			reason: "The endpoint of a ProviderConfig should take precedence over the one of JSON credentials.",
+			args: args{
+				pc:     &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Endpoint: "https://id.example.org"}},
+				apiKey: []byte(`{"endpoint": "https://id.example.com", "apiKey": "key"}`),
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.org", APIKey: "key", UserAgent: userAgent},
+			},
+		},
+		"FailoverEndpoints": {
+			reason: "The failover endpoints of a ProviderConfig should be passed to the client.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint:          "https://id.example.com",
+					FailoverEndpoints: []string{"https://id.example.org"},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", FailoverEndpoints: []string{"https://id.example.org"}, APIKey: "key", UserAgent: userAgent},
+			},
+		},
+		"EndpointRef": {
+			reason: "A ProviderConfig referencing a Service should use its cluster DNS name as the endpoint.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					EndpointRef: &apisv1alpha1.ServiceReference{Name: "pocket-id", Namespace: "identity", Port: 1411},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "http://pocket-id.identity.svc:1411", APIKey: "key", UserAgent: userAgent},
+			},
+		},
+		"Retries": {
+			reason: "The timeout and retry policy of a ProviderConfig should be passed to the client.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint:        "https://id.example.com",
+					Timeout:         &metav1.Duration{Duration: 5 * time.Second},
+					MaxRetries:      3,
+					RetryBackoff:    &metav1.Duration{Duration: 200 * time.Millisecond},
+					MaxRetryBackoff: &metav1.Duration{Duration: time.Second},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, Timeout: 5 * time.Second, MaxRetries: 3, RetryBackoff: 200 * time.Millisecond, MaxRetryBackoff: time.Second},
+			},
+		},
+		"Timeouts": {
+			reason: "The per-operation timeouts of a ProviderConfig should be passed to the client.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint:      "https://id.example.com",
+					ReadTimeout:   &metav1.Duration{Duration: 5 * time.Second},
+					WriteTimeout:  &metav1.Duration{Duration: 10 * time.Second},
+					UploadTimeout: &metav1.Duration{Duration: time.Minute},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, ReadTimeout: 5 * time.Second, WriteTimeout: 10 * time.Second, UploadTimeout: time.Minute},
+			},
+		},
+		"ReadCacheTTL": {
+			reason: "The read cache TTL of a ProviderConfig should be passed to the client.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint:     "https://id.example.com",
+					ReadCacheTTL: &metav1.Duration{Duration: 5 * time.Second},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, ReadCacheTTL: 5 * time.Second},
+			},
+		},
+		"UserAgent": {
+			reason: "The User-Agent of a ProviderConfig should override the default one.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint:  "https://id.example.com",
+					UserAgent: "platform-team",
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: "platform-team"},
+			},
+		},
+		"PreviousAPIKey": {
+			reason: "The previous API key of a ProviderConfig being rotated should be passed to the client.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"previousApiKey": []byte("old")}
+					return nil
+				})},
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					Credentials: apisv1alpha1.ProviderCredentials{PreviousSecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "pocketid", Namespace: "crossplane-system"},
+						Key:             "previousApiKey",
+					}},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, PreviousAPIKey: "old"},
+			},
+		},
+		"Headers": {
+			reason: "Headers of a ProviderConfig, including those read from secrets, should be passed to the client.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("secret\n")}
+					return nil
+				})},
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					Headers:  map[string]string{"CF-Access-Client-Id": "id"},
+					HeadersFrom: []apisv1alpha1.HeaderFromSecret{{
+						Name:      "CF-Access-Client-Secret",
+						SecretRef: xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "cf", Namespace: "crossplane-system"}, Key: "token"},
+					}},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, Headers: map[string]string{
+					"CF-Access-Client-Id":     "id",
+					"CF-Access-Client-Secret": "secret",
+				}},
+			},
+		},
+		"Proxy": {
+			reason: "The proxy of a ProviderConfig should be passed to the client.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					Proxy:    &apisv1alpha1.ProxyConfig{URL: "socks5://proxy:1080", NoProxy: []string{".svc.cluster.local"}},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, ProxyURL: "socks5://proxy:1080", NoProxy: []string{".svc.cluster.local"}},
+			},
+		},
+		"InlineCABundle": {
+			reason: "An inline CA bundle should be passed to the client.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					TLS:      &apisv1alpha1.TLSConfig{CABundle: "PEM"},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, CABundle: []byte("PEM")},
+			},
+		},
+		"SecretCABundle": {
+			reason: "A CA bundle referenced from a secret should be passed to the client.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"ca.crt": []byte("PEM")}
+					return nil
+				})},
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					TLS:      &apisv1alpha1.TLSConfig{CABundleSecretRef: caRef},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, CABundle: []byte("PEM")},
+			},
+		},
+		"TLSOptions": {
+			reason: "TLS verification, version and cipher suite options should be passed to the client.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					TLS: &apisv1alpha1.TLSConfig{
+						InsecureSkipTLSVerify: true,
+						MinVersion:            "1.3",
+						CipherSuites:          []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+					},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{
+					Endpoint:           "https://id.example.com",
+					APIKey:             "key",
+					UserAgent:          userAgent,
+					InsecureSkipVerify: true,
+					MinTLSVersion:      tls.VersionTLS13,
+					CipherSuites:       []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+				},
+			},
+		},
+		"InsecureCipherSuite": {
+			reason: "Cipher suites Go considers insecure should be rejected.",
+			args: args{
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					TLS:      &apisv1alpha1.TLSConfig{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+				}},
+			},
+			want: want{
+				err: errors.Errorf("%s %q", errUnknownCipherSuite, "TLS_RSA_WITH_RC4_128_SHA"),
+			},
+		},
+		"ClientCertificate": {
+			reason: "The certificate and key of a referenced TLS secret should be passed to the client.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{corev1.TLSCertKey: []byte("CERT"), corev1.TLSPrivateKeyKey: []byte("KEY")}
+					return nil
+				})},
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					TLS: &apisv1alpha1.TLSConfig{
+						ClientCertificateSecretRef: &xpv1.SecretReference{Name: "client", Namespace: "crossplane-system"},
+					},
+				}},
+			},
+			want: want{
+				cfg: pocketid.Config{Endpoint: "https://id.example.com", APIKey: "key", UserAgent: userAgent, ClientCertificate: []byte("CERT"), ClientKey: []byte("KEY")},
+			},
+		},
+		"SecretError": {
+			reason: "Errors getting the CA bundle secret should be returned.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				pc: &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+					Endpoint: "https://id.example.com",
+					TLS:      &apisv1alpha1.TLSConfig{CABundleSecretRef: caRef},
+				}},
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot get credentials secret"), errGetCABundle),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.args.apiKey == nil {
+				tc.args.apiKey = []byte("key")
+			}
+			got, err := NewConfig(context.Background(), tc.args.kube, tc.args.pc, tc.args.apiKey)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNewConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cfg, got); diff != "" {
+				t.Errorf("\n%s\nNewConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+)
+
+// Reasons why the credentials of a ProviderConfig cannot be used.
+const (
+	ReasonSecretNotFound xpv1.ConditionReason = "SecretNotFound"
+	ReasonKeyMissing     xpv1.ConditionReason = "KeyMissing"
+	ReasonEmptyAPIKey    xpv1.ConditionReason = "EmptyAPIKey"
+)
+
+// A CredentialsError explains why the credentials of a ProviderConfig cannot
+// be used.
+type CredentialsError struct {
+	Reason xpv1.ConditionReason
+	msg    string
+}
+
+func (e *CredentialsError) Error() string {
+	return e.msg
+}
+
+// ExtractCredentials returns the credentials of the supplied ProviderConfig.
+// It returns a CredentialsError if the secret holding them does not exist,
+// lacks the selected key, or if they are empty. ProviderConfigs authenticated
+// with OAuth have no such credentials.
+func ExtractCredentials(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig) ([]byte, error) {
+	cd := pc.Spec.Credentials
+	if cd.Source == apisv1alpha1.CredentialsSourceOAuth {
+		return nil, nil
+	}
+
+	var data []byte
+	switch ref := cd.SecretRef; {
+	case cd.Source == xpv1.CredentialsSourceSecret && ref != nil:
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, &CredentialsError{Reason: ReasonSecretNotFound, msg: fmt.Sprintf("credentials secret %s/%s does not exist", ref.Namespace, ref.Name)}
+			}
+			return nil, errors.Wrap(err, "cannot get credentials secret")
+		}
+		d, ok := s.Data[ref.Key]
+		if !ok {
+			return nil, &CredentialsError{Reason: ReasonKeyMissing, msg: fmt.Sprintf("credentials secret %s/%s has no key %q", ref.Namespace, ref.Name, ref.Key)}
+		}
+		data = d
+	default:
+		d, err := resource.CommonCredentialExtractor(ctx, cd.Source, kube, cd.CommonCredentialSelectors)
+		if err != nil {
+			return nil, err
+		}
+		data = d
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, &CredentialsError{Reason: ReasonEmptyAPIKey, msg: fmt.Sprintf("the API key read from the %s credentials source is empty", cd.Source)}
+	}
+	return data, nil
+}
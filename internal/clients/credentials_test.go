@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+)
+
+func TestExtractCredentials(t *testing.T) {
+	pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Credentials: apisv1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "pocketid", Namespace: "crossplane-system"},
+			Key:             "apiKey",
+		}},
+	}}}
+	withData := func(data map[string][]byte) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		})
+	}
+
+	type want struct {
+		data   []byte
+		reason xpv1.ConditionReason
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		want   want
+	}{
+		"Success": {
+			reason: "The selected key of the credentials secret should be returned.",
+			get:    withData(map[string][]byte{"apiKey": []byte("key")}),
+			want:   want{data: []byte("key")},
+		},
+		"SecretNotFound": {
+			reason: "A missing credentials secret should be reported as such.",
+			get:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "pocketid")),
+			want:   want{reason: ReasonSecretNotFound},
+		},
+		"KeyMissing": {
+			reason: "A credentials secret lacking the selected key should be reported as such.",
+			get:    withData(map[string][]byte{"credentials": []byte("key")}),
+			want:   want{reason: ReasonKeyMissing},
+		},
+		"EmptyAPIKey": {
+			reason: "Empty credentials should be reported as such.",
+			get:    withData(map[string][]byte{"apiKey": []byte("\n")}),
+			want:   want{reason: ReasonEmptyAPIKey},
+		},
+		"GetError": {
+			reason: "Other errors getting the credentials secret should be returned.",
+			get:    test.NewMockGetFn(errBoom),
+			want:   want{err: errors.Wrap(errBoom, "cannot get credentials secret")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ExtractCredentials(context.Background(), &test.MockClient{MockGet: tc.get}, pc)
+
+			var ce *CredentialsError
+			if errors.As(err, &ce) {
+				if diff := cmp.Diff(tc.want.reason, ce.Reason); diff != "" {
+					t.Errorf("\n%s\nExtractCredentials(...): -want reason, +got:\n%s\n", tc.reason, diff)
+				}
+			} else if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExtractCredentials(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, got); diff != "" {
+				t.Errorf("\n%s\nExtractCredentials(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
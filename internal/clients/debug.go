@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// requestLogger logs the requests of every client when set.
+var requestLogger logging.Logger
+
+// LogRequests makes every client built afterwards log its requests and
+// responses to the supplied logger at debug level, with the secrets they
+// contain redacted.
+func LogRequests(l logging.Logger) {
+	requestLogger = l
+}
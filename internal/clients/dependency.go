@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+)
+
+// A dependencyNotReadyError is returned when a referenced resource exists but
+// has not reported its Pocket ID identifier yet.
+type dependencyNotReadyError struct {
+	msg string
+}
+
+func (e *dependencyNotReadyError) Error() string {
+	return e.msg
+}
+
+// DependencyNotReady returns an error with the supplied message, reporting
+// that a referenced resource is not ready yet.
+func DependencyNotReady(msg string) error {
+	return &dependencyNotReadyError{msg: msg}
+}
+
+// IsDependencyNotReady reports whether err was caused by a referenced resource
+// that is not ready yet.
+func IsDependencyNotReady(err error) bool {
+	var nr *dependencyNotReadyError
+	return errors.As(err, &nr)
+}
+
+// IsTargetGone reports whether err indicates that an object a binding refers
+// to no longer exists, either in Kubernetes or in Pocket ID.
+func IsTargetGone(err error) bool {
+	return kerrors.IsNotFound(err) || IsDependencyNotReady(err)
+}
+
+// WaitForDependency marks a binding as waiting for a referenced resource.
+// The binding is reported as existing and up to date so that the managed
+// reconciler neither tries to create it nor records an error; the reference
+// watches requeue it as soon as the dependency reports its ID, and the poll
+// interval hook checks it again shortly in case the dependency is identified
+// by name. A binding that is being deleted is reported as absent so that its
+// finalizer is removed.
+func WaitForDependency(mg resource.Managed, err error) managed.ExternalObservation {
+	c := xpv1.Unavailable()
+	c.Reason = conditions.ReasonWaitingForDependency
+	c.Message = err.Error()
+	mg.SetConditions(c, conditions.WaitingForDependency(err))
+
+	return managed.ExternalObservation{
+		ResourceExists:   !meta.WasDeleted(mg),
+		ResourceUpToDate: true,
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+)
+
+func TestWaitForDependency(t *testing.T) {
+	notReady := errors.Wrap(DependencyNotReady(`user "jdoe" does not exist`), "cannot resolve user ID")
+
+	type want struct {
+		o      managed.ExternalObservation
+		reason string
+	}
+
+	cases := map[string]struct {
+		reason  string
+		deleted bool
+		want    want
+	}{
+		"Waiting": {
+			reason: "A binding waiting for a dependency should be reported as existing and up to date.",
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, reason: string(conditions.ReasonWaitingForDependency)},
+		},
+		"Deleted": {
+			reason:  "A deleted binding waiting for a dependency should be reported as absent.",
+			deleted: true,
+			want:    want{o: managed.ExternalObservation{ResourceUpToDate: true}, reason: string(conditions.ReasonWaitingForDependency)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1beta1.UserGroupBinding{}
+			if tc.deleted {
+				cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			}
+			got := WaitForDependency(cr, notReady)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nWaitForDependency(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, string(cr.GetCondition(conditions.TypeDependencyReady).Reason)); diff != "" {
+				t.Errorf("\n%s\nWaitForDependency(...): -want DependencyReady reason, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsTargetGone(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"NotFound": {
+			reason: "A referenced resource that does not exist should be gone.",
+			err:    kerrors.NewNotFound(schema.GroupResource{}, "jdoe"),
+			want:   true,
+		},
+		"NotReady": {
+			reason: "A referenced resource that is not ready should be gone.",
+			err:    errors.Wrap(DependencyNotReady("referenced user ID has not been resolved"), "cannot resolve user ID"),
+			want:   true,
+		},
+		"Other": {
+			reason: "Other errors should not be mistaken for a referenced resource being gone.",
+			err:    errors.New("boom"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsTargetGone(tc.err)); diff != "" {
+				t.Errorf("\n%s\nIsTargetGone(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// Creator returns the UID the owner claim of the Pocket ID objects created by
+// the supplied managed resource holds, or an empty string if the objects are
+// orphaned rather than deleted along with it: the orphan sweeper must leave
+// them alone once it is gone.
+func Creator(mg resource.Managed) string {
+	// Unset policies are the defaults of the CRDs.
+	p, d := mg.GetManagementPolicies(), mg.GetDeletionPolicy()
+	if len(p) == 0 {
+		p = xpv1.ManagementPolicies{xpv1.ManagementActionAll}
+	}
+	if d == "" {
+		d = xpv1.DeletionDelete
+	}
+	if !managed.NewManagementPoliciesResolver(true, p, d).ShouldDelete() {
+		return ""
+	}
+	return string(mg.GetUID())
+}
+
+// Owner returns the UID the owner claim of a Pocket ID object with the
+// supplied custom claims should hold once managed by the supplied managed
+// resource. Objects created by the provider are marked as owned by the managed
+// resource managing them now, which may have adopted them after the one that
+// created them was deleted with the Orphan policy or restored from a backup.
+// Objects created by people are left unmarked.
+func Owner(mg resource.Managed, claims map[string]string) string {
+	if pocketid.Owner(claims) == "" {
+		return ""
+	}
+	return Creator(mg)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+func TestOwner(t *testing.T) {
+	owned := map[string]string{pocketid.OwnerClaim: "uid-gone"}
+
+	cases := map[string]struct {
+		reason   string
+		deletion xpv1.DeletionPolicy
+		policies xpv1.ManagementPolicies
+		claims   map[string]string
+		want     string
+	}{
+		"Created": {
+			reason: "Objects created by the provider should be owned by the managed resource managing them.",
+			claims: owned,
+			want:   "uid-user",
+		},
+		"Adopted": {
+			reason: "Objects created by another managed resource should be re-stamped by the one adopting them.",
+			claims: map[string]string{pocketid.OwnerClaim: "uid-gone", "team": "platform"},
+			want:   "uid-user",
+		},
+		"CreatedByPeople": {
+			reason: "Objects created by people should be left unmarked.",
+			claims: map[string]string{"team": "platform"},
+		},
+		"OrphanPolicy": {
+			reason:   "Objects orphaned once their managed resource is deleted should be unmarked.",
+			deletion: xpv1.DeletionOrphan,
+			claims:   owned,
+		},
+		"ObserveOnly": {
+			reason:   "Objects of managed resources that may not delete them should be unmarked.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionUpdate},
+			claims:   owned,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &v1beta1.User{ObjectMeta: metav1.ObjectMeta{UID: "uid-user"}}
+			mg.SetDeletionPolicy(tc.deletion)
+			mg.SetManagementPolicies(tc.policies)
+			if diff := cmp.Diff(tc.want, Owner(mg, tc.claims)); diff != "" {
+				t.Errorf("\n%s\nOwner(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// AppConfigVariable represents a variable of the application configuration of
+// Pocket ID, whose values are all strings whatever their type
+type AppConfigVariable struct {
+	Key      string `json:"key"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	IsPublic bool   `json:"isPublic,omitempty"`
+}
+
+// AppConfig represents the application configuration of Pocket ID. The API
+// exchanges every value as a string, booleans being "true" or "false" and
+// durations a number of minutes.
+type AppConfig struct {
+	// General
+	AppName                   string `json:"appName"`
+	SessionDuration           string `json:"sessionDuration"`
+	EmailsVerified            string `json:"emailsVerified"`
+	DisableAnimations         string `json:"disableAnimations"`
+	AllowOwnAccountEdit       string `json:"allowOwnAccountEdit"`
+	AllowUserSignups          string `json:"allowUserSignups"`
+	SignupDefaultUserGroupIDs string `json:"signupDefaultUserGroupIDs"`
+	SignupDefaultCustomClaims string `json:"signupDefaultCustomClaims"`
+	AccentColor               string `json:"accentColor"`
+
+	// Email
+	SMTPHost                                   string `json:"smtpHost"`
+	SMTPPort                                   string `json:"smtpPort"`
+	SMTPFrom                                   string `json:"smtpFrom"`
+	SMTPUser                                   string `json:"smtpUser"`
+	SMTPPassword                               string `json:"smtpPassword"`
+	SMTPTLS                                    string `json:"smtpTls"`
+	SMTPSkipCertVerify                         string `json:"smtpSkipCertVerify"`
+	EmailLoginNotificationEnabled              string `json:"emailLoginNotificationEnabled"`
+	EmailOneTimeAccessAsUnauthenticatedEnabled string `json:"emailOneTimeAccessAsUnauthenticatedEnabled"`
+	EmailOneTimeAccessAsAdminEnabled           string `json:"emailOneTimeAccessAsAdminEnabled"`
+	EmailAPIKeyExpirationEnabled               string `json:"emailApiKeyExpirationEnabled"`
+
+	// LDAP
+	LDAPEnabled                        string `json:"ldapEnabled"`
+	LDAPURL                            string `json:"ldapUrl"`
+	LDAPBindDN                         string `json:"ldapBindDn"`
+	LDAPBindPassword                   string `json:"ldapBindPassword"`
+	LDAPBase                           string `json:"ldapBase"`
+	LDAPUserSearchFilter               string `json:"ldapUserSearchFilter"`
+	LDAPUserGroupSearchFilter          string `json:"ldapUserGroupSearchFilter"`
+	LDAPSkipCertVerify                 string `json:"ldapSkipCertVerify"`
+	LDAPAttributeUserUniqueIdentifier  string `json:"ldapAttributeUserUniqueIdentifier"`
+	LDAPAttributeUserUsername          string `json:"ldapAttributeUserUsername"`
+	LDAPAttributeUserEmail             string `json:"ldapAttributeUserEmail"`
+	LDAPAttributeUserFirstName         string `json:"ldapAttributeUserFirstName"`
+	LDAPAttributeUserLastName          string `json:"ldapAttributeUserLastName"`
+	LDAPAttributeUserProfilePicture    string `json:"ldapAttributeUserProfilePicture"`
+	LDAPAttributeGroupMember           string `json:"ldapAttributeGroupMember"`
+	LDAPAttributeGroupUniqueIdentifier string `json:"ldapAttributeGroupUniqueIdentifier"`
+	LDAPAttributeGroupName             string `json:"ldapAttributeGroupName"`
+	LDAPAttributeAdminGroup            string `json:"ldapAttributeAdminGroup"`
+	LDAPSoftDeleteUsers                string `json:"ldapSoftDeleteUsers"`
+}
+
+// GetAppConfig retrieves the whole application configuration, including the
+// variables only admins can read
+func (c *Client) GetAppConfig(ctx context.Context) (*AppConfig, error) {
+	return c.getAppConfig(ctx, "/api/application-configuration/all")
+}
+
+// GetPublicAppConfig retrieves the public variables of the application
+// configuration, the others being left empty
+func (c *Client) GetPublicAppConfig(ctx context.Context) (*AppConfig, error) {
+	return c.getAppConfig(ctx, "/api/application-configuration")
+}
+
+// UpdateAppConfig replaces the application configuration. Every variable is
+// set, so the configuration to update should be retrieved with GetAppConfig
+// first rather than built from scratch.
+func (c *Client) UpdateAppConfig(ctx context.Context, config AppConfig) (*AppConfig, error) {
+	vars, err := call[[]AppConfigVariable](ctx, c, http.MethodPut, "/api/application-configuration", "update application configuration", "application configuration", config)
+	if err != nil {
+		return nil, err
+	}
+	return appConfigFromVariables(*vars)
+}
+
+func (c *Client) getAppConfig(ctx context.Context, path string) (*AppConfig, error) {
+	vars, err := call[[]AppConfigVariable](ctx, c, http.MethodGet, path, "get application configuration", "application configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	return appConfigFromVariables(*vars)
+}
+
+// appConfigFromVariables decodes the application configuration from the list
+// of variables the API answers with. Unknown variables are ignored.
+func appConfigFromVariables(vars []AppConfigVariable) (*AppConfig, error) {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		values[v.Key] = v.Value
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode application configuration: %w", err)
+	}
+	var config AppConfig
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode application configuration: %w", err)
+	}
+	return &config, nil
+}
@@ -18,37 +18,24 @@ package pocketid
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"slices"
 )
 
+// userGroupIDsRequest represents the request payload for replacing the groups
+// of a user or the allowed groups of an OIDC client
+type userGroupIDsRequest struct {
+	UserGroupIDs []string `json:"userGroupIds"`
+}
+
 // AddUserToGroup adds a user to a group
 func (c *Client) AddUserToGroup(ctx context.Context, userID, groupID string) error {
-	resp, err := c.makeRequest(ctx, "POST", fmt.Sprintf("/api/users/%s/groups/%s", userID, groupID), nil)
-	if err != nil {
-		return fmt.Errorf("failed to add user to group: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	_, err = checkResponse(resp)
-	return err
+	return c.send(ctx, http.MethodPost, pathf("/api/users/%s/groups/%s", userID, groupID), "add user to group", nil)
 }
 
 // RemoveUserFromGroup removes a user from a group
 func (c *Client) RemoveUserFromGroup(ctx context.Context, userID, groupID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/api/users/%s/groups/%s", userID, groupID), nil)
-	if err != nil {
-		return fmt.Errorf("failed to remove user from group: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil // Binding doesn't exist, which is fine
-	}
-
-	_, err = checkResponse(resp)
-	return err
+	return c.remove(ctx, pathf("/api/users/%s/groups/%s", userID, groupID), "remove user from group")
 }
 
 // IsUserInGroup checks if a user is in a group
@@ -80,32 +67,21 @@ func (c *Client) IsUserInGroup(ctx context.Context, userID, groupID string) (boo
 	return false, nil
 }
 
+// SetUserGroups replaces the groups of a user with the given groups in a
+// single request
+func (c *Client) SetUserGroups(ctx context.Context, userID string, groupIDs []string) error {
+	req := userGroupIDsRequest{UserGroupIDs: nonNil(groupIDs)}
+	return c.send(ctx, http.MethodPut, pathf("/api/users/%s/user-groups", userID), "set user groups", req)
+}
+
 // AddClientToGroup adds an OIDC client to a group
 func (c *Client) AddClientToGroup(ctx context.Context, clientID, groupID string) error {
-	resp, err := c.makeRequest(ctx, "POST", fmt.Sprintf("/api/oidc/clients/%s/groups/%s", clientID, groupID), nil)
-	if err != nil {
-		return fmt.Errorf("failed to add client to group: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	_, err = checkResponse(resp)
-	return err
+	return c.send(ctx, http.MethodPost, pathf("/api/oidc/clients/%s/groups/%s", clientID, groupID), "add client to group", nil)
 }
 
 // RemoveClientFromGroup removes an OIDC client from a group
 func (c *Client) RemoveClientFromGroup(ctx context.Context, clientID, groupID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/api/oidc/clients/%s/groups/%s", clientID, groupID), nil)
-	if err != nil {
-		return fmt.Errorf("failed to remove client from group: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil // Binding doesn't exist, which is fine
-	}
-
-	_, err = checkResponse(resp)
-	return err
+	return c.remove(ctx, pathf("/api/oidc/clients/%s/groups/%s", clientID, groupID), "remove client from group")
 }
 
 // IsClientInGroup checks if an OIDC client is in a group
@@ -136,3 +112,19 @@ func (c *Client) IsClientInGroup(ctx context.Context, clientID, groupID string)
 
 	return false, nil
 }
+
+// SetClientGroups replaces the allowed groups of an OIDC client with the given
+// groups in a single request
+func (c *Client) SetClientGroups(ctx context.Context, clientID string, groupIDs []string) error {
+	req := userGroupIDsRequest{UserGroupIDs: nonNil(groupIDs)}
+	return c.send(ctx, http.MethodPut, pathf("/api/oidc/clients/%s/allowed-user-groups", clientID), "set client groups", req)
+}
+
+// nonNil makes sure an empty list is sent as [] rather than null, which would
+// not clear the existing groups
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
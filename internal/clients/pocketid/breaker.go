@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerThreshold is how many consecutive requests must fail for
+	// a circuit breaker to open.
+	DefaultBreakerThreshold = 5
+
+	// DefaultBreakerCooldown is how long an open circuit breaker rejects
+	// requests before letting one through to probe the API.
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// A CircuitBreaker stops requests from being sent to an endpoint after
+// several consecutive ones failed, so that a Pocket ID instance that is down
+// is not sent requests it cannot serve by every managed resource. Once open,
+// it lets a single request through every cooldown to probe whether the
+// endpoint recovered, closing again when one succeeds. A nil CircuitBreaker
+// never opens.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a circuit breaker opening after threshold
+// consecutive failures, for cooldown at a time.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow returns how long until a request may be sent, or zero if it may be
+// sent now.
+func (b *CircuitBreaker) allow() time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return 0
+	}
+	if d := b.cooldown - time.Since(b.openedAt); d > 0 {
+		return d
+	}
+	if b.probing {
+		return b.cooldown
+	}
+	b.probing = true
+	return 0
+}
+
+// record records the outcome of a request allowed by the circuit breaker.
+// Requests cancelled by their caller do not tell whether the API is
+// available, and are not counted.
+func (b *CircuitBreaker) record(req *http.Request, resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	switch {
+	case err != nil && req.Context().Err() != nil:
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openedAt = time.Now()
+		}
+	default:
+		b.failures = 0
+	}
+}
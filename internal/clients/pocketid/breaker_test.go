@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var requests int
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	b := NewCircuitBreaker(2, 50*time.Millisecond)
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key", CircuitBreaker: b})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := c.ListUsers(ctx, ListOptions{}); StatusCode(err) != http.StatusServiceUnavailable {
+			t.Fatalf("c.ListUsers(...): want the error of the API, got %v", err)
+		}
+	}
+
+	ue := &UnavailableError{}
+	if _, err := c.ListUsers(ctx, ListOptions{}); !errors.As(err, &ue) {
+		t.Fatalf("c.ListUsers(...): want an UnavailableError once the circuit opened, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("c.ListUsers(...): want no request sent while the circuit is open, got %d requests", requests)
+	}
+
+	time.Sleep(ue.RetryAfter)
+	status = http.StatusOK
+	if _, err := c.ListUsers(ctx, ListOptions{}); err != nil {
+		t.Fatalf("c.ListUsers(...): want the probe to succeed after the cooldown, got %v", err)
+	}
+	if _, err := c.ListUsers(ctx, ListOptions{}); err != nil {
+		t.Errorf("c.ListUsers(...): want the circuit closed after a successful probe, got %v", err)
+	}
+}
@@ -19,32 +19,144 @@ package pocketid
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"net/url"
-	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
 )
 
 const (
-	DefaultTimeout = 30 * time.Second
+	DefaultTimeout      = 30 * time.Second
+	DefaultRetryBackoff = time.Second
+
+	DefaultMaxRetryBackoff = 30 * time.Second
 )
 
+// unixSocketHost addresses the Unix domain socket of an endpoint such as
+// unix:///var/run/pocket-id.sock. The .invalid TLD can never resolve.
+const unixSocketHost = "pocket-id.invalid"
+
 // Config holds the configuration for Pocket ID client
 type Config struct {
 	Endpoint string
 	APIKey   string
-	Timeout  time.Duration
+
+	// Timeout of each attempt of a request, unless overridden for requests
+	// reading objects by ReadTimeout, changing them by WriteTimeout, or
+	// uploading files by UploadTimeout. Shorter deadlines of the context of
+	// a request are honoured.
+	Timeout       time.Duration
+	ReadTimeout   time.Duration
+	WriteTimeout  time.Duration
+	UploadTimeout time.Duration
+
+	// UserAgent identifies the client in the requests sent to the API.
+	UserAgent string
+
+	// Headers are added to every request sent to the API. A Host header
+	// overrides the host requests are addressed to.
+	Headers map[string]string
+
+	// FailoverEndpoints serve the same Pocket ID instance as Endpoint, and
+	// are tried in turn when it cannot be reached.
+	FailoverEndpoints []string
+
+	// PreviousAPIKey is used for requests rejected with APIKey while it is
+	// being rotated.
+	PreviousAPIKey string
+
+	// OAuth, when set, authenticates requests with access tokens instead of
+	// APIKey.
+	OAuth *OAuthConfig
+
+	// MaxRetries is how many times idempotent requests failing with a
+	// transient error are retried, waiting RetryBackoff before the first
+	// retry and doubling it for every subsequent one up to MaxRetryBackoff.
+	// Each delay is randomly shortened by up to half so that clients do not
+	// retry in lockstep.
+	MaxRetries      int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+
+	// RateLimiter, when set, delays requests so they do not exceed its rate.
+	// It may be shared by several clients.
+	RateLimiter *rate.Limiter
+
+	// ReadCacheTTL is how long responses to GET requests are reused, until
+	// the client sends any other request. Identical GET requests in flight
+	// are always sent once.
+	ReadCacheTTL time.Duration
+
+	// CircuitBreaker, when set, stops requests from being sent while the
+	// endpoint keeps failing. It may be shared by several clients.
+	CircuitBreaker *CircuitBreaker `json:"-"`
+
+	// CABundle is a PEM encoded bundle of certificate authorities trusted in
+	// addition to the system roots when verifying the server certificate.
+	CABundle []byte
+
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+
+	// MinTLSVersion is the minimum TLS version accepted, TLS 1.2 if unset.
+	MinTLSVersion uint16
+
+	// CipherSuites restricts the TLS 1.2 cipher suites offered, Go's secure
+	// defaults if unset.
+	CipherSuites []uint16
+
+	// ClientCertificate and ClientKey are the PEM encoded certificate and
+	// private key presented to servers that require mutual TLS.
+	ClientCertificate []byte
+	ClientKey         []byte
+
+	// ProxyURL is the proxy requests are sent through, instead of the one
+	// configured by the environment. NoProxy lists the hosts reached directly.
+	ProxyURL string
+	NoProxy  []string
+
+	// Logger, when set, logs every request and response at debug level, with
+	// the secrets they contain redacted.
+	Logger logging.Logger `json:"-"`
 }
 
 // Client is the Pocket ID API client
 type Client struct {
 	config     Config
 	httpClient *http.Client
+
+	// tokens supplies the access tokens of clients authenticated with OAuth.
+	tokens          oauth2.TokenSource
+	tokenHTTPClient *http.Client
+
+	// downloads fetches files from third-party hosts, such as the logos of
+	// OIDC clients, with the default TLS settings rather than those of the
+	// endpoint, and without going through its rate limiter or circuit
+	// breaker: a failing host must not make Pocket ID look unavailable.
+	downloads *http.Client
+
+	// reads coalesces and caches GET requests.
+	reads *readCache
+
+	// previousAPIKeyInUse records whether the last authenticated request was
+	// only accepted with the previous API key.
+	previousAPIKeyInUse atomic.Bool
 }
 
 // NewClient creates a new Pocket ID API client
@@ -52,30 +164,189 @@ func NewClient(config Config) *Client {
 	if config.Timeout == 0 {
 		config.Timeout = DefaultTimeout
 	}
+	for _, t := range []*time.Duration{&config.ReadTimeout, &config.WriteTimeout, &config.UploadTimeout} {
+		if *t == 0 {
+			*t = config.Timeout
+		}
+	}
+	if config.RetryBackoff == 0 {
+		config.RetryBackoff = DefaultRetryBackoff
+	}
+	if config.MaxRetryBackoff == 0 {
+		config.MaxRetryBackoff = DefaultMaxRetryBackoff
+	}
 
 	return &Client{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
+		config:     config,
+		httpClient: &http.Client{},
+		downloads:  &http.Client{},
+		reads:      newReadCache(config.ReadCacheTTL),
 	}
 }
 
 // NewClientFromCredentials creates a new client from credential data
 func NewClientFromCredentials(endpoint string, apiKey string) (*Client, error) {
-	var config Config
-	if endpoint == "" {
+	return NewClientFromConfig(Config{Endpoint: endpoint, APIKey: apiKey})
+}
+
+// NewClientFromConfig validates the configuration and creates a new client
+// whose HTTP transport honours its TLS settings
+func NewClientFromConfig(config Config) (*Client, error) {
+	if config.Endpoint == "" {
 		return nil, fmt.Errorf("endpoint is required")
 	}
-	if apiKey == "" {
+	if config.APIKey == "" && config.OAuth == nil {
 		return nil, fmt.Errorf("apiKey is required in credentials")
 	}
 
 	// Ensure Endpoint doesn't end with /
-	config.Endpoint = strings.TrimSuffix(endpoint, "/")
-	config.APIKey = apiKey
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+
+	// Endpoints such as unix:///var/run/pocket-id.sock are reached through a
+	// Unix domain socket, using a reserved host name to address it
+	socket := ""
+	if strings.HasPrefix(config.Endpoint, "unix://") {
+		if len(config.FailoverEndpoints) > 0 {
+			return nil, fmt.Errorf("failover endpoints are not supported with a Unix domain socket endpoint")
+		}
+		socket = strings.TrimPrefix(config.Endpoint, "unix://")
+		config.Endpoint = "http://" + unixSocketHost
+	}
+
+	transport, err := transportFor(config, socket)
+	if err != nil {
+		return nil, err
+	}
+
+	// Responses are decompressed before they are logged.
+	ct := newCompressingTransport(transport)
+	c := NewClient(config)
+	c.httpClient.Transport = ct
+
+	if len(config.FailoverEndpoints) > 0 {
+		ft, err := newFailoverTransport(ct, config.Endpoint, config.FailoverEndpoints)
+		if err != nil {
+			return nil, err
+		}
+		c.httpClient.Transport = ft
+	}
+	if config.Logger != nil {
+		c.httpClient.Transport = newLoggingTransport(c.httpClient.Transport, config.Logger)
+		c.downloads.Transport = newLoggingTransport(http.DefaultTransport, config.Logger)
+	}
+
+	if config.OAuth != nil {
+		// The authorization server is reached directly rather than through
+		// the Unix domain socket or failover endpoints of the API.
+		tt, err := transportFor(config, "")
+		if err != nil {
+			return nil, err
+		}
+		c.tokenHTTPClient = &http.Client{Transport: tt, Timeout: config.Timeout}
+		if config.Logger != nil {
+			c.tokenHTTPClient.Transport = newLoggingTransport(tt, config.Logger)
+		}
+		c.tokens = newTokenSource(config.OAuth, c.tokenHTTPClient)
+	}
+	return c, nil
+}
+
+// newTransport returns an HTTP transport configured from the TLS settings of
+// the supplied configuration
+func newTransport(config Config) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		CipherSuites:       config.CipherSuites,
+		InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec // Explicitly requested for lab environments.
+	}
+	if config.MinTLSVersion != 0 {
+		t.TLSClientConfig.MinVersion = config.MinTLSVersion
+	}
+
+	if len(config.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(config.CABundle) {
+			return nil, errors.New("CA bundle does not contain any PEM encoded certificate")
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
 
-	return NewClient(config), nil
+	if len(config.ClientCertificate) > 0 || len(config.ClientKey) > 0 {
+		cert, err := tls.X509KeyPair(config.ClientCertificate, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		t.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if config.ProxyURL != "" {
+		proxy := (&httpproxy.Config{
+			HTTPProxy:  config.ProxyURL,
+			HTTPSProxy: config.ProxyURL,
+			NoProxy:    strings.Join(config.NoProxy, ","),
+		}).ProxyFunc()
+		t.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy(req.URL)
+		}
+	}
+
+	if err := tuneTransport(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// dialUnixSocket makes the supplied transport connect to the Unix domain
+// socket at the supplied path for requests to unixSocketHost, without going
+// through a proxy
+func dialUnixSocket(t *http.Transport, socket string) {
+	dial, proxy := t.DialContext, t.Proxy
+	t.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if addr == unixSocketHost+":80" {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		}
+		return dial(ctx, network, addr)
+	}
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		if req.URL.Host == unixSocketHost || proxy == nil {
+			return nil, nil
+		}
+		return proxy(req)
+	}
+}
+
+// CloseIdleConnections closes the idle connections of the client
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+	c.downloads.CloseIdleConnections()
+	if c.tokenHTTPClient != nil {
+		c.tokenHTTPClient.CloseIdleConnections()
+	}
+}
+
+// url returns the URL of the supplied API path, which may include a query,
+// below the path of the endpoint so Pocket ID can be served from a subpath
+func (c *Client) url(path string) (string, error) {
+	base, err := url.Parse(c.config.Endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint: %w", err)
+	}
+	ref, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %w", err)
+	}
+
+	u := *base
+	u.Path = strings.TrimRight(base.Path, "/") + "/" + strings.TrimLeft(ref.Path, "/")
+	u.RawPath = strings.TrimRight(base.EscapedPath(), "/") + "/" + strings.TrimLeft(ref.EscapedPath(), "/")
+	u.RawQuery = ref.RawQuery
+	u.Fragment = ""
+	return u.String(), nil
 }
 
 // makeRequest performs HTTP request with proper authentication
@@ -89,21 +360,246 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.config.Endpoint+path, bodyReader)
+	u, err := c.url(path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("X-API-KEY", c.config.APIKey)
+	c.setHeaders(req)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	return c.httpClient.Do(req)
+	if method == http.MethodGet {
+		return c.reads.get(req, c.authenticate)
+	}
+	return c.write(req)
+}
+
+// write sends a request changing objects, dropping the cached responses to
+// reads that may no longer be current.
+func (c *Client) write(req *http.Request) (*http.Response, error) {
+	c.reads.invalidate()
+	defer c.reads.invalidate()
+	return c.authenticate(req)
+}
+
+// setHeaders adds the User-Agent, the source and idempotency key recorded in
+// the context and the configured headers to a request
+func (c *Client) setHeaders(req *http.Request) {
+	if c.config.UserAgent != "" {
+		req.Header.Set("User-Agent", c.config.UserAgent)
+	}
+	if src, ok := req.Context().Value(requestSourceKey{}).(string); ok {
+		req.Header.Set("X-Request-Source", src)
+	}
+	if key, ok := req.Context().Value(idempotencyKeyKey{}).(string); ok {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	for k, v := range c.config.Headers {
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+}
+
+// authenticate sends a request with an OAuth access token or the API key,
+// falling back to the previous API key if the current one is rejected
+func (c *Client) authenticate(req *http.Request) (*http.Response, error) {
+	if c.tokens != nil {
+		tok, err := c.tokens.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get OAuth access token: %w", err)
+		}
+		tok.SetAuthHeader(req)
+		return c.do(req)
+	}
+
+	req.Header.Set("X-API-KEY", c.config.APIKey)
+	resp, err := c.do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.config.PreviousAPIKey == "" {
+		if err == nil && resp.StatusCode != http.StatusUnauthorized {
+			c.previousAPIKeyInUse.Store(false)
+		}
+		return resp, err
+	}
+	_ = resp.Body.Close()
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		req.Body = body
+	}
+
+	req.Header.Set("X-API-KEY", c.config.PreviousAPIKey)
+	resp, err = c.do(req)
+	if err == nil && resp.StatusCode != http.StatusUnauthorized {
+		c.previousAPIKeyInUse.Store(true)
+	}
+	return resp, err
+}
+
+// UsingPreviousAPIKey reports whether the last authenticated request was only
+// accepted with the previous API key
+func (c *Client) UsingPreviousAPIKey() bool {
+	return c.previousAPIKeyInUse.Load()
+}
+
+// do sends a request, retrying idempotent requests that failed with a
+// transient error, and any request that could not reach the server
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if c.config.RateLimiter != nil {
+			if err := c.config.RateLimiter.Wait(req.Context()); err != nil {
+				return nil, fmt.Errorf("rate limit: %w", err)
+			}
+		}
+
+		if d := c.config.CircuitBreaker.allow(); d > 0 {
+			return nil, &UnavailableError{Endpoint: c.config.Endpoint, RetryAfter: d}
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout(req))
+		start := time.Now()
+		resp, err := c.httpClient.Do(req.WithContext(ctx))
+		observeRequest(req, resp, err, time.Since(start))
+		c.config.CircuitBreaker.record(req, resp, err)
+		if err != nil {
+			cancel()
+		} else {
+			resp.Body = &cancelBody{ReadCloser: newSizedBody(req, resp.Body), cancel: cancel}
+		}
+		retry := isDialError(err) || isIdempotent(req.Method) && isTransient(resp, err)
+		if attempt >= c.config.MaxRetries || !retry {
+			return resp, err
+		}
+
+		// Wait as long as a rate limiting server asks, unless that is longer
+		// than any backoff, in which case the caller is told to come back
+		// later instead.
+		d := retryAfter(resp)
+		if d > c.config.MaxRetryBackoff {
+			return resp, nil
+		}
+		wait := max(c.backoff(attempt), d)
+
+		// There is no point waiting to retry past the deadline of the caller.
+		if dl, ok := req.Context().Deadline(); ok && time.Until(dl) < wait {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+	}
+}
+
+// timeout returns the timeout of each attempt of the supplied request.
+func (c *Client) timeout(req *http.Request) time.Duration {
+	switch {
+	case strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/"):
+		return c.config.UploadTimeout
+	case req.Method == http.MethodGet || req.Method == http.MethodHead:
+		return c.config.ReadTimeout
+	}
+	return c.config.WriteTimeout
+}
+
+// A cancelBody cancels the context of the request it is the response body of
+// once closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// backoff returns the delay before the supplied retry attempt: RetryBackoff
+// doubled for every previous attempt, capped to MaxRetryBackoff, with a random
+// jitter of up to half of it.
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.config.RetryBackoff
+	for i := 0; i < attempt && d < c.config.MaxRetryBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, c.config.MaxRetryBackoff)
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return d - half + rand.N(half+1) //nolint:gosec // Jitter needs no cryptographic randomness.
+}
+
+// retryAfter returns how long a server rate limiting the supplied response
+// asked to wait, either as a number of seconds or as a date.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if s, err := strconv.Atoi(v); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// isIdempotent reports whether a request with the given method can safely be
+// sent more than once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isTransient reports whether a request failed in a way that may succeed when
+// retried
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
 }
 
-// uploadFile uploads a file to the specified path
-func (c *Client) uploadFile(ctx context.Context, path string, fileData []byte, filename string) (*http.Response, error) {
+// uploadFile uploads a file read from r to the specified path. The multipart
+// body is buffered rather than streamed so that the upload can be sent again,
+// when retried or with the previous API key; files are small enough, such as
+// logos of at most MaxLogoSize.
+func (c *Client) uploadFile(ctx context.Context, path string, r io.Reader, filename string) (*http.Response, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
@@ -112,7 +608,7 @@ func (c *Client) uploadFile(ctx context.Context, path string, fileData []byte, f
 		return nil, fmt.Errorf("failed to create form file: %w", err)
 	}
 
-	if _, err := part.Write(fileData); err != nil {
+	if _, err := io.Copy(part, r); err != nil {
 		return nil, fmt.Errorf("failed to write file data: %w", err)
 	}
 
@@ -120,50 +616,78 @@ func (c *Client) uploadFile(ctx context.Context, path string, fileData []byte, f
 		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PUT", c.config.Endpoint+path, body)
+	u, err := c.url(path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", u, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create upload request: %w", err)
 	}
 
-	req.Header.Set("X-API-KEY", c.config.APIKey)
+	c.setHeaders(req)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	return c.httpClient.Do(req)
+	return c.write(req)
 }
 
-// downloadFile downloads a file from the given URL
-func (c *Client) downloadFile(ctx context.Context, fileURL string) ([]byte, string, error) {
+// downloadFile starts downloading a file of one of the supplied accepted media
+// types from the given URL, returning its content as it is read along with
+// its Content-Type. Reading more than limit bytes fails with a TooLargeError,
+// returned right away for files announced as larger. Files are downloaded
+// within the read timeout, and are not retried: the uploads of logos are
+// retried in the background already.
+func (c *Client) downloadFile(ctx context.Context, fileURL string, limit int64, accept string) (io.ReadCloser, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.ReadTimeout)
 	req, err := http.NewRequestWithContext(ctx, "GET", fileURL, nil)
 	if err != nil {
+		cancel()
 		return nil, "", fmt.Errorf("failed to create download request: %w", err)
 	}
+	req.Header.Set("Accept", accept)
+	if c.config.UserAgent != "" {
+		req.Header.Set("User-Agent", c.config.UserAgent)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.downloads.Do(req)
 	if err != nil {
+		cancel()
 		return nil, "", fmt.Errorf("failed to download file from %s: %w", fileURL, err)
 	}
-	defer func() { _ = resp.Body.Close() }()
+	body := &cancelBody{ReadCloser: resp.Body, cancel: cancel}
 
 	if resp.StatusCode != http.StatusOK {
+		_ = body.Close()
 		return nil, "", fmt.Errorf("failed to download file: HTTP %d", resp.StatusCode)
 	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	if resp.ContentLength > limit {
+		_ = body.Close()
+		return nil, "", &TooLargeError{Limit: limit}
 	}
 
-	// Extract filename from URL
-	parsedURL, err := url.Parse(fileURL)
-	if err != nil {
-		return data, "logo", nil
-	}
-	filename := filepath.Base(parsedURL.Path)
-	if filename == "." || filename == "/" {
-		filename = "logo"
-	}
+	return newLimitedBody(body, limit), resp.Header.Get("Content-Type"), nil
+}
 
-	return data, filename, nil
+// A limitedBody fails with a TooLargeError once more than limit bytes are
+// read.
+type limitedBody struct {
+	io.Reader
+	io.Closer
+	read, limit int64
+}
+
+func newLimitedBody(body io.ReadCloser, limit int64) *limitedBody {
+	return &limitedBody{Reader: io.LimitReader(body, limit+1), Closer: body, limit: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return 0, &TooLargeError{Limit: b.limit}
+	}
+	return n, err
 }
 
 // checkResponse checks HTTP response for errors and returns body
@@ -175,8 +699,12 @@ func checkResponse(resp *http.Response) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &RateLimitedError{RetryAfter: retryAfter(resp)}
+	}
+
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: HTTP %d - %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp.StatusCode, body)
 	}
 
 	return body, nil
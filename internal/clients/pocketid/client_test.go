@@ -0,0 +1,642 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+)
+
+func TestRetries(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		method     string
+		maxRetries int
+		statuses   []int
+		want       int
+	}{
+		"RetryTransient": {
+			reason:     "Idempotent requests failing with a transient status should be retried until they succeed.",
+			method:     http.MethodGet,
+			maxRetries: 3,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			want:       3,
+		},
+		"GiveUp": {
+			reason:     "Requests should not be retried more than the configured number of times.",
+			method:     http.MethodGet,
+			maxRetries: 1,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			want:       2,
+		},
+		"NotIdempotent": {
+			reason:     "POST requests should never be retried.",
+			method:     http.MethodPost,
+			maxRetries: 3,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			want:       1,
+		},
+		"NotTransient": {
+			reason:     "Requests failing with a non transient status should not be retried.",
+			method:     http.MethodPut,
+			maxRetries: 3,
+			statuses:   []int{http.StatusBadRequest, http.StatusOK},
+			want:       1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.statuses[got])
+				got++
+			}))
+			defer srv.Close()
+
+			c := NewClient(Config{Endpoint: srv.URL, MaxRetries: tc.maxRetries, RetryBackoff: time.Millisecond})
+			resp, err := c.makeRequest(context.Background(), tc.method, "/api/users", struct{}{})
+			if err != nil {
+				t.Fatalf("\n%s\nc.makeRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			_ = resp.Body.Close()
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.makeRequest(...): -want requests, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestAPIError(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status int
+		body   string
+		kind   error
+		fields []FieldError
+	}{
+		"NotFound": {
+			reason: "A 404 response should be a not found error.",
+			status: http.StatusNotFound,
+			kind:   ErrNotFound,
+		},
+		"Conflict": {
+			reason: "A 409 response should be a conflict error.",
+			status: http.StatusConflict,
+			kind:   ErrConflict,
+		},
+		"Unauthorized": {
+			reason: "A 401 response should be an unauthorized error.",
+			status: http.StatusUnauthorized,
+			kind:   ErrUnauthorized,
+		},
+		"Validation": {
+			reason: "A 400 response should be a validation error listing the offending fields.",
+			status: http.StatusBadRequest,
+			body:   `{"error":"invalid request","errors":[{"field":"callbackURLs","message":"must be absolute"}]}`,
+			kind:   ErrValidation,
+			fields: []FieldError{{Field: "callbackURLs", Message: "must be absolute"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := checkResponse(&http.Response{StatusCode: tc.status, Body: io.NopCloser(strings.NewReader(tc.body))})
+			if !errors.Is(err, tc.kind) {
+				t.Errorf("\n%s\ncheckResponse(...): want error of kind %q, got %v", tc.reason, tc.kind, err)
+			}
+			if diff := cmp.Diff(tc.status, StatusCode(err)); diff != "" {
+				t.Errorf("\n%s\nStatusCode(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			var ae *APIError
+			if errors.As(err, &ae) {
+				if diff := cmp.Diff(tc.fields, ae.FieldErrors); diff != "" {
+					t.Errorf("\n%s\nFieldErrors: -want, +got:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status int
+		body   string
+		want   string
+	}{
+		"JSON": {
+			reason: "The message reported by the API should be used.",
+			status: http.StatusBadRequest,
+			body:   `{"error":"redirect URI must be absolute"}`,
+			want:   "Pocket ID API error (HTTP 400): redirect URI must be absolute",
+		},
+		"FieldErrors": {
+			reason: "The field errors reported by the API should follow its message.",
+			status: http.StatusBadRequest,
+			body:   `{"error":"invalid request","errors":[{"field":"email","message":"must be a valid email"}]}`,
+			want:   "Pocket ID API error (HTTP 400): invalid request; email: must be a valid email",
+		},
+		"NotJSON": {
+			reason: "A body that is not JSON should be used as is.",
+			status: http.StatusBadGateway,
+			body:   "upstream unavailable\n",
+			want:   "Pocket ID API error (HTTP 502): upstream unavailable",
+		},
+		"Empty": {
+			reason: "The status text should be used when there is no body.",
+			status: http.StatusNotFound,
+			want:   "Pocket ID API error (HTTP 404): Not Found",
+		},
+		"Truncated": {
+			reason: "Long bodies should be truncated.",
+			status: http.StatusBadGateway,
+			body:   strings.Repeat("x", 300),
+			want:   "Pocket ID API error (HTTP 502): " + strings.Repeat("x", 256) + "...",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := newAPIError(tc.status, []byte(tc.body)).Error()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nError(): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRetryUnreachable(t *testing.T) {
+	got := 0
+	c := NewClient(Config{Endpoint: "http://pocket-id.example", MaxRetries: 2, RetryBackoff: time.Millisecond})
+	c.httpClient.Transport = roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		got++
+		return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	})
+
+	if _, err := c.makeRequest(context.Background(), http.MethodPost, "/api/users", struct{}{}); err == nil {
+		t.Fatal("c.makeRequest(...): expected an error")
+	}
+	if diff := cmp.Diff(3, got); diff != "" {
+		t.Errorf("POST requests that could not reach the server should be retried: -want requests, +got:\n%s\n", diff)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		retryAfter string
+		want       int
+		wantErr    error
+	}{
+		"WaitAndRetry": {
+			reason:     "Requests should be retried once the server asked.",
+			retryAfter: "0",
+			want:       2,
+		},
+		"TooLong": {
+			reason:     "Requests should not be retried when the server asks to wait longer than any backoff.",
+			retryAfter: "120",
+			want:       1,
+			wantErr:    &RateLimitedError{RetryAfter: 2 * time.Minute},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				got++
+				if got == 1 {
+					w.Header().Set("Retry-After", tc.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer srv.Close()
+
+			c := NewClient(Config{Endpoint: srv.URL, MaxRetries: 3, RetryBackoff: time.Millisecond})
+			resp, err := c.makeRequest(context.Background(), http.MethodGet, "/api/users", nil)
+			if err != nil {
+				t.Fatalf("\n%s\nc.makeRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			_, err = checkResponse(resp)
+			if diff := cmp.Diff(tc.wantErr, err); diff != "" {
+				t.Errorf("\n%s\ncheckResponse(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.makeRequest(...): -want requests, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id":"1","groupName":"admins"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key", ReadTimeout: 10 * time.Millisecond, WriteTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+
+	if _, err := c.GetGroup(context.Background(), "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("c.GetGroup(...): want the read timeout exceeded, got %v", err)
+	}
+	if _, err := c.UpdateGroup(context.Background(), "1", UpdateGroupRequest{GroupName: "admins"}); err != nil {
+		t.Errorf("c.UpdateGroup(...): want the longer write timeout, got %v", err)
+	}
+}
+
+func TestRetryDeadline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key", MaxRetries: 3, RetryBackoff: time.Minute})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err = c.GetGroup(ctx, "1")
+	if StatusCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("c.GetGroup(...): want the last error of the API, got %v", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("c.GetGroup(...): want no retry past the deadline of the caller, returned after %s", d)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := NewClient(Config{RetryBackoff: time.Second, MaxRetryBackoff: 5 * time.Second})
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		for range 20 {
+			got := c.backoff(attempt)
+			if got < want/2 || got > want {
+				t.Errorf("c.backoff(%d): want between %s and %s, got %s", attempt, want/2, want, got)
+			}
+		}
+	}
+	if got := c.backoff(100); got > 5*time.Second || got < 0 {
+		t.Errorf("c.backoff(100): want at most 5s, got %s", got)
+	}
+}
+
+func TestCheckResponseForbidden(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	c := NewClient(Config{Endpoint: srv.URL})
+	_, err := c.ListUsers(context.Background(), ListOptions{})
+	if !errors.Is(err, ErrNotAdmin) {
+		t.Errorf("c.ListUsers(...): want ErrNotAdmin, got %v", err)
+	}
+}
+
+func TestPreviousAPIKey(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		previous string
+		accepted string
+		want     []string
+		wantUsed bool
+	}{
+		"CurrentKeyAccepted": {
+			reason:   "The previous API key should not be used when the current one is accepted.",
+			previous: "old",
+			accepted: "new",
+			want:     []string{"new"},
+		},
+		"CurrentKeyRejected": {
+			reason:   "Requests rejected with the current API key should be retried with the previous one.",
+			previous: "old",
+			accepted: "old",
+			want:     []string{"new", "old"},
+			wantUsed: true,
+		},
+		"NoPreviousKey": {
+			reason:   "Requests rejected with the current API key should fail when there is no previous one.",
+			accepted: "old",
+			want:     []string{"new"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = append(got, r.Header.Get("X-API-KEY"))
+				if r.Header.Get("X-API-KEY") != tc.accepted {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			}))
+			defer srv.Close()
+
+			c := NewClient(Config{Endpoint: srv.URL, APIKey: "new", PreviousAPIKey: tc.previous})
+			resp, err := c.makeRequest(context.Background(), http.MethodPost, "/api/users", struct{}{})
+			if err != nil {
+				t.Fatalf("\n%s\nc.makeRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			_ = resp.Body.Close()
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.makeRequest(...): -want API keys, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantUsed, c.UsingPreviousAPIKey()); diff != "" {
+				t.Errorf("\n%s\nc.UsingPreviousAPIKey(): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUnixSocketEndpoint(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "pocket-id.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("net.Listen(...): unexpected error: %v", err)
+	}
+
+	var got string
+	srv := &httptest.Server{
+		Listener: l,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { //nolint:gosec // Test server.
+			got = r.URL.Path
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		})},
+	}
+	srv.Start()
+	defer srv.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: "unix://" + socket, APIKey: "key"})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+	if _, err := c.ListUsers(context.Background(), ListOptions{}); err != nil {
+		t.Fatalf("c.ListUsers(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("/api/users", got); diff != "" {
+		t.Errorf("c.ListUsers(...): -want request through the socket, +got:\n%s\n", diff)
+	}
+}
+
+func TestEndpointPath(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		prefix string
+		path   string
+		want   string
+	}{
+		"NoPrefix": {
+			reason: "API paths should be sent as is to an endpoint without a path.",
+			path:   "/api/users",
+			want:   "/api/users",
+		},
+		"Prefix": {
+			reason: "API paths should be joined to the path of the endpoint.",
+			prefix: "/auth",
+			path:   "/api/users",
+			want:   "/auth/api/users",
+		},
+		"TrailingSlashes": {
+			reason: "Trailing slashes of the endpoint should not result in empty path segments.",
+			prefix: "/auth//",
+			path:   "/api/users",
+			want:   "/auth/api/users",
+		},
+		"Query": {
+			reason: "The query of an API path should be kept.",
+			prefix: "/auth",
+			path:   "/api/users?search=jdoe",
+			want:   "/auth/api/users?search=jdoe",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.URL.RequestURI()
+			}))
+			defer srv.Close()
+
+			c, err := NewClientFromConfig(Config{Endpoint: srv.URL + tc.prefix, APIKey: "key"})
+			if err != nil {
+				t.Fatalf("\n%s\nNewClientFromConfig(...): unexpected error: %v", tc.reason, err)
+			}
+			resp, err := c.makeRequest(context.Background(), http.MethodGet, tc.path, nil)
+			if err != nil {
+				t.Fatalf("\n%s\nc.makeRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			_ = resp.Body.Close()
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.makeRequest(...): -want request URI, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	var got http.Header
+	var host string
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got, host = r.Header, r.Host
+	}))
+	defer srv.Close()
+
+	c := NewClient(Config{Endpoint: srv.URL, APIKey: "key", Headers: map[string]string{
+		"CF-Access-Client-Id": "id",
+		"X-API-KEY":           "overridden",
+		"Host":                "id.example.com",
+	}})
+	resp, err := c.makeRequest(context.Background(), http.MethodGet, "/api/users", nil)
+	if err != nil {
+		t.Fatalf("c.makeRequest(...): unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if diff := cmp.Diff([]string{"id", "key", "id.example.com"}, []string{got.Get("CF-Access-Client-Id"), got.Get("X-API-KEY"), host}); diff != "" {
+		t.Errorf("c.makeRequest(...): -want headers, +got:\n%s\n", diff)
+	}
+}
+
+func TestOAuth(t *testing.T) {
+	jwt := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(jwt, []byte("federated-jwt\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile(...): unexpected error: %v", err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		oauth  *OAuthConfig
+		want   url.Values
+	}{
+		"ClientSecret": {
+			reason: "Tokens should be requested with the client secret.",
+			oauth:  &OAuthConfig{ClientID: "provider", ClientSecret: "secret", Scopes: []string{"admin"}},
+			want: url.Values{
+				"grant_type": {"client_credentials"},
+				"scope":      {"admin"},
+				"basic_auth": {"provider:secret"},
+			},
+		},
+		"FederatedToken": {
+			reason: "Tokens should be requested with the federated token as client assertion.",
+			oauth:  &OAuthConfig{ClientID: "provider", FederatedTokenFile: jwt},
+			want: url.Values{
+				"grant_type":            {"client_credentials"},
+				"client_id":             {"provider"},
+				"client_assertion_type": {jwtBearerAssertion},
+				"client_assertion":      {"federated-jwt"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []url.Values
+			var auth []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/token" {
+					_ = r.ParseForm()
+					if id, secret, ok := r.BasicAuth(); ok {
+						r.PostForm.Set("basic_auth", id+":"+secret)
+					}
+					got = append(got, r.PostForm)
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+					return
+				}
+				auth = append(auth, r.Header.Get("Authorization"))
+			}))
+			defer srv.Close()
+
+			tc.oauth.TokenURL = srv.URL + "/token"
+			c, err := NewClientFromConfig(Config{Endpoint: srv.URL, OAuth: tc.oauth})
+			if err != nil {
+				t.Fatalf("\n%s\nNewClientFromConfig(...): unexpected error: %v", tc.reason, err)
+			}
+			for range 2 {
+				resp, err := c.makeRequest(context.Background(), http.MethodGet, "/api/users", nil)
+				if err != nil {
+					t.Fatalf("\n%s\nc.makeRequest(...): unexpected error: %v", tc.reason, err)
+				}
+				_ = resp.Body.Close()
+			}
+
+			if diff := cmp.Diff([]url.Values{tc.want}, got); diff != "" {
+				t.Errorf("\n%s\ntoken requests: -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff([]string{"Bearer token", "Bearer token"}, auth); diff != "" {
+				t.Errorf("\n%s\nc.makeRequest(...): -want Authorization, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRequestSource(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer srv.Close()
+
+	c := NewClient(Config{Endpoint: srv.URL, APIKey: "key", UserAgent: "provider-pocketid/v1.0.0 (provider-pod)"})
+	resp, err := c.makeRequest(WithRequestSource(context.Background(), "User/alice"), http.MethodGet, "/api/users", nil)
+	if err != nil {
+		t.Fatalf("c.makeRequest(...): unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	want := map[string]string{"User-Agent": "provider-pocketid/v1.0.0 (provider-pod)", "X-Request-Source": "User/alice"}
+	for k, v := range want {
+		if diff := cmp.Diff(v, got.Get(k)); diff != "" {
+			t.Errorf("c.makeRequest(...): -want %s header, +got:\n%s\n", k, diff)
+		}
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer api.Close()
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer cdn.Close()
+	untrusted := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("\x89PNG\r\n\x1a\n"))
+	}))
+	defer untrusted.Close()
+
+	c, err := NewClientFromConfig(Config{
+		Endpoint:           api.URL,
+		APIKey:             "key",
+		InsecureSkipVerify: true,
+		CircuitBreaker:     NewCircuitBreaker(1, time.Hour),
+		RateLimiter:        rate.NewLimiter(rate.Every(time.Hour), 1),
+	})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for range 2 {
+		if _, _, err := c.downloadFile(ctx, cdn.URL+"/logo.png", MaxLogoSize, logoAccept); err == nil {
+			t.Fatal("c.downloadFile(...): want the error of the host, got none")
+		}
+	}
+	if _, _, err := c.downloadFile(ctx, untrusted.URL+"/logo.png", MaxLogoSize, logoAccept); err == nil {
+		t.Error("c.downloadFile(...): want the certificate of the host verified whatever the TLS settings of the endpoint, got no error")
+	}
+
+	// Neither the circuit breaker nor the rate limiter of the endpoint were
+	// used by the downloads.
+	if _, err := c.ListUsers(ctx, ListOptions{}); err != nil {
+		t.Errorf("c.ListUsers(...): want the API unaffected by failed downloads, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompression(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		gzip   bool
+	}{
+		"Compressed": {
+			reason: "Responses compressed by the server should be decompressed transparently.",
+			gzip:   true,
+		},
+		"Uncompressed": {
+			reason: "Responses the server did not compress should be read as is.",
+			gzip:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var accept string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Get("Accept-Encoding")
+				body := `{"data":[{"id":"1","username":"alice"}]}`
+				if !tc.gzip {
+					_, _ = w.Write([]byte(body))
+					return
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				zw := gzip.NewWriter(w)
+				_, _ = zw.Write([]byte(body))
+				_ = zw.Close()
+			}))
+			defer srv.Close()
+
+			c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key"})
+			if err != nil {
+				t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+			}
+			users, err := c.ListUsers(context.Background(), ListOptions{})
+			if err != nil {
+				t.Fatalf("\n%s\nc.ListUsers(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff("gzip", accept); diff != "" {
+				t.Errorf("\n%s\nc.ListUsers(...): -want Accept-Encoding, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff([]User{{ID: "1", Username: "alice"}}, users); diff != "" {
+				t.Errorf("\n%s\nc.ListUsers(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
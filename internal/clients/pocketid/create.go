@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// idempotencyKeyKey is the context key of the idempotency key of requests.
+type idempotencyKeyKey struct{}
+
+// create sends a request creating an object of the supplied kind. Unlike
+// idempotent requests, requests creating objects are not retried blindly when
+// they fail in a way that leaves unknown whether they reached the server, as
+// that could create the object twice. The object is instead looked up with
+// find, and the request only sent again if it was not created. The object
+// found is returned as listed by the API, without the secrets only returned
+// when it is created. Every attempt carries the same Idempotency-Key header,
+// for proxies and servers able to deduplicate requests.
+func create[T any](ctx context.Context, c *Client, path, kind string, body any, find func(context.Context) (*T, error)) (*T, error) {
+	ctx = context.WithValue(ctx, idempotencyKeyKey{}, newIdempotencyKey())
+
+	for attempt := 0; ; attempt++ {
+		obj, err := createOnce[T](ctx, c, path, kind, body)
+		if err == nil || attempt >= c.config.MaxRetries || !isAmbiguous(ctx, err) {
+			return obj, err
+		}
+
+		found, ferr := find(ctx)
+		if ferr != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(c.backoff(attempt)):
+		}
+	}
+}
+
+func createOnce[T any](ctx context.Context, c *Client, path, kind string, body any) (*T, error) {
+	return call[T](ctx, c, http.MethodPost, path, "create "+kind, kind, body)
+}
+
+// isAmbiguous reports whether a request failed in a way that leaves unknown
+// whether the server processed it: it failed in transit after being sent, or
+// a gateway in front of the server failed.
+func isAmbiguous(ctx context.Context, err error) bool {
+	switch StatusCode(err) {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	var uerr *url.Error
+	return errors.As(err, &uerr) && !isDialError(err) && ctx.Err() == nil
+}
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateAmbiguous(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		created   bool
+		wantPosts int
+	}{
+		"Created": {
+			reason:    "A user created by a request whose response was lost should be looked up rather than created again.",
+			created:   true,
+			wantPosts: 1,
+		},
+		"NotCreated": {
+			reason:    "A user not created by a request whose response was lost should be created again.",
+			wantPosts: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var keys []string
+			exists := false
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodPost:
+					keys = append(keys, r.Header.Get("Idempotency-Key"))
+					if len(keys) == 1 {
+						exists = tc.created
+						w.WriteHeader(http.StatusGatewayTimeout)
+						return
+					}
+					exists = true
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"id":"1","username":"jdoe"}`))
+				case http.MethodGet:
+					if !exists {
+						_, _ = w.Write([]byte(`{"data":[]}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"data":[{"id":"1","username":"jdoe"}]}`))
+				}
+			}))
+			defer srv.Close()
+
+			c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key", MaxRetries: 2, RetryBackoff: time.Millisecond})
+			if err != nil {
+				t.Fatalf("\n%s\nNewClientFromConfig(...): unexpected error: %v", tc.reason, err)
+			}
+
+			u, err := c.CreateUser(context.Background(), CreateUserRequest{Username: "jdoe"})
+			if err != nil {
+				t.Fatalf("\n%s\nc.CreateUser(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(&User{ID: "1", Username: "jdoe"}, u); diff != "" {
+				t.Errorf("\n%s\nc.CreateUser(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantPosts, len(keys)); diff != "" {
+				t.Errorf("\n%s\nc.CreateUser(...): -want requests creating the user, +got:\n%s\n", tc.reason, diff)
+			}
+			for _, k := range keys {
+				if k == "" || k != keys[0] {
+					t.Errorf("\n%s\nc.CreateUser(...): want every attempt to carry the same idempotency key, got %q", tc.reason, keys)
+				}
+			}
+		})
+	}
+}
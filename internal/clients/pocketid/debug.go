@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	// maxLoggedBody is the size of the longest body logged, longer ones
+	// being truncated.
+	maxLoggedBody = 4 << 10
+
+	redacted = "REDACTED"
+)
+
+// secretFields are the substrings of the names of the fields redacted from
+// logged bodies, compared ignoring case.
+var secretFields = []string{"secret", "token", "password", "apikey", "api_key", "assertion"}
+
+// A loggingTransport logs the requests sent through it and their responses,
+// with the secrets they contain redacted. Headers, which carry the API key
+// or access token, are not logged.
+type loggingTransport struct {
+	next http.RoundTripper
+	log  logging.Logger
+}
+
+func newLoggingTransport(next http.RoundTripper, log logging.Logger) *loggingTransport {
+	return &loggingTransport{next: next, log: log}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.GetBody != nil {
+		if b, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(b)
+			_ = b.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	kv := []any{
+		"method", req.Method,
+		"host", req.URL.Host,
+		"path", req.URL.Path,
+		"duration", time.Since(start),
+		"requestBody", redactBody(req.Header.Get("Content-Type"), reqBody),
+	}
+	if err != nil {
+		t.log.Debug("Pocket ID API request failed", append(kv, "error", err)...)
+		return nil, err
+	}
+
+	respBody, rerr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if rerr != nil {
+		// Return the error when the body is read by the caller.
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(respBody), errReader{rerr}))
+	}
+
+	t.log.Debug("Pocket ID API request", append(kv,
+		"status", resp.StatusCode,
+		"responseBody", redactBody(resp.Header.Get("Content-Type"), respBody),
+	)...)
+	return resp, nil
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport.
+func (t *loggingTransport) CloseIdleConnections() {
+	if c, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// errReader fails every read with its error.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// redactBody returns the supplied body as logged, with the values of its
+// secret fields redacted. Only JSON and form bodies are logged, as others
+// such as logos are neither readable nor useful.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mt, _, _ := mime.ParseMediaType(contentType)
+	var s string
+	switch {
+	case mt == "application/json" || json.Valid(body) && mt == "":
+		var v any
+		if err := json.Unmarshal(body, &v); err != nil {
+			return "<invalid JSON body>"
+		}
+		b, _ := json.Marshal(redactJSON(v))
+		s = string(b)
+	case mt == "application/x-www-form-urlencoded":
+		q, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "<invalid form body>"
+		}
+		for k := range q {
+			if isSecretField(k) {
+				q[k] = []string{redacted}
+			}
+		}
+		s = q.Encode()
+	case mt == "":
+		return "<body omitted>"
+	default:
+		return "<" + mt + " body omitted>"
+	}
+
+	if len(s) > maxLoggedBody {
+		s = s[:maxLoggedBody] + "..."
+	}
+	return s
+}
+
+// redactJSON redacts the values of the secret fields of the supplied decoded
+// JSON value, at any depth.
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, f := range v {
+			if isSecretField(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(f)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = redactJSON(e)
+		}
+	}
+	return v
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretFields {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+// recordingLogger records the messages and key-value pairs logged to it.
+type recordingLogger struct {
+	lines *[]string
+}
+
+func (l recordingLogger) Info(msg string, kv ...any)  { l.record(msg, kv) }
+func (l recordingLogger) Debug(msg string, kv ...any) { l.record(msg, kv) }
+func (l recordingLogger) WithValues(...any) logging.Logger {
+	return l
+}
+
+func (l recordingLogger) record(msg string, kv []any) {
+	*l.lines = append(*l.lines, fmt.Sprint(append([]any{msg}, kv...)...))
+}
+
+func TestLogRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1","clientName":"app","clientSecret":"generated-secret","credentials":{"federatedIdentities":[{"accessToken":"token"}]}}`))
+	}))
+	defer srv.Close()
+
+	var lines []string
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "api-key", Logger: recordingLogger{lines: &lines}})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+	got, err := c.CreateOIDCClient(context.Background(), CreateOIDCClientRequest{ClientName: "app"})
+	if err != nil {
+		t.Fatalf("c.CreateOIDCClient(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("generated-secret", got.ClientSecret); diff != "" {
+		t.Errorf("c.CreateOIDCClient(...): -want the response to be returned unredacted, +got:\n%s\n", diff)
+	}
+
+	if len(lines) != 1 {
+		t.Fatalf("c.CreateOIDCClient(...): want one logged request, got %q", lines)
+	}
+	for _, want := range []string{"POST", "/api/oidc/clients", "201", `"clientName":"app"`, `"clientSecret":"REDACTED"`, `"accessToken":"REDACTED"`} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("c.CreateOIDCClient(...): want the logged request to contain %q, got %q", want, lines[0])
+		}
+	}
+	for _, secret := range []string{"api-key", "generated-secret", `"token"`} {
+		if strings.Contains(lines[0], secret) {
+			t.Errorf("c.CreateOIDCClient(...): want %q to be redacted, got %q", secret, lines[0])
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		contentType string
+		body        string
+		want        string
+	}{
+		"Empty": {
+			reason: "Empty bodies should be logged as such.",
+		},
+		"Form": {
+			reason:      "Secret values of forms, such as those sent to OAuth token endpoints, should be redacted.",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=client_credentials&client_secret=s&client_assertion=jwt",
+			want:        "client_assertion=REDACTED&client_secret=REDACTED&grant_type=client_credentials",
+		},
+		"Binary": {
+			reason:      "Bodies that are neither JSON nor forms should be omitted.",
+			contentType: "image/png",
+			body:        "\x89PNG",
+			want:        "<image/png body omitted>",
+		},
+		"Long": {
+			reason:      "Long bodies should be truncated.",
+			contentType: "application/json",
+			body:        `"` + strings.Repeat("a", maxLoggedBody) + `"`,
+			want:        `"` + strings.Repeat("a", maxLoggedBody-1) + "...",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := redactBody(tc.contentType, []byte(tc.body))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nredactBody(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// OpenIDConfiguration represents the discovery document of Pocket ID as an
+// OpenID Connect issuer, along with its signing keys
+type OpenIDConfiguration struct {
+	Issuer                           string   `json:"issuer"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	UserinfoEndpoint                 string   `json:"userinfo_endpoint,omitempty"`
+	EndSessionEndpoint               string   `json:"end_session_endpoint,omitempty"`
+	IntrospectionEndpoint            string   `json:"introspection_endpoint,omitempty"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ScopesSupported                  []string `json:"scopes_supported,omitempty"`
+	ResponseTypesSupported           []string `json:"response_types_supported,omitempty"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported,omitempty"`
+
+	// JWKS is the key set served at JWKSURI.
+	JWKS JWKS `json:"-"`
+}
+
+// JWKS represents a JSON Web Key Set
+type JWKS struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey represents a public key of a JSON Web Key Set
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// GetOpenIDConfiguration retrieves the discovery document of the endpoint and
+// its signing keys, returning ErrNotIssuer if it does not serve them. The keys
+// are retrieved from the path of the JWKS URI on the endpoint rather than from
+// the URI itself, whose host is the public URL of Pocket ID, which the
+// provider may not be able to reach.
+func (c *Client) GetOpenIDConfiguration(ctx context.Context) (*OpenIDConfiguration, error) {
+	var config OpenIDConfiguration
+	if err := c.getWellKnown(ctx, "/.well-known/openid-configuration", "OpenID configuration", &config); err != nil {
+		return nil, err
+	}
+	if config.Issuer == "" || config.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: discovery document lacks issuer or jwks_uri", ErrNotIssuer)
+	}
+
+	u, err := url.Parse(config.JWKSURI)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid jwks_uri: %w", ErrNotIssuer, err)
+	}
+	if err := c.getWellKnown(ctx, u.Path, "JWKS", &config.JWKS); err != nil {
+		return nil, err
+	}
+	if len(config.JWKS.Keys) == 0 {
+		return nil, fmt.Errorf("%w: JWKS has no keys", ErrNotIssuer)
+	}
+
+	return &config, nil
+}
+
+func (c *Client) getWellKnown(ctx context.Context, path, kind string, v any) error {
+	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", kind, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s not found", ErrNotIssuer, kind)
+	}
+
+	body, err := checkResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal %s response: %w", ErrNotIssuer, kind, err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Kinds of errors returned by the API, matched with errors.Is.
+var (
+	// ErrUnauthorized is returned when the API rejects the credentials.
+	ErrUnauthorized = errors.New("credentials rejected by the Pocket ID API")
+
+	// ErrNotAdmin is returned when the API rejects a request because the API
+	// key does not belong to an admin.
+	ErrNotAdmin = errors.New("API key lacks admin rights")
+
+	// ErrNotIssuer is returned when the endpoint does not serve the discovery
+	// document and signing keys of an OpenID Connect issuer.
+	ErrNotIssuer = errors.New("endpoint does not serve an OpenID Connect issuer")
+
+	// ErrNotFound is returned when the requested object does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict is returned when the request conflicts with an existing
+	// object, e.g. one with the same name.
+	ErrConflict = errors.New("conflict with an existing object")
+
+	// ErrValidation is returned when the API rejects the content of the
+	// request. The APIError lists the offending fields when the API reports
+	// them.
+	ErrValidation = errors.New("invalid request")
+)
+
+// An APIError is returned when the API rejects a request.
+type APIError struct {
+	// StatusCode of the response.
+	StatusCode int
+
+	// Message explaining the error, as reported by the API.
+	Message string
+
+	// Body of the response.
+	Body string
+
+	// FieldErrors are the validation errors of the fields of the request,
+	// when the API reports them.
+	FieldErrors []FieldError
+}
+
+// A FieldError is a validation error of a field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// maxErrorBodyLength is the length beyond which the body of responses that
+// carry no error message, such as the HTML pages of proxies, is truncated in
+// error messages.
+const maxErrorBodyLength = 256
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = strings.TrimSpace(e.Body)
+		if len(msg) > maxErrorBodyLength {
+			msg = msg[:maxErrorBodyLength] + "..."
+		}
+	}
+	for _, f := range e.FieldErrors {
+		msg += fmt.Sprintf("; %s: %s", f.Field, f.Message)
+	}
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("Pocket ID API error (HTTP %d): %s", e.StatusCode, strings.TrimPrefix(msg, "; "))
+}
+
+// Is reports whether the error is of the supplied kind.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrNotAdmin:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	}
+	return false
+}
+
+// newAPIError returns the error of a response with the supplied status and
+// body. Pocket ID reports errors as {"error": "message"}.
+func newAPIError(status int, body []byte) *APIError {
+	e := &APIError{StatusCode: status, Body: string(body)}
+
+	var b struct {
+		Error   string       `json:"error"`
+		Message string       `json:"message"`
+		Errors  []FieldError `json:"errors"`
+	}
+	if json.Unmarshal(body, &b) != nil {
+		return e
+	}
+	e.Message = b.Error
+	if e.Message == "" {
+		e.Message = b.Message
+	}
+	if errors.Is(e, ErrValidation) {
+		e.FieldErrors = b.Errors
+	}
+	return e
+}
+
+// A RateLimitedError is returned when the API keeps rejecting requests with
+// 429 Too Many Requests.
+type RateLimitedError struct {
+	// RetryAfter is how long the server asked to wait before retrying, zero
+	// if it did not say.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited by the Pocket ID API, retry after %s", e.RetryAfter)
+	}
+	return "rate limited by the Pocket ID API"
+}
+
+// StatusCode returns the HTTP status of the response the supplied error was
+// returned for, or 0 if it was not returned for a response.
+func StatusCode(err error) int {
+	var ae *APIError
+	if errors.As(err, &ae) {
+		return ae.StatusCode
+	}
+	var rl *RateLimitedError
+	if errors.As(err, &rl) {
+		return http.StatusTooManyRequests
+	}
+	return 0
+}
+
+// An UnavailableError is returned without sending requests while the circuit
+// breaker of the endpoint they would be sent to is open.
+type UnavailableError struct {
+	Endpoint string
+	// RetryAfter is how long until requests are sent again.
+	RetryAfter time.Duration
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("Pocket ID API at %s is unavailable, retry after %s", e.Endpoint, e.RetryAfter.Round(time.Second))
+}
+
+// A TooLargeError is returned when a file is larger than the API accepts.
+type TooLargeError struct {
+	Limit int64
+}
+
+func (e *TooLargeError) Error() string {
+	return fmt.Sprintf("file size exceeds %dMB limit", e.Limit>>20)
+}
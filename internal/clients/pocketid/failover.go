@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// failoverTransport sends the requests to the Pocket ID API to the endpoint
+// that last answered, failing over to the next one when it cannot be reached.
+// Requests to other hosts, such as logo downloads, are sent as is.
+type failoverTransport struct {
+	base      roundTripCloser
+	endpoints []*url.URL
+	active    atomic.Int32
+}
+
+// newFailoverTransport returns a transport failing over between the supplied
+// primary and failover endpoints.
+func newFailoverTransport(base roundTripCloser, primary string, failover []string) (*failoverTransport, error) {
+	t := &failoverTransport{base: base}
+	for _, e := range append([]string{primary}, failover...) {
+		u, err := url.Parse(strings.TrimRight(e, "/"))
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint %q", e)
+		}
+		t.endpoints = append(t.endpoints, u)
+	}
+	return t, nil
+}
+
+// RoundTrip sends the request to the active endpoint, then to the following
+// ones until one of them can be reached.
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	primary := t.endpoints[0]
+	if req.URL.Scheme != primary.Scheme || req.URL.Host != primary.Host {
+		return t.base.RoundTrip(req)
+	}
+	path := strings.TrimPrefix(req.URL.Path, primary.Path)
+
+	var errs []error
+	active := int(t.active.Load())
+	for i := range t.endpoints {
+		idx := (active + i) % len(t.endpoints)
+		ep := t.endpoints[idx]
+
+		r := req.Clone(req.Context())
+		r.URL.Scheme, r.URL.Host, r.URL.Path = ep.Scheme, ep.Host, ep.Path+path
+		if r.Host == primary.Host {
+			// Keep Host headers configured explicitly.
+			r.Host = ""
+		}
+		if i > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			r.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if err == nil {
+			t.active.Store(int32(idx)) //nolint:gosec // There are a handful of endpoints
+			return resp, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", ep.Host, err))
+
+		// A request that is not idempotent may have reached a server that
+		// failed to answer, so it is only sent again if it was never sent.
+		if !isIdempotent(req.Method) && !isDialError(err) {
+			break
+		}
+		if req.Body != nil && req.GetBody == nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// CloseIdleConnections closes the idle connections to every endpoint.
+func (t *failoverTransport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+}
+
+// A roundTripCloser is a transport whose idle connections can be closed.
+type roundTripCloser interface {
+	http.RoundTripper
+	CloseIdleConnections()
+}
+
+// isDialError reports whether the supplied error occurred while connecting to
+// a server, before the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
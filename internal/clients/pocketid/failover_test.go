@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFailover(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	// Nothing listens on the primary endpoint once its server is closed.
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: down.URL, FailoverEndpoints: []string{srv.URL + "/replica"}, APIKey: "key"})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+
+	for range 2 {
+		if _, err := c.ListUsers(context.Background(), ListOptions{}); err != nil {
+			t.Fatalf("c.ListUsers(...): unexpected error: %v", err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"/replica/api/users", "/replica/api/users"}, got); diff != "" {
+		t.Errorf("c.ListUsers(...): -want requests to the failover endpoint, +got:\n%s\n", diff)
+	}
+
+	ft := c.httpClient.Transport.(*failoverTransport)
+	if diff := cmp.Diff(int32(1), ft.active.Load()); diff != "" {
+		t.Errorf("c.ListUsers(...): -want the failover endpoint to stay active, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+func (s *Server) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := []pocketid.APIKey{}
+	for _, id := range sortedKeys(s.apiKeys) {
+		if k := s.apiKeys[id]; matches(r, k.Name, k.Description) {
+			keys = append(keys, *k)
+		}
+	}
+	writePage(w, r, keys)
+}
+
+func (s *Server) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	req := pocketid.CreateAPIKeyRequest{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+	if !req.ExpiresAt.After(time.Now()) {
+		writeError(w, http.StatusBadRequest, "Expiration date must be in the future")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := &pocketid.APIKey{
+		ID:          s.id(""),
+		Name:        req.Name,
+		Description: req.Description,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedAt:   time.Now().UTC(),
+	}
+	s.apiKeys[k.ID] = k
+
+	writeJSON(w, http.StatusCreated, pocketid.CreatedAPIKey{APIKey: *k, Token: newToken()})
+}
+
+func (s *Server) deleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.apiKeys[id]; !ok {
+		writeError(w, http.StatusNotFound, "API key not found")
+		return
+	}
+	delete(s.apiKeys, id)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// publicAppConfig are the variables of the application configuration anyone
+// can read, as in Pocket ID.
+var publicAppConfig = map[string]bool{
+	"appName":             true,
+	"disableAnimations":   true,
+	"allowOwnAccountEdit": true,
+	"allowUserSignups":    true,
+	"accentColor":         true,
+	"ldapEnabled":         true,
+}
+
+// defaultAppConfig is the application configuration of a new Server.
+func defaultAppConfig() map[string]string {
+	return map[string]string{
+		"appName":                   "Pocket ID",
+		"sessionDuration":           "60",
+		"emailsVerified":            "false",
+		"disableAnimations":         "false",
+		"allowOwnAccountEdit":       "true",
+		"allowUserSignups":          "disabled",
+		"signupDefaultUserGroupIDs": "[]",
+		"signupDefaultCustomClaims": "[]",
+		"accentColor":               "default",
+		"smtpHost":                  "",
+		"smtpPort":                  "",
+		"smtpFrom":                  "",
+		"smtpUser":                  "",
+		"smtpPassword":              "",
+		"ldapEnabled":               "false",
+	}
+}
+
+// AppConfig returns the application configuration.
+func (s *Server) AppConfig() pocketid.AppConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var config pocketid.AppConfig
+	b, _ := json.Marshal(s.appConfig)
+	_ = json.Unmarshal(b, &config)
+	return config
+}
+
+func (s *Server) getPublicAppConfig(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.appConfigVariables(true))
+}
+
+func (s *Server) getAppConfig(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.appConfigVariables(false))
+}
+
+func (s *Server) updateAppConfig(w http.ResponseWriter, r *http.Request) {
+	req := map[string]string{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, v := range req {
+		s.appConfig[k] = v
+	}
+	writeJSON(w, http.StatusOK, s.appConfigVariables(false))
+}
+
+// appConfigVariables returns the variables of the application configuration,
+// or only the public ones.
+func (s *Server) appConfigVariables(public bool) []pocketid.AppConfigVariable {
+	vars := []pocketid.AppConfigVariable{}
+	for _, k := range sortedKeys(s.appConfig) {
+		if public && !publicAppConfig[k] {
+			continue
+		}
+		v := pocketid.AppConfigVariable{Key: k, Type: "string", Value: s.appConfig[k]}
+		if !public {
+			v.IsPublic = publicAppConfig[k]
+		}
+		vars = append(vars, v)
+	}
+	return vars
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// AddAuditLog adds an entry to the audit log, returning it with its ID.
+func (s *Server) AddAuditLog(l pocketid.AuditLog) pocketid.AuditLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l.ID = s.id(l.ID)
+	s.auditLogs = append(s.auditLogs, l)
+	return l
+}
+
+// listAuditLogs lists the entries of the audit log newest first, whatever the
+// requested sort.
+func (s *Server) listAuditLogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := r.URL.Query()
+	logs := []pocketid.AuditLog{}
+	for _, l := range s.auditLogs {
+		if v := q.Get("filters[userId]"); v != "" && v != l.UserID {
+			continue
+		}
+		if v := q.Get("filters[event]"); v != "" && v != l.Event {
+			continue
+		}
+		if v := q.Get("filters[clientName]"); v != "" && v != l.Data["clientName"] {
+			continue
+		}
+		logs = append(logs, l)
+	}
+	slices.SortStableFunc(logs, func(a, b pocketid.AuditLog) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	writePage(w, r, logs)
+}
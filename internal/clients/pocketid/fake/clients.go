@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// maxLogoSize is the size of the largest logo accepted.
+const maxLogoSize = 2 << 20
+
+func (s *Server) listClients(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clients := []pocketid.OIDCClient{}
+	for _, id := range sortedKeys(s.clients) {
+		c := s.client(id)
+		if !matches(r, c.ClientName) {
+			continue
+		}
+		c.ClientSecret = ""
+		clients = append(clients, c)
+	}
+	writePage(w, r, clients)
+}
+
+func (s *Server) getClient(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.clients[id]; !ok {
+		writeError(w, http.StatusNotFound, "OIDC client not found")
+		return
+	}
+	c := s.client(id)
+	c.ClientSecret = ""
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) createClient(w http.ResponseWriter, r *http.Request) {
+	req := pocketid.CreateOIDCClientRequest{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.ClientName == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	id := s.id("")
+	c := &pocketid.OIDCClient{ID: id, ClientSecret: "secret-" + id}
+	setClient(c, pocketid.UpdateOIDCClientRequest(req))
+	s.clients[id] = c
+	writeJSON(w, http.StatusCreated, s.client(id))
+}
+
+func (s *Server) regenerateClientSecret(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.clients[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OIDC client not found")
+		return
+	}
+	c.ClientSecret = newToken()
+	writeJSON(w, http.StatusOK, map[string]string{"secret": c.ClientSecret})
+}
+
+func (s *Server) updateClient(w http.ResponseWriter, r *http.Request) {
+	req := pocketid.UpdateOIDCClientRequest{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.clients[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OIDC client not found")
+		return
+	}
+	if req.ClientName == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	setClient(c, req)
+	if s.resetClientGroups {
+		delete(s.clientGroups, c.ID)
+	}
+	writeJSON(w, http.StatusOK, s.client(c.ID))
+}
+
+func (s *Server) deleteClient(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.clients[id]; !ok {
+		writeError(w, http.StatusNotFound, "OIDC client not found")
+		return
+	}
+	delete(s.clients, id)
+	delete(s.clientGroups, id)
+	delete(s.logos, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setClientGroups(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserGroupIDs []string `json:"userGroupIds"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.clients[id]; !ok {
+		writeError(w, http.StatusNotFound, "OIDC client not found")
+		return
+	}
+	if !s.validGroups(w, req.UserGroupIDs) {
+		return
+	}
+	s.clientGroups[id] = slices.Clone(req.UserGroupIDs)
+	writeJSON(w, http.StatusOK, s.client(id))
+}
+
+func (s *Server) addClientToGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, group := r.PathValue("id"), r.PathValue("group")
+	if _, ok := s.clients[id]; !ok {
+		writeError(w, http.StatusNotFound, "OIDC client not found")
+		return
+	}
+	if !s.validGroups(w, []string{group}) {
+		return
+	}
+	if !slices.Contains(s.clientGroups[id], group) {
+		s.clientGroups[id] = append(s.clientGroups[id], group)
+	}
+	writeJSON(w, http.StatusOK, s.client(id))
+}
+
+func (s *Server) removeClientFromGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, group := r.PathValue("id"), r.PathValue("group")
+	i := slices.Index(s.clientGroups[id], group)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "OIDC client is not allowed for the group")
+		return
+	}
+	s.clientGroups[id] = slices.Delete(s.clientGroups[id], i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getLogo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.logos[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Logo not found")
+		return
+	}
+	_, _ = w.Write(l)
+}
+
+func (s *Server) deleteLogo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.logos[id]; !ok {
+		writeError(w, http.StatusNotFound, "Logo not found")
+		return
+	}
+	delete(s.logos, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) uploadLogo(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(maxLogoSize); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer func() { _ = f.Close() }()
+	logo, err := io.ReadAll(io.LimitReader(f, maxLogoSize+1))
+	if err != nil || len(logo) > maxLogoSize {
+		writeError(w, http.StatusBadRequest, "logo is too large")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.clients[id]; !ok {
+		writeError(w, http.StatusNotFound, "OIDC client not found")
+		return
+	}
+	s.logos[id] = logo
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setClient sets the fields of an OIDC client from a request.
+func setClient(c *pocketid.OIDCClient, req pocketid.UpdateOIDCClientRequest) {
+	c.ClientName, c.RedirectURIs, c.PostLogoutURIs, c.LaunchURL = req.ClientName, req.RedirectURIs, req.PostLogoutURIs, req.LaunchURL
+	c.IsPublic, c.RequirePKCE, c.GroupClaims, c.CustomClaims = req.IsPublic, req.RequirePKCE, req.GroupClaims, req.CustomClaims
+	c.AllowedScopes, c.AccessTokenTTL, c.RefreshTokenTTL, c.IDTokenTTL = req.AllowedScopes, req.AccessTokenTTL, req.RefreshTokenTTL, req.IDTokenTTL
+}
@@ -0,0 +1,523 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake is an in-process Pocket ID API, serving the users, groups, OIDC
+// clients, group memberships, logos, profile pictures, API keys, tokens, audit
+// log and application configuration used by the provider from memory. It can be told to fail or
+// slow down requests, and is meant for tests and for developing against go
+// test without a Pocket ID instance.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// DefaultAPIKey is the API key accepted by a Server unless configured
+// otherwise.
+const DefaultAPIKey = "fake-api-key"
+
+// DefaultVersion is the version reported by a Server unless configured
+// otherwise.
+const DefaultVersion = "1.0.0"
+
+// An Option configures a Server.
+type Option func(*Server)
+
+// WithAPIKey sets the API key accepted by the Server.
+func WithAPIKey(key string) Option {
+	return func(s *Server) { s.apiKey = key }
+}
+
+// WithVersion sets the version reported by the Server. An empty version
+// makes the version endpoint answer 404, like servers predating it.
+func WithVersion(v string) Option {
+	return func(s *Server) { s.version = v }
+}
+
+// WithLatency delays every response of the Server.
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) { s.latency = d }
+}
+
+// A failure makes requests fail with a status.
+type failure struct {
+	method string
+	path   string
+	status int
+	times  int
+}
+
+// A Server is an in-process Pocket ID API.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	apiKey   string
+	version  string
+	latency  time.Duration
+	failures []*failure
+	requests []string
+	nextID   int
+	// resetClientGroups makes updates of OIDC clients drop their groups.
+	resetClientGroups bool
+
+	users        map[string]*pocketid.User
+	groups       map[string]*pocketid.Group
+	clients      map[string]*pocketid.OIDCClient
+	userGroups   map[string][]string
+	clientGroups map[string][]string
+	logos        map[string][]byte
+	pictures     map[string][]byte
+	files        map[string][]byte
+	apiKeys      map[string]*pocketid.APIKey
+	accessTokens map[string]string
+	signupTokens map[string]*pocketid.SignupToken
+	auditLogs    []pocketid.AuditLog
+	appConfig    map[string]string
+}
+
+// NewServer starts a Server, which must be closed once done with. Its API key
+// belongs to an admin user named admin.
+func NewServer(o ...Option) *Server {
+	s := &Server{
+		apiKey:       DefaultAPIKey,
+		version:      DefaultVersion,
+		users:        map[string]*pocketid.User{},
+		groups:       map[string]*pocketid.Group{},
+		clients:      map[string]*pocketid.OIDCClient{},
+		userGroups:   map[string][]string{},
+		clientGroups: map[string][]string{},
+		logos:        map[string][]byte{},
+		pictures:     map[string][]byte{},
+		files:        map[string][]byte{},
+		apiKeys:      map[string]*pocketid.APIKey{},
+		accessTokens: map[string]string{},
+		signupTokens: map[string]*pocketid.SignupToken{},
+		appConfig:    defaultAppConfig(),
+	}
+	for _, fn := range o {
+		fn(s)
+	}
+	s.users["admin"] = &pocketid.User{ID: "admin", Username: "admin", Email: "admin@example.com", FirstName: "Admin", IsAdmin: true}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.healthz)
+	mux.HandleFunc("GET /api/version/current", s.getVersion)
+
+	mux.HandleFunc("GET /api/users", s.listUsers)
+	mux.HandleFunc("POST /api/users", s.createUser)
+	mux.HandleFunc("GET /api/users/me", s.getCurrentUser)
+	mux.HandleFunc("GET /api/users/{id}", s.getUser)
+	mux.HandleFunc("PUT /api/users/{id}", s.updateUser)
+	mux.HandleFunc("DELETE /api/users/{id}", s.deleteUser)
+	mux.HandleFunc("PUT /api/users/{id}/user-groups", s.setUserGroups)
+	mux.HandleFunc("GET /api/users/{id}/profile-picture.png", s.getProfilePicture)
+	mux.HandleFunc("DELETE /api/users/{id}/profile-picture", s.deleteProfilePicture)
+	mux.HandleFunc("POST /api/users/{id}/groups/{group}", s.addUserToGroup)
+	mux.HandleFunc("DELETE /api/users/{id}/groups/{group}", s.removeUserFromGroup)
+
+	mux.HandleFunc("GET /api/groups", s.listGroups)
+	mux.HandleFunc("POST /api/groups", s.createGroup)
+	mux.HandleFunc("GET /api/groups/{id}", s.getGroup)
+	mux.HandleFunc("PUT /api/groups/{id}", s.updateGroup)
+	mux.HandleFunc("DELETE /api/groups/{id}", s.deleteGroup)
+
+	mux.HandleFunc("GET /api/oidc/clients", s.listClients)
+	mux.HandleFunc("POST /api/oidc/clients", s.createClient)
+	mux.HandleFunc("GET /api/oidc/clients/{id}", s.getClient)
+	mux.HandleFunc("PUT /api/oidc/clients/{id}", s.updateClient)
+	mux.HandleFunc("DELETE /api/oidc/clients/{id}", s.deleteClient)
+	mux.HandleFunc("POST /api/oidc/clients/{id}/secret", s.regenerateClientSecret)
+	mux.HandleFunc("PUT /api/oidc/clients/{id}/allowed-user-groups", s.setClientGroups)
+	mux.HandleFunc("POST /api/oidc/clients/{id}/groups/{group}", s.addClientToGroup)
+	mux.HandleFunc("DELETE /api/oidc/clients/{id}/groups/{group}", s.removeClientFromGroup)
+	mux.HandleFunc("GET /api/oidc/clients/{id}/logo", s.getLogo)
+	mux.HandleFunc("PUT /api/oidc/clients/{id}/logo", s.uploadLogo)
+	mux.HandleFunc("DELETE /api/oidc/clients/{id}/logo", s.deleteLogo)
+
+	mux.HandleFunc("GET /api/api-keys", s.listAPIKeys)
+	mux.HandleFunc("POST /api/api-keys", s.createAPIKey)
+	mux.HandleFunc("DELETE /api/api-keys/{id}", s.deleteAPIKey)
+
+	mux.HandleFunc("POST /api/users/{id}/one-time-access-token", s.createOneTimeAccessToken)
+	mux.HandleFunc("GET /api/signup-tokens", s.listSignupTokens)
+	mux.HandleFunc("POST /api/signup-tokens", s.createSignupToken)
+	mux.HandleFunc("DELETE /api/signup-tokens/{id}", s.deleteSignupToken)
+
+	mux.HandleFunc("GET /api/audit-logs/all", s.listAuditLogs)
+
+	mux.HandleFunc("GET /api/application-configuration", s.getPublicAppConfig)
+	mux.HandleFunc("GET /api/application-configuration/all", s.getAppConfig)
+	mux.HandleFunc("PUT /api/application-configuration", s.updateAppConfig)
+
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.getOpenIDConfiguration)
+	mux.HandleFunc("GET /.well-known/jwks.json", s.getJWKS)
+
+	mux.HandleFunc("GET /files/{name}", s.getFile)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// Config returns the configuration of a client of the Server.
+func (s *Server) Config() pocketid.Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return pocketid.Config{Endpoint: s.URL, APIKey: s.apiKey}
+}
+
+// SetLatency delays every subsequent response of the Server.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Fail makes the next requests with the supplied method whose path starts with
+// the supplied prefix fail with the supplied status. Only as many requests as
+// times fail, or all of them if times is not positive.
+func (s *Server) Fail(method, path string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure{method: method, path: path, status: status, times: times})
+}
+
+// Requests returns the requests received so far, as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// AddUser adds a user, returning it with its ID.
+func (s *Server) AddUser(u pocketid.User) pocketid.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u.ID = s.id(u.ID)
+	s.users[u.ID] = &u
+	return s.user(u.ID)
+}
+
+// AddGroup adds a group, returning it with its ID.
+func (s *Server) AddGroup(g pocketid.Group) pocketid.Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g.ID = s.id(g.ID)
+	s.groups[g.ID] = &g
+	return g
+}
+
+// AddOIDCClient adds an OIDC client, returning it with its ID.
+func (s *Server) AddOIDCClient(c pocketid.OIDCClient) pocketid.OIDCClient {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.ID = s.id(c.ID)
+	s.clients[c.ID] = &c
+	return s.client(c.ID)
+}
+
+// AddUserToGroup adds the user with the supplied ID to the group with the
+// supplied ID.
+func (s *Server) AddUserToGroup(userID, groupID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.Contains(s.userGroups[userID], groupID) {
+		s.userGroups[userID] = append(s.userGroups[userID], groupID)
+	}
+}
+
+// AddClientToGroup allows the group with the supplied ID to use the OIDC
+// client with the supplied ID.
+func (s *Server) AddClientToGroup(clientID, groupID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.Contains(s.clientGroups[clientID], groupID) {
+		s.clientGroups[clientID] = append(s.clientGroups[clientID], groupID)
+	}
+}
+
+// ResetClientGroupsOnUpdate makes every subsequent update of an OIDC client
+// drop its allowed groups, like some versions of Pocket ID do.
+func (s *Server) ResetClientGroupsOnUpdate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetClientGroups = true
+}
+
+// AddFile serves the supplied content at /files/<name>, e.g. as the source of
+// the logo of an OIDC client.
+func (s *Server) AddFile(name string, content []byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[name] = content
+	return s.URL + "/files/" + name
+}
+
+// User returns the user with the supplied ID.
+func (s *Server) User(id string) (pocketid.User, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[id]; !ok {
+		return pocketid.User{}, false
+	}
+	return s.user(id), true
+}
+
+// Group returns the group with the supplied ID.
+func (s *Server) Group(id string) (pocketid.Group, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[id]
+	if !ok {
+		return pocketid.Group{}, false
+	}
+	return *g, true
+}
+
+// OIDCClient returns the OIDC client with the supplied ID.
+func (s *Server) OIDCClient(id string) (pocketid.OIDCClient, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.clients[id]; !ok {
+		return pocketid.OIDCClient{}, false
+	}
+	return s.client(id), true
+}
+
+// SetProfilePicture sets the profile picture of the user with the supplied ID,
+// as resized by Pocket ID.
+func (s *Server) SetProfilePicture(id string, picture []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pictures[id] = picture
+}
+
+// Logo returns the logo uploaded for the OIDC client with the supplied ID.
+func (s *Server) Logo(id string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.logos[id]
+	return l, ok
+}
+
+// middleware records requests, applies the configured latency and failures,
+// and authenticates requests to the API.
+func (s *Server) middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		latency := s.latency
+		status := s.failure(r)
+		apiKey := s.apiKey
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(latency):
+			}
+		}
+		if status != 0 {
+			writeError(w, status, http.StatusText(status))
+			return
+		}
+		public := r.Method == http.MethodGet && r.URL.Path == "/api/application-configuration"
+		if strings.HasPrefix(r.URL.Path, "/api/") && !public && r.Header.Get("X-API-KEY") != apiKey {
+			writeError(w, http.StatusUnauthorized, "You are not signed in")
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// failure returns the status the supplied request should fail with, if any.
+func (s *Server) failure(r *http.Request) int {
+	for i, f := range s.failures {
+		if f.method != r.Method || !strings.HasPrefix(r.URL.Path, f.path) {
+			continue
+		}
+		if f.times > 0 {
+			f.times--
+			if f.times == 0 {
+				s.failures = slices.Delete(s.failures, i, i+1)
+			}
+		}
+		return f.status
+	}
+	return 0
+}
+
+// id returns the supplied ID, or a new one if it is empty.
+func (s *Server) id(id string) string {
+	if id != "" {
+		return id
+	}
+	s.nextID++
+	return fmt.Sprintf("%08d-0000-4000-8000-000000000000", s.nextID)
+}
+
+// user returns the user with the supplied ID along with the names of its
+// groups.
+func (s *Server) user(id string) pocketid.User {
+	u := *s.users[id]
+	u.UserGroups = s.groupNames(s.userGroups[id])
+	return u
+}
+
+// client returns the OIDC client with the supplied ID along with the names and
+// IDs of its allowed groups.
+func (s *Server) client(id string) pocketid.OIDCClient {
+	c := *s.clients[id]
+	c.GroupNames = s.groupNames(s.clientGroups[id])
+	c.GroupIDs = slices.Clone(s.clientGroups[id])
+	_, c.HasLogo = s.logos[id]
+	return c
+}
+
+func (s *Server) groupNames(ids []string) []string {
+	var names []string
+	for _, id := range ids {
+		if g, ok := s.groups[id]; ok {
+			names = append(names, g.GroupName)
+		}
+	}
+	return names
+}
+
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getVersion(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.version == "" {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"currentVersion": s.version})
+}
+
+func (s *Server) getOpenIDConfiguration(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, pocketid.OpenIDConfiguration{
+		Issuer:                           s.URL,
+		AuthorizationEndpoint:            s.URL + "/authorize",
+		TokenEndpoint:                    s.URL + "/api/oidc/token",
+		UserinfoEndpoint:                 s.URL + "/api/oidc/userinfo",
+		EndSessionEndpoint:               s.URL + "/api/oidc/end-session",
+		JWKSURI:                          s.URL + "/.well-known/jwks.json",
+		ScopesSupported:                  []string{"openid", "profile", "email", "groups"},
+		ResponseTypesSupported:           []string{"code", "id_token"},
+		IDTokenSigningAlgValuesSupported: []string{"RS256"},
+	})
+}
+
+func (s *Server) getJWKS(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, pocketid.JWKS{Keys: []pocketid.JSONWebKey{{
+		Kty: "RSA", Kid: "fake", Use: "sig", Alg: "RS256", N: "sXchDaQebHnPiGvyDOAT4saGEUetSyo9MKLOoWFsueri23bOdgWp4Dy1WlUzewbgBHod5pcM9H95GQRV3JDXboIRROSBigeC5yjU1hGzHHyXss8UDprecbAYxknTcQkhslANGRUZmdTOQ5qTRsLAt6BTYuyvVRdhS8exSZEy_c4gs_7svlJJQ4H9_NxsiIoLwAEk7-Q3UXERGYw_75IDrGA84-lA_-Ct4eTlXHBIY2EaV7t7LjJaynVJCpkv4LKjTTAumiGUIuQhrNhZLuF_RJLqHpM2kgWFLU7-VTdL1VbC2tejvcI2BlMkEpk1BzBZI0KQB0GaDWFLN-aEAw3vRw", E: "AQAB",
+	}}})
+}
+
+func (s *Server) getFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.files[r.PathValue("name")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	_, _ = w.Write(f)
+}
+
+// matches returns whether any of the supplied fields contains the search
+// query of a list request, ignoring case, the way Pocket ID filters lists.
+func matches(r *http.Request, fields ...string) bool {
+	q := strings.ToLower(r.URL.Query().Get("search"))
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), q) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultPageSize is the number of objects of a page when a list request does
+// not set a limit, as in Pocket ID.
+const defaultPageSize = 20
+
+// writePage writes the page of the supplied objects requested by a list
+// request, in the pagination envelope of Pocket ID.
+func writePage[T any](w http.ResponseWriter, r *http.Request, objs []T) {
+	q := r.URL.Query()
+	pg, err := strconv.Atoi(q.Get("pagination[page]"))
+	if err != nil || pg < 1 {
+		pg = 1
+	}
+	limit, err := strconv.Atoi(q.Get("pagination[limit]"))
+	if err != nil || limit < 1 {
+		limit = defaultPageSize
+	}
+
+	start := min((pg-1)*limit, len(objs))
+	end := min(start+limit, len(objs))
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": objs[start:end],
+		"pagination": pocketid.Pagination{
+			TotalPages:   (len(objs) + limit - 1) / limit,
+			TotalItems:   len(objs),
+			CurrentPage:  pg,
+			ItemsPerPage: limit,
+		},
+	})
+}
+
+// writeJSON writes the supplied object as the JSON body of a response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response the way Pocket ID does.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// readJSON decodes the JSON body of a request, writing an error response if
+// it cannot.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, v)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,540 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+func newClient(t *testing.T, cfg pocketid.Config) *pocketid.Client {
+	t.Helper()
+	c, err := pocketid.NewClientFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+	}
+	return c
+}
+
+func TestMemberships(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+	ctx := context.Background()
+
+	g := srv.AddGroup(pocketid.Group{GroupName: "admins"})
+	u, err := c.CreateUser(ctx, pocketid.CreateUserRequest{Username: "alice", Email: "alice@example.com", FirstName: "Alice"})
+	if err != nil {
+		t.Fatalf("c.CreateUser(...): %v", err)
+	}
+	if err := c.SetUserGroups(ctx, u.ID, []string{g.ID}); err != nil {
+		t.Fatalf("c.SetUserGroups(...): %v", err)
+	}
+
+	in, err := c.IsUserInGroup(ctx, u.ID, g.ID)
+	if err != nil {
+		t.Fatalf("c.IsUserInGroup(...): %v", err)
+	}
+	if !in {
+		t.Error("c.IsUserInGroup(...): want the user to be in the group")
+	}
+
+	if _, err := c.CreateUser(ctx, pocketid.CreateUserRequest{Username: "alice", Email: "alice@example.org", FirstName: "Alice"}); !errors.Is(err, pocketid.ErrConflict) {
+		t.Errorf("c.CreateUser(...): want a conflict, got %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+
+	srv.AddGroup(pocketid.Group{GroupName: "admins"})
+	srv.AddGroup(pocketid.Group{GroupName: "admins-readonly"})
+	want := srv.AddGroup(pocketid.Group{GroupName: "ADMINS"})
+
+	got, err := c.GetGroupByExternalName(context.Background(), "ADMINS")
+	if err != nil {
+		t.Fatalf("c.GetGroupByExternalName(...): %v", err)
+	}
+	if diff := cmp.Diff(&want, got); diff != "" {
+		t.Errorf("c.GetGroupByExternalName(...): -want, +got:\n%s\n", diff)
+	}
+
+	groups, err := c.ListGroups(context.Background(), pocketid.ListOptions{Search: "readonly"})
+	if err != nil {
+		t.Fatalf("c.ListGroups(...): %v", err)
+	}
+	if len(groups) != 1 || groups[0].GroupName != "admins-readonly" {
+		t.Errorf("c.ListGroups(...): want only the matching group, got %v", groups)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+
+	for i := range 2*defaultPageSize + 1 {
+		srv.AddGroup(pocketid.Group{GroupName: fmt.Sprintf("group-%02d", i)})
+	}
+
+	groups, err := c.ListGroups(context.Background(), pocketid.ListOptions{})
+	if err != nil {
+		t.Fatalf("c.ListGroups(...): %v", err)
+	}
+	if diff := cmp.Diff(2*defaultPageSize+1, len(groups)); diff != "" {
+		t.Errorf("c.ListGroups(...): -want the groups of all pages, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(3, len(srv.Requests())); diff != "" {
+		t.Errorf("c.ListGroups(...): -want a request per page, +got:\n%s\n", diff)
+	}
+
+	groups, err = c.ListGroups(context.Background(), pocketid.ListOptions{Page: 2, Limit: 5})
+	if err != nil {
+		t.Fatalf("c.ListGroups(...): %v", err)
+	}
+	if len(groups) != 5 || groups[0].GroupName != "group-05" {
+		t.Errorf("c.ListGroups(...): want only the requested page, got %v", groups)
+	}
+}
+
+func TestLogo(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+
+	oc := srv.AddOIDCClient(pocketid.OIDCClient{ClientName: "app"})
+	png := []byte("\x89PNG\r\n\x1a\n")
+	url := srv.AddFile("logo", png)
+	if err := c.UploadOIDCClientLogo(context.Background(), oc.ID, url); err != nil {
+		t.Fatalf("c.UploadOIDCClientLogo(...): %v", err)
+	}
+
+	logo, _ := srv.Logo(oc.ID)
+	if diff := cmp.Diff(png, logo); diff != "" {
+		t.Errorf("srv.Logo(...): -want, +got:\n%s\n", diff)
+	}
+	if got, _ := srv.OIDCClient(oc.ID); !got.HasLogo {
+		t.Error("srv.OIDCClient(...): want the client to have a logo")
+	}
+
+	img, err := c.GetOIDCClientLogo(context.Background(), oc.ID)
+	if err != nil {
+		t.Fatalf("c.GetOIDCClientLogo(...): %v", err)
+	}
+	if diff := cmp.Diff(pocketid.ImageDigest(png), img.Digest()); diff != "" {
+		t.Errorf("c.GetOIDCClientLogo(...): -want digest of the uploaded logo, +got:\n%s\n", diff)
+	}
+	if err := c.DeleteOIDCClientLogo(context.Background(), oc.ID); err != nil {
+		t.Fatalf("c.DeleteOIDCClientLogo(...): %v", err)
+	}
+	if got, _ := srv.OIDCClient(oc.ID); got.HasLogo {
+		t.Error("srv.OIDCClient(...): want the client to have no logo once deleted")
+	}
+	if err := c.DeleteOIDCClientLogo(context.Background(), oc.ID); err != nil {
+		t.Errorf("c.DeleteOIDCClientLogo(...): want no error deleting a deleted logo, got %v", err)
+	}
+
+	none := srv.AddOIDCClient(pocketid.OIDCClient{ClientName: "other"})
+	if img, err := c.GetOIDCClientLogo(context.Background(), none.ID); err != nil || img != nil {
+		t.Errorf("c.GetOIDCClientLogo(...): want no logo and no error, got %v and %v", img, err)
+	}
+}
+
+func TestRegenerateOIDCClientSecret(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+
+	oc := srv.AddOIDCClient(pocketid.OIDCClient{ClientName: "app", ClientSecret: "old"})
+	secret, err := c.RegenerateOIDCClientSecret(context.Background(), oc.ID)
+	if err != nil {
+		t.Fatalf("c.RegenerateOIDCClientSecret(...): %v", err)
+	}
+	got, _ := srv.OIDCClient(oc.ID)
+	if secret == "old" || secret != got.ClientSecret {
+		t.Errorf("c.RegenerateOIDCClientSecret(...): want new secret %q, got %q", got.ClientSecret, secret)
+	}
+	if _, err := c.RegenerateOIDCClientSecret(context.Background(), "missing"); pocketid.StatusCode(err) != http.StatusNotFound {
+		t.Errorf("c.RegenerateOIDCClientSecret(...): want not found error, got %v", err)
+	}
+}
+
+func TestProfilePicture(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+	ctx := context.Background()
+
+	u := srv.AddUser(pocketid.User{Username: "alice", Email: "alice@example.com", FirstName: "Alice", LastName: "Doe"})
+	generated, err := c.GetUserProfilePicture(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("c.GetUserProfilePicture(...): %v", err)
+	}
+	if diff := cmp.Diff("image/png", generated.ContentType); diff != "" {
+		t.Errorf("c.GetUserProfilePicture(...): -want content type, +got:\n%s\n", diff)
+	}
+
+	picture := []byte("\x89PNG\r\n\x1a\npicture")
+	srv.SetProfilePicture(u.ID, picture)
+	got, err := c.GetUserProfilePicture(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("c.GetUserProfilePicture(...): %v", err)
+	}
+	if diff := cmp.Diff(picture, got.Content); diff != "" {
+		t.Errorf("c.GetUserProfilePicture(...): -want, +got:\n%s\n", diff)
+	}
+
+	if err := c.DeleteUserProfilePicture(ctx, u.ID); err != nil {
+		t.Fatalf("c.DeleteUserProfilePicture(...): %v", err)
+	}
+	got, err = c.GetUserProfilePicture(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("c.GetUserProfilePicture(...): %v", err)
+	}
+	if diff := cmp.Diff(generated, got); diff != "" {
+		t.Errorf("c.GetUserProfilePicture(...): -want the generated picture once deleted, +got:\n%s\n", diff)
+	}
+
+	if _, err := c.GetCurrentUserProfilePicture(ctx); err != nil {
+		t.Errorf("c.GetCurrentUserProfilePicture(...): %v", err)
+	}
+	if img, err := c.GetUserProfilePicture(ctx, "missing"); err != nil || img != nil {
+		t.Errorf("c.GetUserProfilePicture(...): want no picture and no error, got %v and %v", img, err)
+	}
+}
+
+func TestLogoTooLarge(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+
+	oc := srv.AddOIDCClient(pocketid.OIDCClient{ClientName: "app"})
+	url := srv.AddFile("huge.png", append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, pocketid.MaxLogoSize)...))
+	err := c.UploadOIDCClientLogo(context.Background(), oc.ID, url)
+
+	tl := &pocketid.TooLargeError{}
+	if !errors.As(err, &tl) {
+		t.Fatalf("c.UploadOIDCClientLogo(...): want a TooLargeError, got %v", err)
+	}
+	for _, r := range srv.Requests() {
+		if r == http.MethodPut+" /api/oidc/clients/"+oc.ID+"/logo" {
+			t.Error("c.UploadOIDCClientLogo(...): want no logo uploaded")
+		}
+	}
+}
+
+func TestFailures(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		setup  func(*Server)
+		cfg    func(pocketid.Config) pocketid.Config
+		want   int
+	}{
+		"Unauthorized": {
+			reason: "Requests with another API key should be rejected.",
+			cfg:    func(c pocketid.Config) pocketid.Config { c.APIKey = "wrong"; return c },
+			want:   http.StatusUnauthorized,
+		},
+		"Fail": {
+			reason: "Requests told to fail should fail with the supplied status.",
+			setup:  func(s *Server) { s.Fail(http.MethodGet, "/api/users", http.StatusServiceUnavailable, 1) },
+			want:   http.StatusServiceUnavailable,
+		},
+		"FailThenRecover": {
+			reason: "Requests told to fail only once should succeed when retried.",
+			setup:  func(s *Server) { s.Fail(http.MethodGet, "/api/users", http.StatusServiceUnavailable, 1) },
+			cfg:    func(c pocketid.Config) pocketid.Config { c.MaxRetries, c.RetryBackoff = 1, time.Millisecond; return c },
+		},
+		"Latency": {
+			reason: "Requests slower than the client timeout should fail.",
+			setup:  func(s *Server) { s.SetLatency(time.Second) },
+			cfg:    func(c pocketid.Config) pocketid.Config { c.Timeout = 10 * time.Millisecond; return c },
+			want:   -1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := NewServer()
+			defer srv.Close()
+			if tc.setup != nil {
+				tc.setup(srv)
+			}
+			cfg := srv.Config()
+			if tc.cfg != nil {
+				cfg = tc.cfg(cfg)
+			}
+
+			_, err := newClient(t, cfg).ListUsers(context.Background(), pocketid.ListOptions{})
+			got := pocketid.StatusCode(err)
+			if got == 0 && err != nil {
+				got = -1
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.ListUsers(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAppConfig(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+	ctx := context.Background()
+
+	config, err := c.GetAppConfig(ctx)
+	if err != nil {
+		t.Fatalf("c.GetAppConfig(...): %v", err)
+	}
+	config.AppName = "Example ID"
+	config.SMTPPassword = "secret"
+	if _, err := c.UpdateAppConfig(ctx, *config); err != nil {
+		t.Fatalf("c.UpdateAppConfig(...): %v", err)
+	}
+	if diff := cmp.Diff(*config, srv.AppConfig()); diff != "" {
+		t.Errorf("srv.AppConfig(): -want, +got:\n%s\n", diff)
+	}
+
+	public, err := newClient(t, pocketid.Config{Endpoint: srv.URL, APIKey: "invalid"}).GetPublicAppConfig(ctx)
+	if err != nil {
+		t.Fatalf("c.GetPublicAppConfig(...): %v", err)
+	}
+	want := &pocketid.AppConfig{
+		AppName:             "Example ID",
+		DisableAnimations:   "false",
+		AllowOwnAccountEdit: "true",
+		AllowUserSignups:    "disabled",
+		AccentColor:         "default",
+		LDAPEnabled:         "false",
+	}
+	if diff := cmp.Diff(want, public); diff != "" {
+		t.Errorf("c.GetPublicAppConfig(...): -want only public variables, +got:\n%s\n", diff)
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+	ctx := context.Background()
+
+	if _, err := c.CreateAPIKey(ctx, pocketid.CreateAPIKeyRequest{Name: "expired", ExpiresAt: time.Now().Add(-time.Hour)}); err == nil {
+		t.Errorf("c.CreateAPIKey(...): want error creating an expired key")
+	}
+
+	expires := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	k, err := c.CreateAPIKey(ctx, pocketid.CreateAPIKeyRequest{Name: "ci", ExpiresAt: expires})
+	if err != nil {
+		t.Fatalf("c.CreateAPIKey(...): %v", err)
+	}
+	if k.Token == "" {
+		t.Errorf("c.CreateAPIKey(...): want a token")
+	}
+
+	keys, err := c.ListAPIKeys(ctx, pocketid.ListOptions{})
+	if err != nil {
+		t.Fatalf("c.ListAPIKeys(...): %v", err)
+	}
+	if diff := cmp.Diff([]pocketid.APIKey{k.APIKey}, keys); diff != "" {
+		t.Errorf("c.ListAPIKeys(...): -want, +got:\n%s\n", diff)
+	}
+	if keys[0].ExpiresWithin(time.Now(), time.Hour) || !keys[0].ExpiresWithin(time.Now(), 48*time.Hour) {
+		t.Errorf("keys[0].ExpiresWithin(...): want the key to expire within 48 hours only")
+	}
+
+	if err := c.DeleteAPIKey(ctx, k.APIKey.ID); err != nil {
+		t.Fatalf("c.DeleteAPIKey(...): %v", err)
+	}
+	if err := c.DeleteAPIKey(ctx, k.APIKey.ID); err != nil {
+		t.Errorf("c.DeleteAPIKey(...): want no error deleting a deleted key, got %v", err)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+	ctx := context.Background()
+
+	u := srv.AddUser(pocketid.User{Username: "alice", Email: "alice@example.com", FirstName: "Alice"})
+	at, err := c.CreateOneTimeAccessToken(ctx, u.ID, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("c.CreateOneTimeAccessToken(...): %v", err)
+	}
+	if id, _ := srv.AccessTokenUser(at.Token); id != u.ID {
+		t.Errorf("c.CreateOneTimeAccessToken(...): want a token signing in %q, got %q", u.ID, id)
+	}
+	if diff := cmp.Diff(srv.URL+"/lc/"+at.Token, at.URL); diff != "" {
+		t.Errorf("c.CreateOneTimeAccessToken(...): -want URL, +got:\n%s\n", diff)
+	}
+
+	st, err := c.CreateSignupToken(ctx, pocketid.CreateSignupTokenRequest{TTL: 24 * time.Hour, UsageLimit: 5})
+	if err != nil {
+		t.Fatalf("c.CreateSignupToken(...): %v", err)
+	}
+	if diff := cmp.Diff(srv.URL+"/st/"+st.Token, st.URL); diff != "" {
+		t.Errorf("c.CreateSignupToken(...): -want URL, +got:\n%s\n", diff)
+	}
+	tokens, err := c.ListSignupTokens(ctx, pocketid.ListOptions{})
+	if err != nil {
+		t.Fatalf("c.ListSignupTokens(...): %v", err)
+	}
+	if diff := cmp.Diff([]pocketid.SignupToken{*st}, tokens); diff != "" {
+		t.Errorf("c.ListSignupTokens(...): -want, +got:\n%s\n", diff)
+	}
+	if err := c.DeleteSignupToken(ctx, st.ID); err != nil {
+		t.Fatalf("c.DeleteSignupToken(...): %v", err)
+	}
+}
+
+func TestAuditLogs(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	var logs []pocketid.AuditLog
+	for i := range 30 {
+		event := "SIGN_IN"
+		if i%2 == 0 {
+			event = "TOKEN_SIGN_IN"
+		}
+		logs = append(logs, srv.AddAuditLog(pocketid.AuditLog{CreatedAt: now.Add(-time.Duration(i) * time.Hour), Event: event, UserID: "admin"}))
+	}
+
+	got, err := c.ListAuditLogs(ctx, pocketid.AuditLogFilter{Since: now.Add(-4 * time.Hour), Until: now.Add(-time.Hour), Limit: 2})
+	if err != nil {
+		t.Fatalf("c.ListAuditLogs(...): %v", err)
+	}
+	if diff := cmp.Diff(logs[2:5], got); diff != "" {
+		t.Errorf("c.ListAuditLogs(...): -want entries of the time range, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(3, len(srv.Requests())); diff != "" {
+		t.Errorf("c.ListAuditLogs(...): -want no page past Since requested, +got:\n%s\n", diff)
+	}
+
+	got, err = c.ListAuditLogs(ctx, pocketid.AuditLogFilter{Event: "SIGN_IN"})
+	if err != nil {
+		t.Fatalf("c.ListAuditLogs(...): %v", err)
+	}
+	if diff := cmp.Diff(15, len(got)); diff != "" {
+		t.Errorf("c.ListAuditLogs(...): -want entries of the event, +got:\n%s\n", diff)
+	}
+}
+
+func TestOpenIDConfiguration(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+
+	config, err := c.GetOpenIDConfiguration(context.Background())
+	if err != nil {
+		t.Fatalf("c.GetOpenIDConfiguration(...): %v", err)
+	}
+	if diff := cmp.Diff(srv.URL+"/api/oidc/token", config.TokenEndpoint); diff != "" {
+		t.Errorf("c.GetOpenIDConfiguration(...): -want token endpoint, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(1, len(config.JWKS.Keys)); diff != "" {
+		t.Errorf("c.GetOpenIDConfiguration(...): -want keys, +got:\n%s\n", diff)
+	}
+
+	srv.Fail(http.MethodGet, "/.well-known/jwks.json", http.StatusNotFound, 1)
+	if _, err := c.GetOpenIDConfiguration(context.Background()); !errors.Is(err, pocketid.ErrNotIssuer) {
+		t.Errorf("c.GetOpenIDConfiguration(...): want ErrNotIssuer without keys, got %v", err)
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, pocketid.Config{Endpoint: srv.URL, APIKey: "invalid"})
+
+	if err := c.Healthz(context.Background()); err != nil {
+		t.Errorf("c.Healthz(...): want a healthy server without valid credentials, got %v", err)
+	}
+	srv.Fail(http.MethodGet, "/healthz", http.StatusInternalServerError, 0)
+	if err := c.Healthz(context.Background()); err == nil {
+		t.Error("c.Healthz(...): want error from an unhealthy server")
+	}
+}
+
+func TestRequests(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.Config())
+	ctx := context.Background()
+	u := srv.AddUser(pocketid.User{Username: "alice", Email: "alice@example.com", FirstName: "Alice"})
+
+	cases := map[string]struct {
+		reason string
+		call   func() (any, error)
+		want   any
+		status int
+	}{
+		"Get": {
+			reason: "Objects should be decoded from their JSON response.",
+			call:   func() (any, error) { return c.GetUser(ctx, u.ID) },
+			want:   &u,
+		},
+		"GetNotFound": {
+			reason: "Objects that do not exist should be returned as nil without error.",
+			call:   func() (any, error) { return c.GetUser(ctx, "missing") },
+			want:   (*pocketid.User)(nil),
+		},
+		"PutError": {
+			reason: "Error responses should be returned as API errors.",
+			call: func() (any, error) {
+				return c.UpdateUser(ctx, u.ID, pocketid.UpdateUserRequest{Username: "admin", Email: "other@example.com", FirstName: "A"})
+			},
+			want:   (*pocketid.User)(nil),
+			status: http.StatusConflict,
+		},
+		"RemoveNotFound": {
+			reason: "Deleting what does not exist should not be an error.",
+			call:   func() (any, error) { return nil, c.DeleteGroup(ctx, "missing") },
+		},
+		"SendNotFound": {
+			reason: "Requests sent about what does not exist should return not found errors.",
+			call:   func() (any, error) { return nil, c.AddUserToGroup(ctx, u.ID, "missing") },
+			status: http.StatusNotFound,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.call()
+			if diff := cmp.Diff(tc.status, pocketid.StatusCode(err)); diff != "" {
+				t.Errorf("\n%s\n-want status, +got (error %v):\n%s\n", tc.reason, err, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\n-want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
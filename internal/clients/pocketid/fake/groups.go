@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/http"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	groups := []pocketid.Group{}
+	for _, id := range sortedKeys(s.groups) {
+		if g := s.groups[id]; matches(r, g.GroupName, g.FriendlyName) {
+			groups = append(groups, *g)
+		}
+	}
+	writePage(w, r, groups)
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Group not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	req := pocketid.CreateGroupRequest{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.validGroup(w, "", req.GroupName) {
+		return
+	}
+	g := &pocketid.Group{ID: s.id(""), GroupName: req.GroupName, FriendlyName: req.FriendlyName, CustomClaims: req.CustomClaims}
+	s.groups[g.ID] = g
+	writeJSON(w, http.StatusCreated, g)
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request) {
+	req := pocketid.UpdateGroupRequest{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Group not found")
+		return
+	}
+	if !s.validGroup(w, g.ID, req.GroupName) {
+		return
+	}
+	g.GroupName, g.FriendlyName, g.CustomClaims = req.GroupName, req.FriendlyName, req.CustomClaims
+	writeJSON(w, http.StatusOK, g)
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.groups[id]; !ok {
+		writeError(w, http.StatusNotFound, "Group not found")
+		return
+	}
+	delete(s.groups, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validGroup writes an error response if the supplied group name is missing
+// or used by another group than the one with the supplied ID.
+func (s *Server) validGroup(w http.ResponseWriter, id, name string) bool {
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return false
+	}
+	for _, g := range s.groups {
+		if g.ID != id && g.GroupName == name {
+			writeError(w, http.StatusConflict, "Group name is already in use")
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// AccessTokenUser returns the ID of the user the supplied one-time access
+// token signs in.
+func (s *Server) AccessTokenUser(token string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.accessTokens[token]
+	return id, ok
+}
+
+func (s *Server) createOneTimeAccessToken(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		ExpiresAt time.Time `json:"expiresAt"`
+	}{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.users[id]; !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	token := newToken()
+	s.accessTokens[token] = id
+	writeJSON(w, http.StatusCreated, map[string]string{"token": token})
+}
+
+func (s *Server) listSignupTokens(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens := []pocketid.SignupToken{}
+	for _, id := range sortedKeys(s.signupTokens) {
+		tokens = append(tokens, *s.signupTokens[id])
+	}
+	writePage(w, r, tokens)
+}
+
+func (s *Server) createSignupToken(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		TTL        string `json:"ttl"`
+		UsageLimit int    `json:"usageLimit"`
+	}{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	ttl, err := time.ParseDuration(req.TTL)
+	if err != nil || ttl <= 0 || req.UsageLimit < 1 || req.UsageLimit > 100 {
+		writeError(w, http.StatusBadRequest, "Invalid TTL or usage limit")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now().UTC()
+	t := &pocketid.SignupToken{
+		ID:         s.id(""),
+		Token:      newToken(),
+		ExpiresAt:  now.Add(ttl),
+		UsageLimit: req.UsageLimit,
+		CreatedAt:  now,
+	}
+	s.signupTokens[t.ID] = t
+	writeJSON(w, http.StatusCreated, t)
+}
+
+func (s *Server) deleteSignupToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.signupTokens[id]; !ok {
+		writeError(w, http.StatusNotFound, "Signup token not found")
+		return
+	}
+	delete(s.signupTokens, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newToken returns a random token.
+func newToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
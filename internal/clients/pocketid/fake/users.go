@@ -0,0 +1,258 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := []pocketid.User{}
+	for _, id := range sortedKeys(s.users) {
+		u := s.user(id)
+		if matches(r, u.Username, u.Email, u.FirstName, u.LastName) {
+			users = append(users, u)
+		}
+	}
+	writePage(w, r, users)
+}
+
+func (s *Server) getCurrentUser(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.user("admin"))
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.users[id]; !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.user(id))
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	req := pocketid.CreateUserRequest{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.validUser(w, "", req.Username, req.Email) {
+		return
+	}
+	u := &pocketid.User{
+		ID:           s.id(""),
+		Username:     req.Username,
+		Email:        req.Email,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Locale:       req.Locale,
+		Disabled:     req.Disabled,
+		IsAdmin:      req.IsAdmin,
+		CustomClaims: req.CustomClaims,
+	}
+	s.users[u.ID] = u
+	writeJSON(w, http.StatusCreated, s.user(u.ID))
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	req := pocketid.UpdateUserRequest{}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	if !s.validUser(w, u.ID, req.Username, req.Email) {
+		return
+	}
+	u.Username, u.Email, u.FirstName, u.LastName = req.Username, req.Email, req.FirstName, req.LastName
+	u.Locale, u.Disabled, u.CustomClaims = req.Locale, req.Disabled, req.CustomClaims
+	writeJSON(w, http.StatusOK, s.user(u.ID))
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.users[id]; !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	delete(s.users, id)
+	delete(s.userGroups, id)
+	delete(s.pictures, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getProfilePicture serves the profile picture of a user, generating one from
+// their initials if they have none, as Pocket ID does.
+func (s *Server) getProfilePicture(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if id == "me" {
+		id = "admin"
+	}
+	u, ok := s.users[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	picture, ok := s.pictures[id]
+	if !ok {
+		picture = append([]byte(pngMagic), initials(u)...)
+	}
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(picture)
+}
+
+func (s *Server) deleteProfilePicture(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.users[id]; !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	delete(s.pictures, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pngMagic starts the content of PNG images.
+const pngMagic = "\x89PNG\r\n\x1a\n"
+
+func initials(u *pocketid.User) string {
+	var s string
+	for _, n := range []string{u.FirstName, u.LastName} {
+		if n != "" {
+			s += strings.ToUpper(n[:1])
+		}
+	}
+	return s
+}
+
+func (s *Server) setUserGroups(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserGroupIDs []string `json:"userGroupIds"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := s.users[id]; !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	if !s.validGroups(w, req.UserGroupIDs) {
+		return
+	}
+	s.userGroups[id] = slices.Clone(req.UserGroupIDs)
+	writeJSON(w, http.StatusOK, s.user(id))
+}
+
+func (s *Server) addUserToGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, group := r.PathValue("id"), r.PathValue("group")
+	if _, ok := s.users[id]; !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	if !s.validGroups(w, []string{group}) {
+		return
+	}
+	if !slices.Contains(s.userGroups[id], group) {
+		s.userGroups[id] = append(s.userGroups[id], group)
+	}
+	writeJSON(w, http.StatusOK, s.user(id))
+}
+
+func (s *Server) removeUserFromGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, group := r.PathValue("id"), r.PathValue("group")
+	i := slices.Index(s.userGroups[id], group)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "User is not in the group")
+		return
+	}
+	s.userGroups[id] = slices.Delete(s.userGroups[id], i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validUser writes an error response if the supplied username or email is
+// missing or used by another user than the one with the supplied ID.
+func (s *Server) validUser(w http.ResponseWriter, id, username, email string) bool {
+	if username == "" || email == "" {
+		writeError(w, http.StatusBadRequest, "username and email are required")
+		return false
+	}
+	for _, u := range s.users {
+		if u.ID == id {
+			continue
+		}
+		if u.Username == username {
+			writeError(w, http.StatusConflict, "Username is already in use")
+			return false
+		}
+		if u.Email == email {
+			writeError(w, http.StatusConflict, "Email is already in use")
+			return false
+		}
+	}
+	return true
+}
+
+// validGroups writes an error response if any of the supplied groups does not
+// exist.
+func (s *Server) validGroups(w http.ResponseWriter, ids []string) bool {
+	for _, id := range ids {
+		if _, ok := s.groups[id]; !ok {
+			writeError(w, http.StatusNotFound, "Group not found")
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys returns the keys of the supplied map in order, so that lists are
+// stable.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -18,9 +18,6 @@ package pocketid
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // Group represents a group in Pocket ID API
@@ -29,6 +26,7 @@ type Group struct {
 	GroupName    string            `json:"groupName"`
 	FriendlyName string            `json:"friendlyName,omitempty"`
 	CustomClaims map[string]string `json:"customClaims,omitempty"`
+	LdapID       string            `json:"ldapId,omitempty"`
 }
 
 // CreateGroupRequest represents the request payload for creating a group
@@ -47,32 +45,12 @@ type UpdateGroupRequest struct {
 
 // GetGroup retrieves a group by ID
 func (c *Client) GetGroup(ctx context.Context, groupID string) (*Group, error) {
-	resp, err := c.makeRequest(ctx, "GET", fmt.Sprintf("/api/groups/%s", groupID), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get group: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil // Group doesn't exist
-	}
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var group Group
-	if err := json.Unmarshal(body, &group); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal group response: %w", err)
-	}
-
-	return &group, nil
+	return get[Group](ctx, c, pathf("/api/groups/%s", groupID), "group")
 }
 
 // GetGroupByExternalName retrieves a group by group name (external name)
 func (c *Client) GetGroupByExternalName(ctx context.Context, groupName string) (*Group, error) {
-	groups, err := c.ListGroups(ctx)
+	groups, err := c.ListGroups(ctx, ListOptions{Search: groupName})
 	if err != nil {
 		return nil, err
 	}
@@ -86,81 +64,24 @@ func (c *Client) GetGroupByExternalName(ctx context.Context, groupName string) (
 	return nil, nil // Group not found
 }
 
-// ListGroups retrieves all groups
-func (c *Client) ListGroups(ctx context.Context) ([]Group, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/api/groups", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list groups: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var groups []Group
-	if err := json.Unmarshal(body, &groups); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal groups response: %w", err)
-	}
-
-	return groups, nil
+// ListGroups retrieves the groups matching the supplied options
+func (c *Client) ListGroups(ctx context.Context, opts ListOptions) ([]Group, error) {
+	return list[Group](ctx, c, "/api/groups", "groups", opts)
 }
 
 // CreateGroup creates a new group
 func (c *Client) CreateGroup(ctx context.Context, req CreateGroupRequest) (*Group, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/api/groups", req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create group: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var group Group
-	if err := json.Unmarshal(body, &group); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal group response: %w", err)
-	}
-
-	return &group, nil
+	return create(ctx, c, "/api/groups", "group", req, func(ctx context.Context) (*Group, error) {
+		return c.GetGroupByExternalName(ctx, req.GroupName)
+	})
 }
 
 // UpdateGroup updates an existing group
 func (c *Client) UpdateGroup(ctx context.Context, groupID string, req UpdateGroupRequest) (*Group, error) {
-	resp, err := c.makeRequest(ctx, "PUT", fmt.Sprintf("/api/groups/%s", groupID), req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update group: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var group Group
-	if err := json.Unmarshal(body, &group); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal group response: %w", err)
-	}
-
-	return &group, nil
+	return put[Group](ctx, c, pathf("/api/groups/%s", groupID), "group", req)
 }
 
 // DeleteGroup deletes a group by ID
 func (c *Client) DeleteGroup(ctx context.Context, groupID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/api/groups/%s", groupID), nil)
-	if err != nil {
-		return fmt.Errorf("failed to delete group: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil // Already deleted
-	}
-
-	_, err = checkResponse(resp)
-	return err
+	return c.remove(ctx, pathf("/api/groups/%s", groupID), "delete group")
 }
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+)
+
+// Image represents an image served by Pocket ID, such as the logo of an OIDC
+// client or the profile picture of a user
+type Image struct {
+	Content     []byte
+	ContentType string
+}
+
+// Digest returns the digest of the content of the image, to compare it with
+// the digest of the content it should have without keeping either around
+func (i *Image) Digest() string {
+	return ImageDigest(i.Content)
+}
+
+// ImageDigest returns the digest of the supplied image content, as
+// sha256:<hex>
+func ImageDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// GetOIDCClientLogo retrieves the logo of an OIDC client, or nil if it has
+// none. Logos are served as uploaded.
+func (c *Client) GetOIDCClientLogo(ctx context.Context, clientID string) (*Image, error) {
+	return c.getImage(ctx, fmt.Sprintf("/api/oidc/clients/%s/logo", clientID), "logo")
+}
+
+// GetUserProfilePicture retrieves the profile picture of a user, or nil if
+// the user does not exist. Pocket ID resizes uploaded pictures to PNG and
+// generates one from the initials of users without any, so unlike logos the
+// picture cannot be compared with the uploaded content.
+func (c *Client) GetUserProfilePicture(ctx context.Context, userID string) (*Image, error) {
+	return c.getImage(ctx, fmt.Sprintf("/api/users/%s/profile-picture.png", userID), "profile picture")
+}
+
+// GetCurrentUserProfilePicture retrieves the profile picture of the user
+// owning the API key
+func (c *Client) GetCurrentUserProfilePicture(ctx context.Context) (*Image, error) {
+	return c.getImage(ctx, "/api/users/me/profile-picture.png", "profile picture")
+}
+
+// DeleteOIDCClientLogo deletes the logo of an OIDC client, if it has any
+func (c *Client) DeleteOIDCClientLogo(ctx context.Context, clientID string) error {
+	return c.deleteImage(ctx, fmt.Sprintf("/api/oidc/clients/%s/logo", clientID), "logo")
+}
+
+// DeleteUserProfilePicture deletes the profile picture uploaded for a user,
+// who is then shown a picture generated from their initials
+func (c *Client) DeleteUserProfilePicture(ctx context.Context, userID string) error {
+	return c.deleteImage(ctx, fmt.Sprintf("/api/users/%s/profile-picture", userID), "profile picture")
+}
+
+// getImage retrieves the image at the supplied path, no larger than
+// MaxLogoSize, or nil if there is none.
+func (c *Client) getImage(ctx context.Context, path, kind string) (*Image, error) {
+	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", kind, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil // No image
+	}
+	if resp.ContentLength > MaxLogoSize {
+		return nil, &TooLargeError{Limit: MaxLogoSize}
+	}
+
+	resp.Body = newLimitedBody(resp.Body, MaxLogoSize)
+	body, err := checkResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Image{Content: body, ContentType: resp.Header.Get("Content-Type")}, nil
+}
+
+func (c *Client) deleteImage(ctx context.Context, path, kind string) error {
+	return c.remove(ctx, path, "delete "+kind)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Sort directions of ListOptions.
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// ListOptions filter, paginate and sort the objects returned by list calls.
+// Zero values are left to the defaults of the server.
+type ListOptions struct {
+	// Search only returns the objects whose names or other searchable
+	// fields contain it.
+	Search string
+
+	// Page is the page to return, starting at 1, of Limit objects each. All
+	// pages are returned when it is not set.
+	Page  int
+	Limit int
+
+	// SortColumn is the field to sort by, in SortDirection.
+	SortColumn    string
+	SortDirection string
+
+	// Filters only returns the objects whose fields equal the values of the
+	// fields they are keyed by, for the lists supporting them.
+	Filters map[string]string
+}
+
+// path returns the supplied list path with the query encoding the options.
+func (o ListOptions) path(p string) string {
+	q := url.Values{}
+	if o.Search != "" {
+		q.Set("search", o.Search)
+	}
+	if o.Page > 0 {
+		q.Set("pagination[page]", strconv.Itoa(o.Page))
+	}
+	if o.Limit > 0 {
+		q.Set("pagination[limit]", strconv.Itoa(o.Limit))
+	}
+	if o.SortColumn != "" {
+		q.Set("sort[column]", o.SortColumn)
+	}
+	if o.SortDirection != "" {
+		q.Set("sort[direction]", o.SortDirection)
+	}
+	for k, v := range o.Filters {
+		q.Set("filters["+k+"]", v)
+	}
+	return withQuery(p, q)
+}
+
+// Pagination describes the page returned by a list call.
+type Pagination struct {
+	TotalPages   int `json:"totalPages"`
+	TotalItems   int `json:"totalItems"`
+	CurrentPage  int `json:"currentPage"`
+	ItemsPerPage int `json:"itemsPerPage"`
+}
+
+// page is the envelope of the responses of list calls.
+type page[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// list retrieves the objects of the supplied list path matching the options,
+// following the pages of the response unless a page was requested.
+func list[T any](ctx context.Context, c *Client, p, kind string, opts ListOptions) ([]T, error) {
+	all := opts.Page == 0
+	if all {
+		opts.Page = 1
+	}
+
+	var objs []T
+	for {
+		pg, err := listPage[T](ctx, c, p, kind, opts)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, pg.Data...)
+
+		// Objects created or deleted while paging may shift the pages, which
+		// is resolved by the next poll like any other concurrent change.
+		if !all || len(pg.Data) == 0 || opts.Page >= pg.Pagination.TotalPages {
+			return objs, nil
+		}
+		opts.Page++
+	}
+}
+
+func listPage[T any](ctx context.Context, c *Client, p, kind string, opts ListOptions) (*page[T], error) {
+	return call[page[T]](ctx, c, http.MethodGet, opts.path(p), "list "+kind, kind, nil)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListOptionsPath(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		opts   ListOptions
+		want   string
+	}{
+		"Empty": {
+			reason: "No query should be added without options.",
+			want:   "/api/users",
+		},
+		"Search": {
+			reason: "The search query should be escaped.",
+			opts:   ListOptions{Search: "j doe&x"},
+			want:   "/api/users?search=j+doe%26x",
+		},
+		"All": {
+			reason: "Pagination and sorting should use the parameter names of Pocket ID.",
+			opts:   ListOptions{Search: "jdoe", Page: 2, Limit: 50, SortColumn: "username", SortDirection: SortDescending},
+			want:   "/api/users?pagination%5Blimit%5D=50&pagination%5Bpage%5D=2&search=jdoe&sort%5Bcolumn%5D=username&sort%5Bdirection%5D=desc",
+		},
+		"Filters": {
+			reason: "Filters should be keyed by the field they filter.",
+			opts:   ListOptions{Filters: map[string]string{"event": "SIGN_IN", "userId": "1"}},
+			want:   "/api/users?filters%5Bevent%5D=SIGN_IN&filters%5BuserId%5D=1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.opts.path("/api/users")); diff != "" {
+				t.Errorf("\n%s\no.path(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// sniffLen is how many bytes of a logo are read to detect its format, as
+// many as http.DetectContentType considers.
+const sniffLen = 512
+
+// logoAccept is the Accept header of logo downloads, asking servers able to
+// negotiate content for a format Pocket ID supports.
+const logoAccept = "image/png, image/jpeg, image/gif, image/svg+xml;q=0.9"
+
+// logoExtensions are the extensions of the logo formats supported by Pocket
+// ID, by media type.
+var logoExtensions = map[string]string{
+	"image/png":     ".png",
+	"image/jpeg":    ".jpg",
+	"image/gif":     ".gif",
+	"image/svg+xml": ".svg",
+}
+
+// imageExtension returns the extension of the format of a logo served with the
+// supplied Content-Type and starting with head, if Pocket ID supports it. The
+// content is trusted over the Content-Type, which servers often set to a
+// generic type such as application/octet-stream.
+func imageExtension(contentType string, head []byte) (string, bool) {
+	if ext, ok := logoExtensions[mediaType(http.DetectContentType(head))]; ok {
+		return ext, true
+	}
+	if ext, ok := logoExtensions[mediaType(contentType)]; ok {
+		return ext, true
+	}
+	// SVG is XML, which cannot be sniffed as an image.
+	if bytes.Contains(bytes.ToLower(head), []byte("<svg")) {
+		return ".svg", true
+	}
+	return "", false
+}
+
+func mediaType(contentType string) string {
+	mt, _, _ := mime.ParseMediaType(contentType)
+	return mt
+}
+
+// logoFilename returns the name, without extension, of a logo downloaded from
+// the supplied URL.
+func logoFilename(logoURL string) string {
+	if u, err := url.Parse(logoURL); err == nil {
+		if base := path.Base(u.Path); base != "." && base != "/" {
+			return strings.TrimSuffix(base, path.Ext(base))
+		}
+	}
+	return "logo"
+}
+
+// ParseDataURI returns the content and media type of an RFC 2397 data: URI,
+// such as data:image/png;base64,iVBORw0KGgo=. Content larger than MaxLogoSize
+// is rejected with a TooLargeError.
+func ParseDataURI(uri string) ([]byte, string, error) {
+	meta, data, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, "", errors.New("missing comma before the data")
+	}
+
+	mt, isBase64 := strings.CutSuffix(meta, ";base64")
+	var content []byte
+	if isBase64 {
+		b, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid base64 data: %w", err)
+		}
+		content = b
+	} else {
+		s, err := url.PathUnescape(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid percent-encoded data: %w", err)
+		}
+		content = []byte(s)
+	}
+
+	if len(content) > MaxLogoSize {
+		return nil, "", &TooLargeError{Limit: MaxLogoSize}
+	}
+	return content, mt, nil
+}
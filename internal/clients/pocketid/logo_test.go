@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestImageExtension(t *testing.T) {
+	type want struct {
+		ext string
+		ok  bool
+	}
+	cases := map[string]struct {
+		reason      string
+		contentType string
+		head        string
+		want        want
+	}{
+		"SniffedPNG": {
+			reason:      "PNG logos should be detected from their content whatever their Content-Type.",
+			contentType: "application/octet-stream",
+			head:        "\x89PNG\r\n\x1a\n",
+			want:        want{ext: ".png", ok: true},
+		},
+		"ContentType": {
+			reason:      "Logos whose content is not recognised should be detected from their Content-Type.",
+			contentType: "image/svg+xml; charset=utf-8",
+			head:        `<?xml version="1.0"?>`,
+			want:        want{ext: ".svg", ok: true},
+		},
+		"SVG": {
+			reason:      "SVG logos served with a generic Content-Type should be detected from their content.",
+			contentType: "text/plain",
+			head:        `<?xml version="1.0"?><SVG xmlns="http://www.w3.org/2000/svg">`,
+			want:        want{ext: ".svg", ok: true},
+		},
+		"HTML": {
+			reason:      "Content that is not an image, such as the login page of a CDN, should be rejected.",
+			contentType: "text/html",
+			head:        "<!DOCTYPE html><html>",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ext, ok := imageExtension(tc.contentType, []byte(tc.head))
+			if diff := cmp.Diff(tc.want, want{ext: ext, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nimageExtension(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLogoFilename(t *testing.T) {
+	cases := map[string]struct {
+		url  string
+		want string
+	}{
+		"Extension":   {url: "https://cdn.example.org/logo.jpeg?sig=abc", want: "logo"},
+		"NoExtension": {url: "https://cdn.example.org/assets/1234", want: "1234"},
+		"NoPath":      {url: "https://cdn.example.org", want: "logo"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, logoFilename(tc.url)); diff != "" {
+				t.Errorf("logoFilename(...): -want, +got:\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestParseDataURI(t *testing.T) {
+	type want struct {
+		content     string
+		contentType string
+		err         bool
+	}
+	cases := map[string]struct {
+		reason string
+		uri    string
+		want   want
+	}{
+		"Base64": {
+			reason: "Base64 encoded data should be decoded.",
+			uri:    "data:image/png;base64,iVBORw0KGgo=",
+			want:   want{content: "\x89PNG\r\n\x1a\n", contentType: "image/png"},
+		},
+		"PercentEncoded": {
+			reason: "Percent-encoded data, as used for SVG, should be unescaped.",
+			uri:    "data:image/svg+xml,%3Csvg%3E%3C/svg%3E",
+			want:   want{content: "<svg></svg>", contentType: "image/svg+xml"},
+		},
+		"NoComma": {
+			reason: "A data URI without data should be rejected.",
+			uri:    "data:image/png;base64",
+			want:   want{err: true},
+		},
+		"InvalidBase64": {
+			reason: "Invalid base64 data should be rejected.",
+			uri:    "data:image/png;base64,!!!",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			content, contentType, err := ParseDataURI(tc.uri)
+			got := want{content: string(content), contentType: contentType, err: err != nil}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nparseDataURI(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUploadOIDCClientLogoContent(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\nlogo"
+
+	cases := map[string]struct {
+		reason      string
+		current     string
+		wantUploads int
+	}{
+		"NoLogo": {
+			reason:      "A logo should be uploaded for a client without any.",
+			wantUploads: 1,
+		},
+		"Changed": {
+			reason:      "A logo should be uploaded for a client with another one.",
+			current:     "\x89PNG\r\n\x1a\nold",
+			wantUploads: 1,
+		},
+		"Unchanged": {
+			reason:  "A logo should not be uploaded again for a client with the same one.",
+			current: png,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			uploads := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					if tc.current == "" {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					w.Header().Set("Content-Type", "image/png")
+					_, _ = w.Write([]byte(tc.current))
+				case http.MethodPut:
+					uploads++
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer srv.Close()
+
+			c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key"})
+			if err != nil {
+				t.Fatalf("\n%s\nNewClientFromConfig(...): unexpected error: %v", tc.reason, err)
+			}
+			if err := c.UploadOIDCClientLogoContent(context.Background(), "id", strings.NewReader(png), ""); err != nil {
+				t.Fatalf("\n%s\nc.UploadOIDCClientLogoContent(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.wantUploads, uploads); diff != "" {
+				t.Errorf("\n%s\nc.UploadOIDCClientLogoContent(...): -want uploads, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pocketid_api_requests_total",
+		Help: "Total number of requests sent to the Pocket ID API, by method, path template and status code.",
+	}, []string{"method", "path", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pocketid_api_request_duration_seconds",
+		Help:    "Duration of the requests sent to the Pocket ID API, by method and path template.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+
+	requestSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pocketid_api_request_size_bytes",
+		Help:    "Size of the bodies of the requests sent to the Pocket ID API, by method and path template.",
+		Buckets: sizeBuckets,
+	}, []string{"method", "path"})
+
+	responseSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pocketid_api_response_size_bytes",
+		Help:    "Uncompressed size of the bodies of the responses read from the Pocket ID API, by method and path template.",
+		Buckets: sizeBuckets,
+	}, []string{"method", "path"})
+)
+
+// sizeBuckets range from 256B to 16MiB, as lists of every user or client of
+// large instances reach megabytes.
+var sizeBuckets = prometheus.ExponentialBuckets(256, 4, 10)
+
+// idPattern matches the UUIDs identifying Pocket ID objects in API paths.
+var idPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Collectors returns the collectors of the metrics of the requests sent to
+// the Pocket ID API, to be registered with the metrics of the provider.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{requestsTotal, requestDuration, requestSize, responseSize}
+}
+
+// observeRequest records the metrics of a request that completed with the
+// supplied response or error. Each attempt of a retried request is recorded.
+func observeRequest(req *http.Request, resp *http.Response, err error, d time.Duration) {
+	path := pathTemplate(req.URL.Path)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	requestsTotal.WithLabelValues(req.Method, path, status).Inc()
+	requestDuration.WithLabelValues(req.Method, path).Observe(d.Seconds())
+	if req.ContentLength > 0 {
+		requestSize.WithLabelValues(req.Method, path).Observe(float64(req.ContentLength))
+	}
+}
+
+// A sizedBody records the size of the response body it wraps once closed,
+// counting the bytes actually read rather than trusting Content-Length, which
+// compressed and chunked responses lack.
+type sizedBody struct {
+	io.ReadCloser
+	observer prometheus.Observer
+	n        int64
+}
+
+func newSizedBody(req *http.Request, body io.ReadCloser) *sizedBody {
+	return &sizedBody{ReadCloser: body, observer: responseSize.WithLabelValues(req.Method, pathTemplate(req.URL.Path))}
+}
+
+func (b *sizedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *sizedBody) Close() error {
+	if b.observer != nil {
+		b.observer.Observe(float64(b.n))
+		b.observer = nil
+	}
+	return b.ReadCloser.Close()
+}
+
+// pathTemplate returns the supplied request path with the IDs it contains
+// replaced by {id}, and without the path of the endpoint, so that metrics
+// have a bounded number of labels. Paths outside of the API, such as logos
+// downloaded from their URL, are reported as "other".
+func pathTemplate(p string) string {
+	i := strings.Index(p, "/api/")
+	if i < 0 {
+		return "other"
+	}
+
+	segments := strings.Split(p[i+1:], "/")
+	for j, s := range segments {
+		if idPattern.MatchString(s) {
+			segments[j] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
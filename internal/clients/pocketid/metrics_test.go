@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPathTemplate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		path   string
+		want   string
+	}{
+		"Collection": {
+			reason: "Paths without IDs should be kept.",
+			path:   "/api/users",
+			want:   "/api/users",
+		},
+		"IDs": {
+			reason: "Every ID of a path should be replaced.",
+			path:   "/api/users/0b1e5e2c-7a9d-4c1a-9f3e-2d4b6a8c0e1f/groups/6f1c2b3a-4d5e-4f60-8a7b-9c0d1e2f3a4b",
+			want:   "/api/users/{id}/groups/{id}",
+		},
+		"EndpointPath": {
+			reason: "The path of the endpoint should be removed.",
+			path:   "/auth/api/users/me",
+			want:   "/api/users/me",
+		},
+		"Other": {
+			reason: "Paths outside of the API should not be reported.",
+			path:   "/logos/app.png",
+			want:   "other",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, pathTemplate(tc.path)); diff != "" {
+				t.Errorf("\n%s\npathTemplate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key"})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+
+	requests := requestsTotal.WithLabelValues(http.MethodGet, "/api/groups/{id}", "404")
+	before := testutil.ToFloat64(requests)
+	if _, err := c.GetGroup(context.Background(), "0b1e5e2c-7a9d-4c1a-9f3e-2d4b6a8c0e1f"); err != nil {
+		t.Fatalf("c.GetGroup(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(before+1, testutil.ToFloat64(requests)); diff != "" {
+		t.Errorf("c.GetGroup(...): -want a recorded request, +got:\n%s\n", diff)
+	}
+}
+
+func TestSizedBody(t *testing.T) {
+	var got []float64
+	b := &sizedBody{
+		ReadCloser: io.NopCloser(strings.NewReader(`{"data":[]}`)),
+		observer:   prometheus.ObserverFunc(func(v float64) { got = append(got, v) }),
+	}
+	if _, err := io.Copy(io.Discard, b); err != nil {
+		t.Fatalf("io.Copy(...): unexpected error: %v", err)
+	}
+	for range 2 {
+		if err := b.Close(); err != nil {
+			t.Fatalf("b.Close(): unexpected error: %v", err)
+		}
+	}
+	if diff := cmp.Diff([]float64{11}, got); diff != "" {
+		t.Errorf("b.Close(): -want the size read recorded once, +got:\n%s\n", diff)
+	}
+}
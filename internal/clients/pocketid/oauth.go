@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// jwtBearerAssertion is the type of the client assertions presented instead
+// of a client secret, as defined by RFC 7523.
+const jwtBearerAssertion = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// OAuthConfig configures how access tokens are obtained through the OAuth 2.0
+// client credentials grant.
+type OAuthConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+
+	// FederatedTokenFile is the path of a JWT presented as client assertion
+	// instead of ClientSecret. It is read whenever a token is requested, so
+	// that it may be rotated.
+	FederatedTokenFile string
+}
+
+// newTokenSource returns a token source requesting tokens through the
+// supplied HTTP client, and reusing them until shortly before they expire.
+func newTokenSource(config *OAuthConfig, hc *http.Client) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, &clientCredentials{config: config, httpClient: hc})
+}
+
+// clientCredentials requests new tokens from the authorization server.
+type clientCredentials struct {
+	config     *OAuthConfig
+	httpClient *http.Client
+}
+
+func (s *clientCredentials) Token() (*oauth2.Token, error) {
+	cc := &clientcredentials.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		TokenURL:     s.config.TokenURL,
+		Scopes:       s.config.Scopes,
+	}
+	if s.config.FederatedTokenFile != "" {
+		jwt, err := os.ReadFile(s.config.FederatedTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read federated token: %w", err)
+		}
+		cc.AuthStyle = oauth2.AuthStyleInParams
+		cc.EndpointParams = url.Values{
+			"client_assertion_type": {jwtBearerAssertion},
+			"client_assertion":      {strings.TrimSpace(string(jwt))},
+		}
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, s.httpClient)
+	return cc.Token(ctx)
+}
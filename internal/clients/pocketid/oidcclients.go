@@ -17,13 +17,17 @@ limitations under the License.
 package pocketid
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"io"
+	"net/url"
 	"strings"
 )
 
+// MaxLogoSize is the size of the largest logo accepted by Pocket ID.
+const MaxLogoSize = 2 << 20
+
 // OIDCClient represents an OIDC client in Pocket ID API
 type OIDCClient struct {
 	ID              string            `json:"id,omitempty"`
@@ -42,6 +46,7 @@ type OIDCClient struct {
 	RefreshTokenTTL int               `json:"refreshTokenTTL,omitempty"`
 	IDTokenTTL      int               `json:"idTokenTTL,omitempty"`
 	GroupNames      []string          `json:"groupNames,omitempty"`
+	GroupIDs        []string          `json:"groupIds,omitempty"`
 }
 
 // CreateOIDCClientRequest represents the request payload for creating an OIDC client
@@ -78,32 +83,12 @@ type UpdateOIDCClientRequest struct {
 
 // GetOIDCClient retrieves an OIDC client by ID
 func (c *Client) GetOIDCClient(ctx context.Context, clientID string) (*OIDCClient, error) {
-	resp, err := c.makeRequest(ctx, "GET", fmt.Sprintf("/api/oidc/clients/%s", clientID), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get OIDC client: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil // Client doesn't exist
-	}
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var client OIDCClient
-	if err := json.Unmarshal(body, &client); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal OIDC client response: %w", err)
-	}
-
-	return &client, nil
+	return get[OIDCClient](ctx, c, pathf("/api/oidc/clients/%s", clientID), "OIDC client")
 }
 
 // GetOIDCClientByExternalName retrieves an OIDC client by client name (external name)
 func (c *Client) GetOIDCClientByExternalName(ctx context.Context, clientName string) (*OIDCClient, error) {
-	clients, err := c.ListOIDCClients(ctx)
+	clients, err := c.ListOIDCClients(ctx, ListOptions{Search: clientName})
 	if err != nil {
 		return nil, err
 	}
@@ -117,109 +102,109 @@ func (c *Client) GetOIDCClientByExternalName(ctx context.Context, clientName str
 	return nil, nil // Client not found
 }
 
-// ListOIDCClients retrieves all OIDC clients
-func (c *Client) ListOIDCClients(ctx context.Context) ([]OIDCClient, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/api/oidc/clients", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list OIDC clients: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var clients []OIDCClient
-	if err := json.Unmarshal(body, &clients); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal OIDC clients response: %w", err)
-	}
-
-	return clients, nil
+// ListOIDCClients retrieves the OIDC clients matching the supplied options
+func (c *Client) ListOIDCClients(ctx context.Context, opts ListOptions) ([]OIDCClient, error) {
+	return list[OIDCClient](ctx, c, "/api/oidc/clients", "OIDC clients", opts)
 }
 
 // CreateOIDCClient creates a new OIDC client
 func (c *Client) CreateOIDCClient(ctx context.Context, req CreateOIDCClientRequest) (*OIDCClient, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/api/oidc/clients", req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create OIDC client: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var client OIDCClient
-	if err := json.Unmarshal(body, &client); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal OIDC client response: %w", err)
-	}
-
-	return &client, nil
+	return create(ctx, c, "/api/oidc/clients", "OIDC client", req, func(ctx context.Context) (*OIDCClient, error) {
+		return c.GetOIDCClientByExternalName(ctx, req.ClientName)
+	})
 }
 
 // UpdateOIDCClient updates an existing OIDC client
 func (c *Client) UpdateOIDCClient(ctx context.Context, clientID string, req UpdateOIDCClientRequest) (*OIDCClient, error) {
-	resp, err := c.makeRequest(ctx, "PUT", fmt.Sprintf("/api/oidc/clients/%s", clientID), req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update OIDC client: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var client OIDCClient
-	if err := json.Unmarshal(body, &client); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal OIDC client response: %w", err)
-	}
-
-	return &client, nil
+	return put[OIDCClient](ctx, c, pathf("/api/oidc/clients/%s", clientID), "OIDC client", req)
 }
 
 // DeleteOIDCClient deletes an OIDC client by ID
 func (c *Client) DeleteOIDCClient(ctx context.Context, clientID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/api/oidc/clients/%s", clientID), nil)
-	if err != nil {
-		return fmt.Errorf("failed to delete OIDC client: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
+	return c.remove(ctx, pathf("/api/oidc/clients/%s", clientID), "delete OIDC client")
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil // Already deleted
+// RegenerateOIDCClientSecret replaces the secret of an OIDC client with a new
+// one, which is returned. The previous secret stops working at once. A request
+// whose outcome is unknown is not sent again, as it may have replaced the
+// secret already: the secret must then be regenerated once more.
+func (c *Client) RegenerateOIDCClientSecret(ctx context.Context, clientID string) (string, error) {
+	ctx = context.WithValue(ctx, idempotencyKeyKey{}, newIdempotencyKey())
+	s, err := createOnce[struct {
+		Secret string `json:"secret"`
+	}](ctx, c, fmt.Sprintf("/api/oidc/clients/%s/secret", clientID), "OIDC client secret", nil)
+	if err != nil {
+		return "", err
 	}
-
-	_, err = checkResponse(resp)
-	return err
+	return s.Secret, nil
 }
 
-// UploadOIDCClientLogo uploads a logo for an OIDC client from a URL
+// UploadOIDCClientLogo uploads a logo for an OIDC client from an http(s) URL,
+// or a data: URI embedding it. Downloaded logos fail as soon as they exceed
+// MaxLogoSize. The logo is not uploaded if the client has the same one
+// already.
 func (c *Client) UploadOIDCClientLogo(ctx context.Context, clientID, logoURL string) error {
 	if logoURL == "" {
 		return nil
 	}
 
+	if strings.HasPrefix(logoURL, "data:") {
+		logo, contentType, err := ParseDataURI(logoURL)
+		if err != nil {
+			return fmt.Errorf("invalid logo data URI: %w", err)
+		}
+		return c.UploadOIDCClientLogoContent(ctx, clientID, bytes.NewReader(logo), contentType)
+	}
+
+	u, err := url.Parse(logoURL)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported logo URL %q: only http(s) URLs and data: URIs are supported", logoURL)
+	}
+
 	// Download the logo from the URL
-	logoData, filename, err := c.downloadFile(ctx, logoURL)
+	logo, contentType, err := c.downloadFile(ctx, logoURL, MaxLogoSize, logoAccept)
 	if err != nil {
 		return fmt.Errorf("failed to download logo: %w", err)
 	}
+	defer func() { _ = logo.Close() }()
 
-	// Validate file size (2MB limit)
-	if len(logoData) > 2*1024*1024 {
-		return fmt.Errorf("logo file size exceeds 2MB limit")
+	return c.uploadLogo(ctx, clientID, logo, contentType, logoFilename(logoURL))
+}
+
+// UploadOIDCClientLogoContent uploads a logo for an OIDC client read from the
+// supplied reader, served with the supplied Content-Type if any. A
+// TooLargeError is returned as soon as it exceeds MaxLogoSize. Its format is
+// detected from its content or Content-Type. The logo is not uploaded if the
+// client has the same one already.
+func (c *Client) UploadOIDCClientLogoContent(ctx context.Context, clientID string, logo io.Reader, contentType string) error {
+	return c.uploadLogo(ctx, clientID, newLimitedBody(io.NopCloser(logo), MaxLogoSize), contentType, "logo")
+}
+
+// uploadLogo uploads a logo named after the supplied name with the extension
+// of its detected format, unless the OIDC client has the same logo already.
+func (c *Client) uploadLogo(ctx context.Context, clientID string, logo io.Reader, contentType, name string) error {
+	// Logos are buffered to be uploaded anyway, see uploadFile.
+	content, err := io.ReadAll(logo)
+	if err != nil {
+		return fmt.Errorf("failed to read logo: %w", err)
+	}
+	ext, ok := imageExtension(contentType, content[:min(len(content), sniffLen)])
+	if !ok {
+		return fmt.Errorf("invalid image format. Supported formats: PNG, JPEG, GIF, SVG")
 	}
 
-	// Validate file type based on URL extension
-	if !isValidImageExtension(logoURL) {
-		return fmt.Errorf("invalid image format. Supported formats: PNG, JPEG, JPG, GIF, SVG")
+	// Logos are served as uploaded, so the logo is not uploaded again if it
+	// did not change.
+	current, err := c.GetOIDCClientLogo(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.Digest() == ImageDigest(content) {
+		return nil
 	}
 
 	// Upload the logo
-	resp, err := c.uploadFile(ctx, fmt.Sprintf("/api/oidc/clients/%s/logo", clientID), logoData, filename)
+	resp, err := c.uploadFile(ctx, fmt.Sprintf("/api/oidc/clients/%s/logo", clientID), bytes.NewReader(content), name+ext)
 	if err != nil {
 		return fmt.Errorf("failed to upload logo: %w", err)
 	}
@@ -228,17 +213,3 @@ func (c *Client) UploadOIDCClientLogo(ctx context.Context, clientID, logoURL str
 	_, err = checkResponse(resp)
 	return err
 }
-
-// isValidImageExtension checks if the URL has a valid image extension
-func isValidImageExtension(url string) bool {
-	url = strings.ToLower(url)
-	validExtensions := []string{".png", ".jpeg", ".jpg", ".gif", ".svg"}
-
-	for _, ext := range validExtensions {
-		if strings.HasSuffix(url, ext) {
-			return true
-		}
-	}
-
-	return false
-}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import "maps"
+
+// OwnerClaim is the custom claim marking the users, groups and OIDC clients
+// created by the provider. It holds the UID of the managed resource that
+// created them, telling them apart from the objects created by people.
+const OwnerClaim = "crossplane_owner"
+
+// Owner returns the UID of the managed resource that created an object with
+// the supplied custom claims, or an empty string if it was not created by the
+// provider.
+func Owner(claims map[string]string) string {
+	return claims[OwnerClaim]
+}
+
+// WithOwner returns a copy of the supplied custom claims marking their object
+// as created by the managed resource with the supplied UID, or unmarked if the
+// UID is empty. Any owner claim they hold is overridden.
+func WithOwner(claims map[string]string, uid string) map[string]string {
+	if uid == "" {
+		if _, ok := claims[OwnerClaim]; !ok {
+			return claims
+		}
+		c := maps.Clone(claims)
+		delete(c, OwnerClaim)
+		return c
+	}
+	c := maps.Clone(claims)
+	if c == nil {
+		c = map[string]string{}
+	}
+	c[OwnerClaim] = uid
+	return c
+}
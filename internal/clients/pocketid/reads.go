@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// maxReads is the number of responses a readCache keeps, both cached and to
+// revalidate. The oldest are evicted first.
+const maxReads = 1024
+
+// A snapshot is a response read in full, so that it can be returned to
+// several callers.
+type snapshot struct {
+	status int
+	header http.Header
+	body   []byte
+	at     time.Time
+}
+
+// response returns a new response with the content of the snapshot.
+func (s *snapshot) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", s.status, http.StatusText(s.status)),
+		StatusCode:    s.status,
+		Header:        s.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(s.body)),
+		ContentLength: int64(len(s.body)),
+		Request:       req,
+	}
+}
+
+// A readCache sends identical GET requests in flight once, and reuses their
+// successful or not found responses for its TTL until invalidated. Responses
+// carrying an ETag or Last-Modified header are then revalidated with
+// conditional requests, the server answering 304 Not Modified instead of
+// sending them again while they are unchanged. Requests are only shared by
+// callers sending them with the same source and User-Agent, so that Pocket ID
+// attributes them to the right managed resource.
+type readCache struct {
+	group singleflight.Group
+	ttl   time.Duration
+
+	mu  sync.Mutex
+	m   map[string]*snapshot
+	gen uint64
+
+	// validated holds the last responses carrying an ETag or Last-Modified
+	// header, which are revalidated with conditional requests rather than
+	// downloaded again while unchanged.
+	validated map[string]*snapshot
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{ttl: ttl, m: map[string]*snapshot{}, validated: map[string]*snapshot{}}
+}
+
+// get returns the response to the supplied GET request, sent with the
+// supplied function unless it is cached or already in flight.
+func (c *readCache) get(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	key := req.URL.String() + "\n" + req.Header.Get("X-Request-Source") + "\n" + req.Header.Get("User-Agent")
+	if s := c.cached(key); s != nil {
+		return s.response(req), nil
+	}
+
+	// The request is shared by every caller, and so not cancelled when the
+	// one that sent it gives up, though it honours its deadline.
+	ch := c.group.DoChan(key, func() (any, error) {
+		c.mu.Lock()
+		gen := c.gen
+		prev := c.validated[key]
+		c.mu.Unlock()
+
+		ctx := context.WithoutCancel(req.Context())
+		if dl, ok := req.Context().Deadline(); ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, dl)
+			defer cancel()
+		}
+
+		r := req.Clone(ctx)
+		if prev != nil {
+			if etag := prev.header.Get("ETag"); etag != "" {
+				r.Header.Set("If-None-Match", etag)
+			}
+			if lm := prev.header.Get("Last-Modified"); lm != "" {
+				r.Header.Set("If-Modified-Since", lm)
+			}
+		}
+
+		resp, err := send(r)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		s := &snapshot{status: resp.StatusCode, header: resp.Header, body: body, at: time.Now()}
+		if s.status == http.StatusNotModified && prev != nil {
+			s = &snapshot{status: prev.status, header: prev.header, body: prev.body, at: s.at}
+		}
+		c.store(key, s, gen)
+		return s, nil
+	})
+
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case r := <-ch:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*snapshot).response(req), nil
+	}
+}
+
+func (c *readCache) cached(key string) *snapshot {
+	if c.ttl <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.m[key]
+	if !ok || time.Since(s.at) > c.ttl {
+		delete(c.m, key)
+		return nil
+	}
+	return s
+}
+
+// store caches a successful or not found response, unless the cache was
+// invalidated since the request was sent, and keeps the successful responses
+// that can be revalidated.
+func (c *readCache) store(key string, s *snapshot, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case s.status < 200 || s.status > 299:
+		delete(c.validated, key)
+	case s.header.Get("ETag") != "" || s.header.Get("Last-Modified") != "":
+		keep(c.validated, key, s)
+	}
+
+	if c.ttl <= 0 || s.status < 200 || s.status > 299 && s.status != http.StatusNotFound {
+		return
+	}
+	if c.gen != gen {
+		return
+	}
+	keep(c.m, key, s)
+}
+
+// keep stores a snapshot in the supplied map, evicting its oldest snapshot
+// first if it holds maxReads already.
+func keep(m map[string]*snapshot, key string, s *snapshot) {
+	if _, ok := m[key]; !ok && len(m) >= maxReads {
+		oldest := ""
+		for k, v := range m {
+			if oldest == "" || v.at.Before(m[oldest].at) {
+				oldest = k
+			}
+		}
+		delete(m, oldest)
+	}
+	m[key] = s
+}
+
+// invalidate drops every cached response, and those of requests in flight.
+func (c *readCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	clear(c.m)
+}
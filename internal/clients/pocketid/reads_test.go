@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCoalesceReads(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id":"1","groupName":"admins"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key"})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g, err := c.GetGroup(context.Background(), "1")
+			if err != nil || g == nil || g.GroupName != "admins" {
+				t.Errorf("c.GetGroup(...): want the group, got %v, %v", g, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if diff := cmp.Diff(int32(1), requests.Load()); diff != "" {
+		t.Errorf("c.GetGroup(...): -want identical reads in flight sent once, +got:\n%s\n", diff)
+	}
+}
+
+func TestReadCacheTTL(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		_, _ = w.Write([]byte(`{"id":"1","groupName":"admins"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key", ReadCacheTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := c.GetGroup(ctx, "1"); err != nil {
+			t.Fatalf("c.GetGroup(...): unexpected error: %v", err)
+		}
+	}
+	if _, err := c.UpdateGroup(ctx, "1", UpdateGroupRequest{GroupName: "admins"}); err != nil {
+		t.Fatalf("c.UpdateGroup(...): unexpected error: %v", err)
+	}
+	if _, err := c.GetGroup(ctx, "1"); err != nil {
+		t.Fatalf("c.GetGroup(...): unexpected error: %v", err)
+	}
+
+	want := []string{http.MethodGet, http.MethodPut, http.MethodGet}
+	if diff := cmp.Diff(want, requests); diff != "" {
+		t.Errorf("c.GetGroup(...): -want reads reused until a write, +got:\n%s\n", diff)
+	}
+}
+
+func TestConditionalReads(t *testing.T) {
+	var conditional []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"id":"1","groupName":"admins"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key"})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+
+	for range 2 {
+		g, err := c.GetGroup(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("c.GetGroup(...): unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(&Group{ID: "1", GroupName: "admins"}, g); diff != "" {
+			t.Errorf("c.GetGroup(...): -want, +got:\n%s\n", diff)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"", `"v1"`}, conditional); diff != "" {
+		t.Errorf("c.GetGroup(...): -want the response revalidated, +got If-None-Match:\n%s\n", diff)
+	}
+}
+
+func TestReadsBySource(t *testing.T) {
+	var sources []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sources = append(sources, r.Header.Get("X-Request-Source"))
+		_, _ = w.Write([]byte(`{"id":"1","groupName":"admins"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClientFromConfig(Config{Endpoint: srv.URL, APIKey: "key", ReadCacheTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig(...): unexpected error: %v", err)
+	}
+
+	for _, src := range []string{"Group/admins", "UserGroupBinding/alice-admins", "Group/admins"} {
+		if _, err := c.GetGroup(WithRequestSource(context.Background(), src), "1"); err != nil {
+			t.Fatalf("c.GetGroup(...): unexpected error: %v", err)
+		}
+	}
+
+	want := []string{"Group/admins", "UserGroupBinding/alice-admins"}
+	if diff := cmp.Diff(want, sources); diff != "" {
+		t.Errorf("c.GetGroup(...): -want reads only shared by the same source, +got:\n%s\n", diff)
+	}
+}
+
+func TestKeep(t *testing.T) {
+	start := time.Now()
+	m := map[string]*snapshot{}
+	for i := range maxReads + 1 {
+		keep(m, strconv.Itoa(i), &snapshot{at: start.Add(time.Duration(i) * time.Second)})
+	}
+
+	if diff := cmp.Diff(maxReads, len(m)); diff != "" {
+		t.Errorf("keep(...): -want responses, +got:\n%s\n", diff)
+	}
+	if _, ok := m["0"]; ok {
+		t.Error("keep(...): want the oldest response evicted")
+	}
+}
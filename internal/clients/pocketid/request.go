@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// pathf returns the path formatted from the supplied template, such as
+// /api/users/%s/groups/%s, with the supplied path segments escaped.
+func pathf(format string, segments ...string) string {
+	args := make([]any, len(segments))
+	for i, s := range segments {
+		args[i] = url.PathEscape(s)
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// withQuery returns the supplied path with the supplied query, if any.
+func withQuery(p string, q url.Values) string {
+	if len(q) == 0 {
+		return p
+	}
+	return p + "?" + q.Encode()
+}
+
+// call sends a request with the supplied method, path and JSON body, if any,
+// and decodes its JSON response. The action, such as "update user", and the
+// kind of object returned describe the request in errors. Error responses are
+// returned as an *APIError.
+func call[T any](ctx context.Context, c *Client, method, path, action, kind string, body any) (*T, error) {
+	resp, err := c.makeRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, err := checkResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	return decode[T](b, kind)
+}
+
+// decode decodes the JSON response body of a request returning an object of
+// the supplied kind. An empty body decodes to the zero value.
+func decode[T any](body []byte, kind string) (*T, error) {
+	var obj T
+	if len(body) == 0 {
+		return &obj, nil
+	}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s response: %w", kind, err)
+	}
+	return &obj, nil
+}
+
+// get retrieves the object of the supplied kind at the supplied path, or nil
+// if it does not exist.
+func get[T any](ctx context.Context, c *Client, path, kind string) (*T, error) {
+	obj, err := call[T](ctx, c, http.MethodGet, path, "get "+kind, kind, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return obj, err
+}
+
+// put updates the object of the supplied kind at the supplied path, returning
+// it as updated.
+func put[T any](ctx context.Context, c *Client, path, kind string, body any) (*T, error) {
+	return call[T](ctx, c, http.MethodPut, path, "update "+kind, kind, body)
+}
+
+// send sends a request whose response body is of no interest.
+func (c *Client) send(ctx context.Context, method, path, action string, body any) error {
+	resp, err := c.makeRequest(ctx, method, path, body)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, err = checkResponse(resp)
+	return err
+}
+
+// remove sends a DELETE request, ignoring that what it deletes does not exist.
+func (c *Client) remove(ctx context.Context, path, action string) error {
+	err := c.send(ctx, http.MethodDelete, path, action, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil // Already deleted
+	}
+	return err
+}
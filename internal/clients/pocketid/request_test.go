@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPathf(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		format   string
+		segments []string
+		want     string
+	}{
+		"Plain": {
+			reason:   "Segments without reserved characters should be kept as is.",
+			format:   "/api/users/%s/groups/%s",
+			segments: []string{"1", "2"},
+			want:     "/api/users/1/groups/2",
+		},
+		"Escaped": {
+			reason:   "Segments should not be able to add path segments or a query.",
+			format:   "/api/users/%s",
+			segments: []string{"../groups?x=1"},
+			want:     "/api/users/..%2Fgroups%3Fx=1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, pathf(tc.format, tc.segments...)); diff != "" {
+				t.Errorf("\n%s\npathf(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestURLKeepsEscaping(t *testing.T) {
+	c := NewClient(Config{Endpoint: "https://id.example.com/pocket-id"})
+	got, err := c.url(pathf("/api/users/%s", "a/b"))
+	if err != nil {
+		t.Fatalf("c.url(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("https://id.example.com/pocket-id/api/users/a%2Fb", got); diff != "" {
+		t.Errorf("c.url(...): -want, +got:\n%s\n", diff)
+	}
+}
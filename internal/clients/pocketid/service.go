@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Service is the Pocket ID API, as implemented by Client. Controllers depend
+// on it rather than on Client so that it can be faked in tests.
+type Service interface {
+	// Users
+	GetUser(ctx context.Context, userID string) (*User, error)
+	GetCurrentUser(ctx context.Context) (*User, error)
+	GetUserProfilePicture(ctx context.Context, userID string) (*Image, error)
+	GetCurrentUserProfilePicture(ctx context.Context) (*Image, error)
+	DeleteUserProfilePicture(ctx context.Context, userID string) error
+	GetUserByExternalName(ctx context.Context, username string) (*User, error)
+	ListUsers(ctx context.Context, opts ListOptions) ([]User, error)
+	CreateUser(ctx context.Context, req CreateUserRequest) (*User, error)
+	UpdateUser(ctx context.Context, userID string, req UpdateUserRequest) (*User, error)
+	DeleteUser(ctx context.Context, userID string) error
+
+	// Groups
+	GetGroup(ctx context.Context, groupID string) (*Group, error)
+	GetGroupByExternalName(ctx context.Context, groupName string) (*Group, error)
+	ListGroups(ctx context.Context, opts ListOptions) ([]Group, error)
+	CreateGroup(ctx context.Context, req CreateGroupRequest) (*Group, error)
+	UpdateGroup(ctx context.Context, groupID string, req UpdateGroupRequest) (*Group, error)
+	DeleteGroup(ctx context.Context, groupID string) error
+
+	// OIDC clients
+	GetOIDCClient(ctx context.Context, clientID string) (*OIDCClient, error)
+	GetOIDCClientByExternalName(ctx context.Context, clientName string) (*OIDCClient, error)
+	ListOIDCClients(ctx context.Context, opts ListOptions) ([]OIDCClient, error)
+	CreateOIDCClient(ctx context.Context, req CreateOIDCClientRequest) (*OIDCClient, error)
+	UpdateOIDCClient(ctx context.Context, clientID string, req UpdateOIDCClientRequest) (*OIDCClient, error)
+	DeleteOIDCClient(ctx context.Context, clientID string) error
+	RegenerateOIDCClientSecret(ctx context.Context, clientID string) (string, error)
+	GetOIDCClientLogo(ctx context.Context, clientID string) (*Image, error)
+	UploadOIDCClientLogo(ctx context.Context, clientID, logoURL string) error
+	UploadOIDCClientLogoContent(ctx context.Context, clientID string, logo io.Reader, contentType string) error
+	DeleteOIDCClientLogo(ctx context.Context, clientID string) error
+
+	// Group memberships
+	AddUserToGroup(ctx context.Context, userID, groupID string) error
+	RemoveUserFromGroup(ctx context.Context, userID, groupID string) error
+	IsUserInGroup(ctx context.Context, userID, groupID string) (bool, error)
+	SetUserGroups(ctx context.Context, userID string, groupIDs []string) error
+	AddClientToGroup(ctx context.Context, clientID, groupID string) error
+	RemoveClientFromGroup(ctx context.Context, clientID, groupID string) error
+	IsClientInGroup(ctx context.Context, clientID, groupID string) (bool, error)
+	SetClientGroups(ctx context.Context, clientID string, groupIDs []string) error
+
+	// API keys
+	ListAPIKeys(ctx context.Context, opts ListOptions) ([]APIKey, error)
+	CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreatedAPIKey, error)
+	DeleteAPIKey(ctx context.Context, keyID string) error
+
+	// Tokens
+	CreateOneTimeAccessToken(ctx context.Context, userID string, expiresAt time.Time) (*OneTimeAccessToken, error)
+	ListSignupTokens(ctx context.Context, opts ListOptions) ([]SignupToken, error)
+	CreateSignupToken(ctx context.Context, req CreateSignupTokenRequest) (*SignupToken, error)
+	DeleteSignupToken(ctx context.Context, tokenID string) error
+
+	// Audit log
+	ListAuditLogs(ctx context.Context, filter AuditLogFilter) ([]AuditLog, error)
+
+	// Application configuration
+	GetAppConfig(ctx context.Context) (*AppConfig, error)
+	GetPublicAppConfig(ctx context.Context) (*AppConfig, error)
+	UpdateAppConfig(ctx context.Context, config AppConfig) (*AppConfig, error)
+
+	// Server
+	GetVersion(ctx context.Context) (string, error)
+	Healthz(ctx context.Context) error
+	GetOpenIDConfiguration(ctx context.Context) (*OpenIDConfiguration, error)
+}
+
+var _ Service = &Client{}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import "context"
+
+// requestSourceKey is the context key of the source of requests.
+type requestSourceKey struct{}
+
+// WithRequestSource returns a context whose requests to the API are sent with
+// an X-Request-Source header identifying their source, such as the managed
+// resource being reconciled, so that the audit log of Pocket ID can attribute
+// changes to it.
+func WithRequestSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, requestSourceKey{}, source)
+}
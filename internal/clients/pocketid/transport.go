@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+const (
+	// maxIdleConnsPerHost is how many idle connections are kept open to each
+	// endpoint, enough for the concurrent reconciles of a busy provider to
+	// reuse connections rather than open new ones.
+	maxIdleConnsPerHost = 32
+
+	// idleConnTimeout is how long idle connections are kept open.
+	idleConnTimeout = 90 * time.Second
+
+	// http2ReadIdleTimeout is how long an HTTP/2 connection may receive no
+	// frame before it is health checked with a ping, failing which within
+	// http2PingTimeout it is closed rather than reused.
+	http2ReadIdleTimeout = 30 * time.Second
+	http2PingTimeout     = 15 * time.Second
+
+	// transportTTL is how long a shared transport is kept after it was last
+	// used by a new client.
+	transportTTL = time.Hour
+)
+
+// A sharedTransport is a transport shared by clients along with when it was
+// last shared.
+type sharedTransport struct {
+	transport *http.Transport
+	used      time.Time
+}
+
+// transports holds the transports built so far, keyed by a hash of their
+// settings, so that clients of the same endpoint with different credentials
+// share their connections.
+var transports = struct {
+	sync.Mutex
+	m map[string]*sharedTransport
+}{m: map[string]*sharedTransport{}}
+
+// transportSettings are the settings of a Config that a transport depends on.
+type transportSettings struct {
+	Socket             string
+	CABundle           []byte
+	InsecureSkipVerify bool
+	MinTLSVersion      uint16
+	CipherSuites       []uint16
+	ClientCertificate  []byte
+	ClientKey          []byte
+	ProxyURL           string
+	NoProxy            []string
+}
+
+// transportFor returns the transport of clients with the supplied
+// configuration, reaching the API through the supplied Unix domain socket if
+// any, shared with the clients that have the same settings.
+func transportFor(config Config, socket string) (*http.Transport, error) {
+	data, err := json.Marshal(transportSettings{
+		Socket:             socket,
+		CABundle:           config.CABundle,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		MinTLSVersion:      config.MinTLSVersion,
+		CipherSuites:       config.CipherSuites,
+		ClientCertificate:  config.ClientCertificate,
+		ClientKey:          config.ClientKey,
+		ProxyURL:           config.ProxyURL,
+		NoProxy:            config.NoProxy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot hash transport settings: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	key := hex.EncodeToString(sum[:])
+
+	transports.Lock()
+	defer transports.Unlock()
+
+	now := time.Now()
+	for k, t := range transports.m {
+		if now.Sub(t.used) > transportTTL {
+			t.transport.CloseIdleConnections()
+			delete(transports.m, k)
+		}
+	}
+
+	if t, ok := transports.m[key]; ok {
+		t.used = now
+		return t.transport, nil
+	}
+
+	t, err := newTransport(config)
+	if err != nil {
+		return nil, err
+	}
+	if socket != "" {
+		dialUnixSocket(t, socket)
+	}
+	transports.m[key] = &sharedTransport{transport: t, used: now}
+	return t, nil
+}
+
+// tuneTransport configures a transport to reuse its connections, which it
+// keeps healthy, as much as possible.
+func tuneTransport(t *http.Transport) error {
+	t.ForceAttemptHTTP2 = true
+	t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	t.IdleConnTimeout = idleConnTimeout
+
+	h2, err := http2.ConfigureTransports(t)
+	if err != nil {
+		return fmt.Errorf("cannot configure HTTP/2: %w", err)
+	}
+	h2.ReadIdleTimeout = http2ReadIdleTimeout
+	h2.PingTimeout = http2PingTimeout
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTransportFor(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		a      Config
+		b      Config
+		socket string
+		want   bool
+	}{
+		"SameSettings": {
+			reason: "Clients with the same settings but different credentials should share a transport.",
+			a:      Config{Endpoint: "https://a.example.org", APIKey: "one"},
+			b:      Config{Endpoint: "https://a.example.org", APIKey: "two"},
+			want:   true,
+		},
+		"DifferentCA": {
+			reason: "Clients trusting different CAs should not share a transport.",
+			a:      Config{Endpoint: "https://a.example.org", InsecureSkipVerify: true},
+			b:      Config{Endpoint: "https://a.example.org"},
+			want:   false,
+		},
+		"DifferentProxy": {
+			reason: "Clients using different proxies should not share a transport.",
+			a:      Config{Endpoint: "https://a.example.org", ProxyURL: "http://proxy.example.org"},
+			b:      Config{Endpoint: "https://a.example.org"},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, err := transportFor(tc.a, tc.socket)
+			if err != nil {
+				t.Fatalf("transportFor(...): unexpected error: %v", err)
+			}
+			b, err := transportFor(tc.b, tc.socket)
+			if err != nil {
+				t.Fatalf("transportFor(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, a == b); diff != "" {
+				t.Errorf("\n%s\ntransportFor(...): -want shared, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTuneTransport(t *testing.T) {
+	tr, err := newTransport(Config{})
+	if err != nil {
+		t.Fatalf("newTransport(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(maxIdleConnsPerHost, tr.MaxIdleConnsPerHost); diff != "" {
+		t.Errorf("newTransport(...): -want MaxIdleConnsPerHost, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(idleConnTimeout, tr.IdleConnTimeout); diff != "" {
+		t.Errorf("newTransport(...): -want IdleConnTimeout, +got:\n%s\n", diff)
+	}
+	if _, ok := tr.TLSNextProto["h2"]; !ok {
+		t.Errorf("newTransport(...): want HTTP/2 configured")
+	}
+}
@@ -18,9 +18,7 @@ package pocketid
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 // User represents a user in Pocket ID API
@@ -35,6 +33,7 @@ type User struct {
 	IsAdmin      bool              `json:"isAdmin,omitempty"`
 	UserGroups   []string          `json:"userGroups,omitempty"`
 	CustomClaims map[string]string `json:"customClaims,omitempty"`
+	LdapID       string            `json:"ldapId,omitempty"`
 }
 
 // CreateUserRequest represents the request payload for creating a user
@@ -62,32 +61,21 @@ type UpdateUserRequest struct {
 
 // GetUser retrieves a user by ID
 func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
-	resp, err := c.makeRequest(ctx, "GET", fmt.Sprintf("/api/users/%s", userID), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil // User doesn't exist
-	}
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
+	return get[User](ctx, c, pathf("/api/users/%s", userID), "user")
+}
 
-	var user User
-	if err := json.Unmarshal(body, &user); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal user response: %w", err)
+// GetCurrentUser retrieves the user owning the API key
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	user, err := get[User](ctx, c, "/api/users/me", "current user")
+	if err == nil && user == nil {
+		return nil, fmt.Errorf("failed to get current user: %w", ErrNotFound)
 	}
-
-	return &user, nil
+	return user, err
 }
 
 // GetUserByExternalName retrieves a user by username (external name)
 func (c *Client) GetUserByExternalName(ctx context.Context, username string) (*User, error) {
-	users, err := c.ListUsers(ctx)
+	users, err := c.ListUsers(ctx, ListOptions{Search: username})
 	if err != nil {
 		return nil, err
 	}
@@ -101,81 +89,24 @@ func (c *Client) GetUserByExternalName(ctx context.Context, username string) (*U
 	return nil, nil // User not found
 }
 
-// ListUsers retrieves all users
-func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/api/users", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list users: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var users []User
-	if err := json.Unmarshal(body, &users); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal users response: %w", err)
-	}
-
-	return users, nil
+// ListUsers retrieves the users matching the supplied options
+func (c *Client) ListUsers(ctx context.Context, opts ListOptions) ([]User, error) {
+	return list[User](ctx, c, "/api/users", "users", opts)
 }
 
 // CreateUser creates a new user
 func (c *Client) CreateUser(ctx context.Context, req CreateUserRequest) (*User, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/api/users", req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var user User
-	if err := json.Unmarshal(body, &user); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal user response: %w", err)
-	}
-
-	return &user, nil
+	return create(ctx, c, "/api/users", "user", req, func(ctx context.Context) (*User, error) {
+		return c.GetUserByExternalName(ctx, req.Username)
+	})
 }
 
 // UpdateUser updates an existing user
 func (c *Client) UpdateUser(ctx context.Context, userID string, req UpdateUserRequest) (*User, error) {
-	resp, err := c.makeRequest(ctx, "PUT", fmt.Sprintf("/api/users/%s", userID), req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update user: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := checkResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	var user User
-	if err := json.Unmarshal(body, &user); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal user response: %w", err)
-	}
-
-	return &user, nil
+	return put[User](ctx, c, pathf("/api/users/%s", userID), "user", req)
 }
 
 // DeleteUser deletes a user by ID
 func (c *Client) DeleteUser(ctx context.Context, userID string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/api/users/%s", userID), nil)
-	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil // Already deleted
-	}
-
-	_, err = checkResponse(resp)
-	return err
+	return c.remove(ctx, pathf("/api/users/%s", userID), "delete user")
 }
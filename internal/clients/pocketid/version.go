@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// SupportedMajorVersion is the latest major version of Pocket ID the provider
+// is built for. Later major versions may break the API in ways the provider
+// cannot detect, while the fields missing from earlier versions are gated
+// individually.
+const SupportedMajorVersion = 1
+
+// CompatibleVersion reports whether the provider is built for the supplied
+// version of the Pocket ID server. Versions that are unknown or cannot be
+// parsed are assumed compatible.
+func CompatibleVersion(v string) bool {
+	sv, err := version.ParseGeneric(v)
+	if err != nil {
+		return true
+	}
+	return sv.Major() <= SupportedMajorVersion
+}
+
+// GetVersion retrieves the version of the Pocket ID server, or an empty string
+// if the server does not report it
+func (c *Client) GetVersion(ctx context.Context) (string, error) {
+	v, err := get[struct {
+		CurrentVersion string `json:"currentVersion"`
+	}](ctx, c, "/api/version/current", "version")
+	if err != nil || v == nil {
+		return "", err // The server may predate the version endpoint
+	}
+	return v.CurrentVersion, nil
+}
+
+// Healthz checks that the Pocket ID server is up and can reach its database,
+// without authenticating, through its health endpoint. Servers predating the
+// endpoint are assumed healthy. Its result is never cached.
+func (c *Client) Healthz(ctx context.Context) error {
+	u, err := c.url("/healthz")
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to check health: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil // Server predates the health endpoint
+	}
+
+	_, err = checkResponse(resp)
+	return err
+}
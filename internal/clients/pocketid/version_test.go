@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pocketid
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompatibleVersion(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		version string
+		want    bool
+	}{
+		"Unknown": {
+			reason: "Servers that do not report their version should be assumed compatible.",
+			want:   true,
+		},
+		"Invalid": {
+			reason:  "Versions that cannot be parsed should be assumed compatible.",
+			version: "dev",
+			want:    true,
+		},
+		"Older": {
+			reason:  "Older major versions should be compatible, their missing fields being gated individually.",
+			version: "0.51.0",
+			want:    true,
+		},
+		"Supported": {
+			reason:  "The supported major version should be compatible.",
+			version: "v1.6.4",
+			want:    true,
+		},
+		"Newer": {
+			reason:  "Later major versions should be incompatible.",
+			version: "2.0.0",
+			want:    false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CompatibleVersion(tc.version)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCompatibleVersion(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+)
+
+// AnnotationKeyPollInterval is the annotation of a managed resource overriding
+// how often it is checked for drift, as a duration such as 1h.
+const AnnotationKeyPollInterval = "pocketid.crossplane.io/poll-interval"
+
+// pollIntervalTimeout bounds the read of the ProviderConfig of a managed
+// resource computing its poll interval, served by the cache of the manager.
+const pollIntervalTimeout = 5 * time.Second
+
+// dependencyPollInterval is how often managed resources waiting for a
+// dependency are checked again. Dependencies identified by name, such as the
+// groupName of a binding, are not watched.
+const dependencyPollInterval = 10 * time.Second
+
+// pollJitter is the jitter of the poll interval of managed resources whose
+// ProviderConfig sets none.
+var pollJitter time.Duration
+
+// JitterPolls makes the poll interval of managed resources shifted randomly by
+// up to the supplied duration either way, unless their ProviderConfig sets its
+// own jitter.
+func JitterPolls(d time.Duration) {
+	pollJitter = d
+}
+
+// PollIntervalHook returns a hook computing the poll interval of managed
+// resources from their poll interval annotation, or else from the poll
+// interval of their ProviderConfig, shifted by its jitter or else that of the
+// provider. The poll interval of the provider is used if neither is set or the
+// ProviderConfig cannot be read. Jitter never shortens the poll interval by
+// more than half, so that resources keep being polled whatever it is.
+// Resources waiting for a dependency are polled again shortly instead.
+func PollIntervalHook(kube client.Reader) managed.PollIntervalHook {
+	return func(mg resource.Managed, pollInterval time.Duration) time.Duration {
+		if mg.GetCondition(conditions.TypeDependencyReady).Reason == conditions.ReasonWaitingForDependency {
+			return min(pollInterval, dependencyPollInterval)
+		}
+
+		// The hook is not passed the context of the reconcile.
+		ctx, cancel := context.WithTimeout(context.Background(), pollIntervalTimeout)
+		defer cancel()
+
+		annotated := false
+		if d, err := time.ParseDuration(mg.GetAnnotations()[AnnotationKeyPollInterval]); err == nil && d > 0 {
+			pollInterval, annotated = d, true
+		}
+
+		jitter := pollJitter
+		pc := &apisv1alpha1.ProviderConfig{}
+		if ref := mg.GetProviderConfigReference(); ref != nil && kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc) == nil {
+			if pc.Spec.PollInterval != nil && !annotated {
+				pollInterval = pc.Spec.PollInterval.Duration
+			}
+			if pc.Spec.PollJitter != nil {
+				jitter = pc.Spec.PollJitter.Duration
+			}
+		}
+
+		if jitter > 0 {
+			shift := time.Duration((rand.Float64()*2 - 1) * float64(jitter)) //nolint:gosec // No need for secure randomness
+			pollInterval = max(pollInterval+shift, pollInterval/2)
+		}
+		return pollInterval
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+)
+
+func TestPollIntervalHook(t *testing.T) {
+	withSpec := func(spec apisv1alpha1.ProviderConfigSpec) test.ObjectFn {
+		return func(obj client.Object) error {
+			obj.(*apisv1alpha1.ProviderConfig).Spec = spec
+			return nil
+		}
+	}
+
+	cases := map[string]struct {
+		reason      string
+		kube        client.Reader
+		annotations map[string]string
+		conditions  []xpv1.Condition
+		jitter      time.Duration
+		min         time.Duration
+		max         time.Duration
+	}{
+		"Default": {
+			reason: "The poll interval of the provider should be used if the ProviderConfig does not override it.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{}))},
+			min:    time.Minute,
+			max:    time.Minute,
+		},
+		"Override": {
+			reason: "The poll interval of the ProviderConfig should be used if set.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{
+				PollInterval: &metav1.Duration{Duration: 10 * time.Minute},
+			}))},
+			min: 10 * time.Minute,
+			max: 10 * time.Minute,
+		},
+		"Jitter": {
+			reason: "The poll interval should be shifted by up to the jitter of the ProviderConfig.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{
+				PollJitter: &metav1.Duration{Duration: 10 * time.Second},
+			}))},
+			min: 50 * time.Second,
+			max: 70 * time.Second,
+		},
+		"ProviderJitter": {
+			reason: "The poll interval should be shifted by up to the jitter of the provider if the ProviderConfig sets none.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{}))},
+			jitter: 10 * time.Second,
+			min:    50 * time.Second,
+			max:    70 * time.Second,
+		},
+		"JitterAboveInterval": {
+			reason: "A jitter longer than the poll interval should never shorten it by more than half.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{
+				PollJitter: &metav1.Duration{Duration: time.Hour},
+			}))},
+			min: 30 * time.Second,
+			max: time.Hour + time.Minute,
+		},
+		"JitterOverride": {
+			reason: "The jitter of the ProviderConfig should override that of the provider.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{
+				PollJitter: &metav1.Duration{},
+			}))},
+			jitter: 10 * time.Second,
+			min:    time.Minute,
+			max:    time.Minute,
+		},
+		"Annotation": {
+			reason: "The poll interval annotation of the resource should override that of the ProviderConfig.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{
+				PollInterval: &metav1.Duration{Duration: 10 * time.Minute},
+			}))},
+			annotations: map[string]string{AnnotationKeyPollInterval: "1h"},
+			min:         time.Hour,
+			max:         time.Hour,
+		},
+		"InvalidAnnotation": {
+			reason:      "An invalid poll interval annotation should be ignored.",
+			kube:        &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{}))},
+			annotations: map[string]string{AnnotationKeyPollInterval: "hourly"},
+			min:         time.Minute,
+			max:         time.Minute,
+		},
+		"AnnotationWithoutProviderConfig": {
+			reason:      "The poll interval annotation should be used even if the ProviderConfig cannot be read.",
+			kube:        &test.MockClient{MockGet: test.NewMockGetFn(context.DeadlineExceeded)},
+			annotations: map[string]string{AnnotationKeyPollInterval: "30s"},
+			min:         30 * time.Second,
+			max:         30 * time.Second,
+		},
+		"WaitingForDependency": {
+			reason:     "Resources waiting for a dependency should be polled again shortly.",
+			kube:       &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{PollJitter: &metav1.Duration{Duration: 10 * time.Second}}))},
+			conditions: []xpv1.Condition{conditions.WaitingForDependency(errors.New("group admins not found"))},
+			min:        dependencyPollInterval,
+			max:        dependencyPollInterval,
+		},
+		"DependencyReady": {
+			reason:     "Resources whose dependencies are ready should be polled at their poll interval.",
+			kube:       &test.MockClient{MockGet: test.NewMockGetFn(nil, withSpec(apisv1alpha1.ProviderConfigSpec{}))},
+			conditions: []xpv1.Condition{conditions.DependencyReady()},
+			min:        time.Minute,
+			max:        time.Minute,
+		},
+		"GetError": {
+			reason: "The poll interval of the provider should be used if the ProviderConfig cannot be read.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(context.DeadlineExceeded)},
+			min:    time.Minute,
+			max:    time.Minute,
+		},
+		"GetErrorJitter": {
+			reason: "The jitter of the provider should be used if the ProviderConfig cannot be read.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(context.DeadlineExceeded)},
+			jitter: 10 * time.Second,
+			min:    50 * time.Second,
+			max:    70 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			JitterPolls(tc.jitter)
+			defer JitterPolls(0)
+
+			mg := &apisv1alpha1.Group{}
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: "default"})
+			mg.SetAnnotations(tc.annotations)
+			mg.SetConditions(tc.conditions...)
+
+			got := PollIntervalHook(tc.kube)(mg, time.Minute)
+			if got < tc.min || got > tc.max {
+				t.Errorf("\n%s\nPollIntervalHook(...): want between %s and %s, got %s", tc.reason, tc.min, tc.max, got)
+			}
+		})
+	}
+}
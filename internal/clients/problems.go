@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+)
+
+// reportProblems sets the conditions of the supplied managed resource
+// reporting the problems its requests ran into: rate limiting, rejected
+// credentials and conflicts with existing Pocket ID objects. Problems are
+// cleared once its requests no longer run into them.
+func reportProblems(mg resource.Managed, err error) {
+	rl := &pocketid.RateLimitedError{}
+	if errors.As(err, &rl) {
+		mg.SetConditions(conditions.RateLimited(rl.RetryAfter))
+	} else {
+		conditions.Clear(mg, conditions.NotRateLimited())
+	}
+
+	switch {
+	case errors.Is(err, pocketid.ErrUnauthorized), errors.Is(err, pocketid.ErrNotAdmin):
+		mg.SetConditions(conditions.CredentialsInvalid(err))
+	case err == nil:
+		conditions.Clear(mg, conditions.CredentialsValid())
+	}
+
+	// Other conflicts, such as duplicate bindings, are reported and cleared
+	// by the controllers detecting them.
+	switch {
+	case errors.Is(err, pocketid.ErrConflict):
+		mg.SetConditions(conditions.AlreadyExists(err))
+	case err == nil && mg.GetCondition(conditions.TypeConflicting).Reason == conditions.ReasonAlreadyExists:
+		mg.SetConditions(conditions.NoConflict())
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+)
+
+func TestReportProblems(t *testing.T) {
+	type want map[xpv1.ConditionType]corev1.ConditionStatus
+
+	cases := map[string]struct {
+		reason   string
+		existing []xpv1.Condition
+		err      error
+		want     want
+	}{
+		"NoProblem": {
+			reason: "A resource that never ran into a problem should not report any.",
+			want: want{
+				conditions.TypeRateLimited:      corev1.ConditionUnknown,
+				conditions.TypeCredentialsValid: corev1.ConditionUnknown,
+				conditions.TypeConflicting:      corev1.ConditionUnknown,
+			},
+		},
+		"OtherError": {
+			reason: "Errors that are none of the problems reported should not be reported.",
+			err:    errors.New("boom"),
+			want: want{
+				conditions.TypeRateLimited:      corev1.ConditionUnknown,
+				conditions.TypeCredentialsValid: corev1.ConditionUnknown,
+				conditions.TypeConflicting:      corev1.ConditionUnknown,
+			},
+		},
+		"RateLimited": {
+			reason: "Rate limited requests should be reported.",
+			err:    errors.Wrap(&pocketid.RateLimitedError{RetryAfter: time.Minute}, "cannot get user"),
+			want:   want{conditions.TypeRateLimited: corev1.ConditionTrue},
+		},
+		"CredentialsRejected": {
+			reason: "Rejected credentials should be reported.",
+			err:    errors.Wrap(&pocketid.APIError{StatusCode: http.StatusUnauthorized}, "cannot get user"),
+			want:   want{conditions.TypeCredentialsValid: corev1.ConditionFalse},
+		},
+		"AlreadyExists": {
+			reason: "Conflicts with existing Pocket ID objects should be reported.",
+			err:    errors.Wrap(&pocketid.APIError{StatusCode: http.StatusConflict}, "cannot create user"),
+			want:   want{conditions.TypeConflicting: corev1.ConditionTrue},
+		},
+		"Resolved": {
+			reason:   "Problems should be cleared once requests succeed.",
+			existing: []xpv1.Condition{conditions.RateLimited(0), conditions.CredentialsInvalid(errors.New("boom")), conditions.AlreadyExists(errors.New("boom"))},
+			want: want{
+				conditions.TypeRateLimited:      corev1.ConditionFalse,
+				conditions.TypeCredentialsValid: corev1.ConditionTrue,
+				conditions.TypeConflicting:      corev1.ConditionFalse,
+			},
+		},
+		"DuplicateBindingKept": {
+			reason:   "Conflicts reported by controllers should be left to them.",
+			existing: []xpv1.Condition{conditions.DuplicateBinding("OIDCClientGroupBinding", "older")},
+			want:     want{conditions.TypeConflicting: corev1.ConditionTrue},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.existing...)
+			reportProblems(mg, tc.err)
+			for ct, want := range tc.want {
+				if diff := cmp.Diff(want, mg.GetCondition(ct).Status); diff != "" {
+					t.Errorf("\n%s\nreportProblems(...): -want %s status, +got:\n%s\n", tc.reason, ct, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+)
+
+// rateLimiters holds the rate limiter shared by all the clients of each
+// ProviderConfig, keyed by its UID so a recreated ProviderConfig starts with
+// a full bucket.
+var rateLimiters = struct {
+	sync.Mutex
+	m map[types.UID]*rate.Limiter
+}{m: map[types.UID]*rate.Limiter{}}
+
+// rateLimiter returns the rate limiter shared by the clients of the supplied
+// ProviderConfig, updated to its current limits, or nil if it is unlimited.
+func rateLimiter(pc *apisv1alpha1.ProviderConfig) *rate.Limiter {
+	rateLimiters.Lock()
+	defer rateLimiters.Unlock()
+
+	rl := pc.Spec.RateLimit
+	if rl == nil {
+		delete(rateLimiters.m, pc.GetUID())
+		return nil
+	}
+
+	burst := int(rl.Burst)
+	if burst == 0 {
+		burst = int(rl.RPS)
+	}
+
+	l, ok := rateLimiters.m[pc.GetUID()]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(rl.RPS), burst)
+		rateLimiters.m[pc.GetUID()] = l
+	}
+	if l.Limit() != rate.Limit(rl.RPS) {
+		l.SetLimit(rate.Limit(rl.RPS))
+	}
+	if l.Burst() != burst {
+		l.SetBurst(burst)
+	}
+	return l
+}
+
+// PruneRateLimiters drops the rate limiters of the ProviderConfigs that no
+// longer exist, keeping only those of the ProviderConfigs with the supplied
+// UIDs.
+func PruneRateLimiters(live ...types.UID) {
+	keep := make(map[types.UID]bool, len(live))
+	for _, uid := range live {
+		keep[uid] = true
+	}
+
+	rateLimiters.Lock()
+	defer rateLimiters.Unlock()
+	for uid := range rateLimiters.m {
+		if !keep[uid] {
+			delete(rateLimiters.m, uid)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+)
+
+func TestRateLimiter(t *testing.T) {
+	pc := func(rl *apisv1alpha1.RateLimitConfig) *apisv1alpha1.ProviderConfig {
+		return &apisv1alpha1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{UID: "cool-uid"},
+			Spec:       apisv1alpha1.ProviderConfigSpec{RateLimit: rl},
+		}
+	}
+
+	first := rateLimiter(pc(&apisv1alpha1.RateLimitConfig{RPS: 5}))
+	if diff := cmp.Diff([]interface{}{rate.Limit(5), 5}, []interface{}{first.Limit(), first.Burst()}); diff != "" {
+		t.Errorf("rateLimiter(...): -want limit and burst, +got:\n%s\n", diff)
+	}
+
+	second := rateLimiter(pc(&apisv1alpha1.RateLimitConfig{RPS: 10, Burst: 20}))
+	if first != second {
+		t.Errorf("rateLimiter(...): want the rate limiter of a ProviderConfig to be shared by its clients")
+	}
+	if diff := cmp.Diff([]interface{}{rate.Limit(10), 20}, []interface{}{second.Limit(), second.Burst()}); diff != "" {
+		t.Errorf("rateLimiter(...): -want updated limit and burst, +got:\n%s\n", diff)
+	}
+
+	if l := rateLimiter(pc(nil)); l != nil {
+		t.Errorf("rateLimiter(...): want no rate limiter for an unlimited ProviderConfig, got %v", l)
+	}
+}
+
+func TestPruneRateLimiters(t *testing.T) {
+	pc := func(uid types.UID) *apisv1alpha1.ProviderConfig {
+		return &apisv1alpha1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{UID: uid},
+			Spec:       apisv1alpha1.ProviderConfigSpec{RateLimit: &apisv1alpha1.RateLimitConfig{RPS: 5}},
+		}
+	}
+
+	live := rateLimiter(pc("live-uid"))
+	rateLimiter(pc("deleted-uid"))
+
+	PruneRateLimiters("live-uid")
+
+	rateLimiters.Lock()
+	_, deleted := rateLimiters.m["deleted-uid"]
+	rateLimiters.Unlock()
+	if deleted {
+		t.Errorf("PruneRateLimiters(...): want the rate limiter of a deleted ProviderConfig to be dropped")
+	}
+	if l := rateLimiter(pc("live-uid")); l != live {
+		t.Errorf("PruneRateLimiters(...): want the rate limiter of an existing ProviderConfig to be kept")
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+// reasonResolvedFromStatus is the event reason used when the references of a
+// managed resource cannot be resolved and the IDs they last resolved to are
+// used instead.
+const reasonResolvedFromStatus event.Reason = "ResolvedFromStatus"
+
+// A ResolvedIDsFn sets the IDs the references of the supplied managed resource
+// last resolved to, as recorded in its status, wherever its references are
+// not resolved. It reports whether every reference of the managed resource
+// has an ID.
+type ResolvedIDsFn func(mg resource.Managed) bool
+
+// A ReferenceResolver resolves the references of managed resources to other
+// managed resources. The referenced resources are read from the informer
+// cache of the manager rather than from the API server. If they cannot be
+// read, for example because the referenced resource is briefly unavailable or
+// the API server is disrupted, the IDs the references last resolved to are
+// used instead, so that the managed resource is still reconciled.
+type ReferenceResolver struct {
+	resolver    managed.ReferenceResolver
+	resolvedIDs ResolvedIDsFn
+	recorder    event.Recorder
+}
+
+// NewReferenceResolver returns a ReferenceResolver resolving references with
+// the supplied client, which should be the cache-backed client of the
+// manager, and falling back to the IDs returned by the supplied function.
+func NewReferenceResolver(c client.Client, fn ResolvedIDsFn, r event.Recorder) *ReferenceResolver {
+	return &ReferenceResolver{
+		resolver:    managed.NewAPISimpleReferenceResolver(c),
+		resolvedIDs: fn,
+		recorder:    r,
+	}
+}
+
+// ResolveReferences of the supplied managed resource. Errors caused by the API
+// server are ignored if every reference already resolved once.
+func (r *ReferenceResolver) ResolveReferences(ctx context.Context, mg resource.Managed) error {
+	err := r.resolver.ResolveReferences(ctx, mg)
+	if err == nil || !isTransientResolutionError(err) || !r.resolvedIDs(mg) {
+		return err
+	}
+	r.recorder.Event(mg, event.Warning(reasonResolvedFromStatus, errors.Wrap(err, "using the IDs references last resolved to")))
+	return nil
+}
+
+// isTransientResolutionError reports whether err was returned by the API
+// server, or because it did not respond in time, rather than because the
+// references of a managed resource are invalid.
+func isTransientResolutionError(err error) bool {
+	var s kerrors.APIStatus
+	return errors.As(err, &s) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// A ReferenceFn returns the ID a managed resource refers to, along with the
+// reference and selector it is resolved from.
+type ReferenceFn func(mg resource.Managed) (id string, ref *xpv1.Reference, sel *xpv1.Selector)
+
+// EnqueueReferencing returns a MapFunc that enqueues every managed resource
+// of the lists returned by the supplied function whose reference returned by
+// fn refers to, or selects, the supplied object.
+func EnqueueReferencing(kube client.Reader, newList func() resource.ManagedList, fn ReferenceFn) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		l := newList()
+		if err := kube.List(ctx, l); err != nil {
+			// The managed resources will still be picked up on their
+			// next poll.
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, mg := range l.GetItems() {
+			id, ref, sel := fn(mg)
+			if RefersTo(mg, o, id, ref, sel) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: mg.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
+
+// RefersTo reports whether the supplied reference or selector of mg, resolved
+// to the supplied ID, points at o. References are resolved again on every
+// reconcile, while selectors are only resolved until an ID is set.
+func RefersTo(mg resource.Managed, o client.Object, id string, ref *xpv1.Reference, sel *xpv1.Selector) bool {
+	switch {
+	case ref != nil:
+		return ref.Name == o.GetName()
+	case sel == nil || id != "":
+		return false
+	case sel.MatchControllerRef != nil && *sel.MatchControllerRef && !meta.HaveSameController(mg, o):
+		return false
+	}
+	return labels.SelectorFromSet(sel.MatchLabels).Matches(labels.Set(o.GetLabels()))
+}
+
+// ReferenceChanged accepts updates to a referenced User, Group or OIDCClient
+// only when its external ID or its labels changed, which is all the managed
+// resources referring to it depend on.
+func ReferenceChanged() predicate.Predicate {
+	return predicate.Or(
+		predicate.LabelChangedPredicate{},
+		predicate.Funcs{
+			UpdateFunc: func(e ctrlevent.UpdateEvent) bool {
+				return atProviderID(e.ObjectOld) != atProviderID(e.ObjectNew)
+			},
+		},
+	)
+}
+
+// atProviderID returns the external ID recorded in the status of a User,
+// Group or OIDCClient.
+func atProviderID(o client.Object) string {
+	switch r := o.(type) {
+	case *apisv1beta1.User:
+		return r.Status.AtProvider.ID
+	case *apisv1beta1.Group:
+		return r.Status.AtProvider.ID
+	case *apisv1beta1.OIDCClient:
+		return r.Status.AtProvider.ID
+	}
+	return ""
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+func TestReferenceResolver(t *testing.T) {
+	unavailable := errors.Wrap(kerrors.NewServiceUnavailable("etcd is unavailable"), "mg.Spec.ForProvider.UserID")
+	notFound := errors.Wrap(kerrors.NewNotFound(schema.GroupResource{}, "jdoe"), "mg.Spec.ForProvider.UserID")
+	notReady := errors.New("mg.Spec.ForProvider.UserID: referenced field was empty (referenced resource may not yet be ready)")
+
+	type want struct {
+		err     error
+		reasons []event.Reason
+	}
+
+	cases := map[string]struct {
+		reason   string
+		err      error
+		resolved bool
+		want     want
+	}{
+		"Resolved": {
+			reason: "References that resolve should be used.",
+			want:   want{},
+		},
+		"Unavailable": {
+			reason:   "The IDs references last resolved to should be used while the API server is unavailable.",
+			err:      unavailable,
+			resolved: true,
+			want:     want{reasons: []event.Reason{reasonResolvedFromStatus}},
+		},
+		"BrieflyGone": {
+			reason:   "The IDs references last resolved to should be used while a referenced resource cannot be found.",
+			err:      notFound,
+			resolved: true,
+			want:     want{reasons: []event.Reason{reasonResolvedFromStatus}},
+		},
+		"NeverResolved": {
+			reason: "Errors should be returned if references never resolved.",
+			err:    unavailable,
+			want:   want{err: unavailable},
+		},
+		"NotReady": {
+			reason:   "Errors that are not caused by the API server should be returned.",
+			err:      notReady,
+			resolved: true,
+			want:     want{err: notReady},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &recorder{}
+			r := &ReferenceResolver{
+				resolver: managed.ReferenceResolverFn(func(_ context.Context, _ resource.Managed) error {
+					return tc.err
+				}),
+				resolvedIDs: func(_ resource.Managed) bool { return tc.resolved },
+				recorder:    rec,
+			}
+
+			err := r.ResolveReferences(context.Background(), &fake.Managed{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.ResolveReferences(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, rec.reasons); diff != "" {
+				t.Errorf("\n%s\nr.ResolveReferences(...): -want event reasons, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func TestRefersTo(t *testing.T) {
+	controlledBy := func(uid string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:            "admins",
+			Labels:          map[string]string{"tier": "admin"},
+			OwnerReferences: []metav1.OwnerReference{{UID: types.UID(uid), Controller: ptr.To(true)}},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     metav1.ObjectMeta
+		id     string
+		ref    *xpv1.Reference
+		sel    *xpv1.Selector
+		want   bool
+	}{
+		"ReferencedByName": {
+			reason: "A reference naming the object should refer to it.",
+			ref:    &xpv1.Reference{Name: "admins"},
+			want:   true,
+		},
+		"ResolvedReference": {
+			reason: "A reference should refer to the object it names even once resolved, as references are resolved again.",
+			id:     "some-id",
+			ref:    &xpv1.Reference{Name: "admins"},
+			want:   true,
+		},
+		"ReferencedOther": {
+			reason: "A reference naming another object should not refer to it.",
+			ref:    &xpv1.Reference{Name: "other"},
+		},
+		"SelectedByLabels": {
+			reason: "A selector matching the labels of the object should refer to it.",
+			sel:    &xpv1.Selector{MatchLabels: map[string]string{"tier": "admin"}},
+			want:   true,
+		},
+		"ResolvedSelector": {
+			reason: "A selector should not refer to anything once resolved, as selectors are not resolved again.",
+			id:     "some-id",
+			sel:    &xpv1.Selector{MatchLabels: map[string]string{"tier": "admin"}},
+		},
+		"SameController": {
+			reason: "A selector matching controller references should refer to objects with the same controller.",
+			mg:     controlledBy("uid-xr"),
+			sel:    &xpv1.Selector{MatchControllerRef: ptr.To(true)},
+			want:   true,
+		},
+		"OtherController": {
+			reason: "A selector matching controller references should not refer to objects with another controller.",
+			mg:     controlledBy("uid-other-xr"),
+			sel:    &xpv1.Selector{MatchControllerRef: ptr.To(true)},
+		},
+		"NothingSet": {
+			reason: "An ID set directly should not refer to anything.",
+			id:     "some-id",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &apisv1beta1.UserGroupBinding{ObjectMeta: tc.mg}
+			o := &apisv1beta1.Group{ObjectMeta: controlledBy("uid-xr")}
+			if diff := cmp.Diff(tc.want, RefersTo(mg, o, tc.id, tc.ref, tc.sel)); diff != "" {
+				t.Errorf("\n%s\nRefersTo(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReferenceChanged(t *testing.T) {
+	withID := func(id string) *apisv1beta1.OIDCClient {
+		oc := &apisv1beta1.OIDCClient{}
+		oc.Status.AtProvider.ID = id
+		return oc
+	}
+
+	cases := map[string]struct {
+		reason string
+		old    client.Object
+		new    client.Object
+		want   bool
+	}{
+		"IDAppeared": {
+			reason: "A referenced resource that just got its external ID should trigger the resources referring to it.",
+			old:    withID(""),
+			new:    withID("abc"),
+			want:   true,
+		},
+		"Unchanged": {
+			reason: "A status update that does not change the external ID should be ignored.",
+			old:    withID("abc"),
+			new:    withID("abc"),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ReferenceChanged().Update(ctrlevent.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nReferenceChanged().Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
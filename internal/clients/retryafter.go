@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+// defaultRetryAfter is how long managed resources rate limited by a Pocket ID
+// API that did not say how long to wait are left alone.
+const defaultRetryAfter = time.Minute
+
+// rateLimited holds how long the Pocket ID API asked to wait before the
+// requests made on behalf of a managed resource, keyed by <kind>/<name>, are
+// retried.
+var rateLimited sync.Map
+
+// recordRateLimited records how long to wait before the requests made on
+// behalf of the supplied managed resource are retried, if they were rate
+// limited or short-circuited by an open circuit breaker.
+func recordRateLimited(source string, err error) {
+	rl := &pocketid.RateLimitedError{}
+	ue := &pocketid.UnavailableError{}
+	switch {
+	case errors.As(err, &rl):
+		rateLimited.Store(source, rl.RetryAfter)
+	case errors.As(err, &ue):
+		rateLimited.Store(source, ue.RetryAfter)
+	}
+}
+
+// HonorRetryAfter returns a reconciler requeueing the managed resources of the
+// supplied kind whose requests were rate limited by the Pocket ID API once
+// the API asked, or short-circuited by an open circuit breaker once it lets
+// requests through again, rather than retrying them as soon as failed
+// reconciles are.
+func HonorRetryAfter(kind string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		res, err := r.Reconcile(ctx, req)
+		v, ok := rateLimited.LoadAndDelete(kind + "/" + req.Name)
+		if !ok || err != nil {
+			return res, err
+		}
+		d := v.(time.Duration)
+		if d <= 0 {
+			d = defaultRetryAfter
+		}
+		return reconcile.Result{RequeueAfter: d}, nil
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+type observeFn func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error)
+
+type mockExternal struct {
+	managed.ExternalClient
+	observe observeFn
+}
+
+func (m *mockExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	return m.observe(ctx, mg)
+}
+
+func TestHonorRetryAfter(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   reconcile.Result
+	}{
+		"RateLimited": {
+			reason: "A resource rate limited by the API should be requeued once the API asked.",
+			err:    &pocketid.RateLimitedError{RetryAfter: 2 * time.Minute},
+			want:   reconcile.Result{RequeueAfter: 2 * time.Minute},
+		},
+		"RateLimitedWithoutRetryAfter": {
+			reason: "A resource rate limited by the API should be left alone for a while if the API did not say how long.",
+			err:    &pocketid.RateLimitedError{},
+			want:   reconcile.Result{RequeueAfter: defaultRetryAfter},
+		},
+		"CircuitOpen": {
+			reason: "A resource short-circuited by an open circuit breaker should be requeued once it lets requests through again.",
+			err:    &pocketid.UnavailableError{RetryAfter: 20 * time.Second},
+			want:   reconcile.Result{RequeueAfter: 20 * time.Second},
+		},
+		"OtherError": {
+			reason: "A resource failing otherwise should be requeued as the reconciler says.",
+			err:    errors.New("boom"),
+			want:   reconcile.Result{Requeue: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := AttributeRequests("User", &mockExternal{observe: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{}, tc.err
+			}})
+			r := HonorRetryAfter("User", reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+				mg := &fake.Managed{}
+				mg.SetName(req.Name)
+				_, _ = e.Observe(ctx, mg)
+				return reconcile.Result{Requeue: true}, nil
+			}))
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "alice"}})
+			if err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
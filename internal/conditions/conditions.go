@@ -0,0 +1,293 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conditions defines the conditions shared by the controllers of the
+// provider, so that the same problem is reported the same way whichever
+// resource it affects.
+package conditions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types.
+const (
+	// TypeCredentialsValid reports whether Pocket ID accepts the credentials
+	// a resource is reconciled with.
+	TypeCredentialsValid xpv1.ConditionType = "CredentialsValid"
+
+	// TypeDependencyReady reports whether the resources a resource refers to
+	// have reported their Pocket ID identifier.
+	TypeDependencyReady xpv1.ConditionType = "DependencyReady"
+
+	// TypeLogoSynced reports whether the logo of a resource was uploaded to
+	// Pocket ID.
+	TypeLogoSynced xpv1.ConditionType = "LogoSynced"
+
+	// TypeRateLimited reports whether the requests of a resource are rate
+	// limited by Pocket ID.
+	TypeRateLimited xpv1.ConditionType = "RateLimited"
+
+	// TypeConflicting reports whether a resource conflicts with another
+	// resource or Pocket ID object.
+	TypeConflicting xpv1.ConditionType = "Conflicting"
+
+	// TypeUnsupportedField reports whether a resource uses fields its Pocket
+	// ID server does not support.
+	TypeUnsupportedField xpv1.ConditionType = "UnsupportedField"
+
+	// TypeInUse reports whether a resource being deleted is still used by
+	// bindings, which are deleted first.
+	TypeInUse xpv1.ConditionType = "InUse"
+)
+
+// Condition reasons.
+const (
+	ReasonCredentialsAccepted xpv1.ConditionReason = "CredentialsAccepted"
+	ReasonCredentialsRejected xpv1.ConditionReason = "CredentialsRejected"
+
+	ReasonDependencyReady      xpv1.ConditionReason = "DependencyReady"
+	ReasonWaitingForDependency xpv1.ConditionReason = "WaitingForDependency"
+
+	ReasonLogoUploaded     xpv1.ConditionReason = "LogoUploaded"
+	ReasonLogoUploading    xpv1.ConditionReason = "LogoUploading"
+	ReasonLogoUploadFailed xpv1.ConditionReason = "LogoUploadFailed"
+
+	ReasonRateLimited    xpv1.ConditionReason = "RateLimited"
+	ReasonNotRateLimited xpv1.ConditionReason = "NotRateLimited"
+
+	ReasonAlreadyExists    xpv1.ConditionReason = "AlreadyExists"
+	ReasonDuplicateBinding xpv1.ConditionReason = "DuplicateBinding"
+	ReasonNoConflict       xpv1.ConditionReason = "NoConflict"
+
+	ReasonUnsupportedField xpv1.ConditionReason = "UnsupportedField"
+	ReasonFieldsSupported  xpv1.ConditionReason = "FieldsSupported"
+
+	ReasonWaitingForBindings xpv1.ConditionReason = "WaitingForBindings"
+	ReasonNotInUse           xpv1.ConditionReason = "NotInUse"
+)
+
+// A Conditioned object reports conditions, such as a managed resource or a
+// ProviderConfig.
+type Conditioned interface {
+	GetCondition(ct xpv1.ConditionType) xpv1.Condition
+	SetConditions(c ...xpv1.Condition)
+}
+
+// Clear sets the supplied condition, reporting that a problem is resolved,
+// only if the supplied object reported a condition of its type already. This
+// keeps objects that never had the problem from reporting it at all.
+func Clear(o Conditioned, c xpv1.Condition) {
+	if o.GetCondition(c.Type).Status != corev1.ConditionUnknown {
+		o.SetConditions(c)
+	}
+}
+
+// CredentialsValid returns a condition indicating that Pocket ID accepts the
+// credentials of a resource.
+func CredentialsValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsValid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsAccepted,
+	}
+}
+
+// CredentialsInvalid returns a condition indicating that Pocket ID rejects the
+// credentials of a resource.
+func CredentialsInvalid(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsRejected,
+		Message:            err.Error(),
+	}
+}
+
+// DependencyReady returns a condition indicating that the resources a resource
+// refers to have reported their Pocket ID identifier.
+func DependencyReady() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDependencyReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependencyReady,
+	}
+}
+
+// WaitingForDependency returns a condition indicating that a resource a
+// resource refers to has not reported its Pocket ID identifier yet.
+func WaitingForDependency(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDependencyReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForDependency,
+		Message:            err.Error(),
+	}
+}
+
+// LogoUploaded returns a condition indicating that the logo of a resource was
+// uploaded.
+func LogoUploaded() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeLogoSynced,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonLogoUploaded,
+	}
+}
+
+// LogoUploading returns a condition indicating that the logo of a resource is
+// being uploaded.
+func LogoUploading() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeLogoSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonLogoUploading,
+	}
+}
+
+// LogoUploadFailed returns a condition indicating that the logo of a resource
+// could not be uploaded after the supplied attempts, and is retried.
+func LogoUploadFailed(attempts int, err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeLogoSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonLogoUploadFailed,
+		Message:            fmt.Sprintf("cannot upload logo after %d attempts, retrying: %s", attempts, err),
+	}
+}
+
+// RateLimited returns a condition indicating that the requests of a resource
+// are rate limited by Pocket ID, and retried after the supplied duration.
+func RateLimited(retryAfter time.Duration) xpv1.Condition {
+	msg := "Requests are rate limited by Pocket ID"
+	if retryAfter > 0 {
+		msg = fmt.Sprintf("%s, retrying in %s", msg, retryAfter)
+	}
+	return xpv1.Condition{
+		Type:               TypeRateLimited,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRateLimited,
+		Message:            msg,
+	}
+}
+
+// NotRateLimited returns a condition indicating that the requests of a
+// resource are no longer rate limited.
+func NotRateLimited() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRateLimited,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotRateLimited,
+	}
+}
+
+// AlreadyExists returns a condition indicating that Pocket ID rejected a
+// resource because it conflicts with an existing object.
+func AlreadyExists(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflicting,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAlreadyExists,
+		Message:            err.Error(),
+	}
+}
+
+// DuplicateBinding returns a condition indicating that a binding binds the
+// same objects as the supplied, older binding of the supplied kind.
+func DuplicateBinding(kind, owner string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflicting,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDuplicateBinding,
+		Message:            fmt.Sprintf("%s %q already binds these objects", kind, owner),
+	}
+}
+
+// NoConflict returns a condition indicating that a resource no longer
+// conflicts with another.
+func NoConflict() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflicting,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoConflict,
+	}
+}
+
+// UnsupportedField returns a condition indicating that a resource uses the
+// supplied fields, which the Pocket ID server of the supplied version does
+// not support.
+func UnsupportedField(serverVersion string, fields []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUnsupportedField,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnsupportedField,
+		Message:            fmt.Sprintf("Pocket ID %s does not support %s", serverVersion, strings.Join(fields, ", ")),
+	}
+}
+
+// FieldsSupported returns a condition indicating that the Pocket ID server of
+// a resource supports every field it uses.
+func FieldsSupported() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUnsupportedField,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFieldsSupported,
+	}
+}
+
+// InUse returns a condition indicating that a resource being deleted is still
+// used by the supplied bindings, and is deleted once they are gone.
+func InUse(users []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInUse,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForBindings,
+		Message:            fmt.Sprintf("Waiting for %s to be deleted first", strings.Join(users, ", ")),
+	}
+}
+
+// NotInUse returns a condition indicating that a resource is no longer used by
+// any binding.
+func NotInUse() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInUse,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotInUse,
+	}
+}
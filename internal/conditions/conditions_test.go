@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestClear(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		existing []xpv1.Condition
+		clear    xpv1.Condition
+		want     corev1.ConditionStatus
+	}{
+		"NeverReported": {
+			reason: "A problem never reported should not be reported as resolved.",
+			clear:  NotRateLimited(),
+			want:   corev1.ConditionUnknown,
+		},
+		"Reported": {
+			reason:   "A problem reported should be reported as resolved.",
+			existing: []xpv1.Condition{RateLimited(0)},
+			clear:    NotRateLimited(),
+			want:     corev1.ConditionFalse,
+		},
+		"ReportedSatisfied": {
+			reason:   "A condition reported unsatisfied should be reported satisfied once resolved.",
+			existing: []xpv1.Condition{WaitingForDependency(errBoom{})},
+			clear:    DependencyReady(),
+			want:     corev1.ConditionTrue,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.existing...)
+			Clear(mg, tc.clear)
+			if diff := cmp.Diff(tc.want, mg.GetCondition(tc.clear.Type).Status); diff != "" {
+				t.Errorf("\n%s\nClear(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type errBoom struct{}
+
+func (errBoom) Error() string { return "boom" }
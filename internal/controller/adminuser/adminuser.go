@@ -37,8 +37,11 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
 
 	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/changelog"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
 	"github.com/crossplane/provider-pocketid/internal/features"
+	"github.com/crossplane/provider-pocketid/internal/readonly"
 )
 
 const (
@@ -52,8 +55,8 @@ const (
 
 // newPocketIDService creates a new Pocket ID service
 var (
-	newPocketIDService = func(endpoint string, creds []byte) (interface{}, error) {
-		return pocketid.NewClientFromCredentials(endpoint, string(creds))
+	newPocketIDService = func(cfg pocketid.Config) (pocketid.Service, error) {
+		return clients.NewClient(cfg)
 	}
 )
 
@@ -66,14 +69,19 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: newPocketIDService}),
+			newServiceFn: newPocketIDService,
+			recorder:     recorder}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(clients.PollIntervalHook(mgr.GetClient())),
+		managed.WithCriticalAnnotationUpdater(clients.NewCriticalAnnotationUpdater(mgr.GetClient())),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...),
 		managed.WithManagementPolicies(),
 	}
@@ -102,7 +110,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&apisv1alpha1.AdminUser{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, clients.HonorRetryAfter(apisv1alpha1.AdminUserKind, r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -110,7 +118,8 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(endpoint string, creds []byte) (interface{}, error)
+	newServiceFn func(cfg pocketid.Config) (pocketid.Service, error)
+	recorder     event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -133,24 +142,28 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	data, err := clients.ExtractCredentials(ctx, c.kube, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	svc, err := c.newServiceFn(pc.Spec.Endpoint, data)
+	cfg, err := clients.NewConfig(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	svc, err := c.newServiceFn(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc.(*pocketid.Client)}, nil
+	return readonly.Wrap(pc, clients.AttributeRequests(apisv1alpha1.AdminUserKind, &external{service: svc}), c.recorder), nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service *pocketid.Client
+	service pocketid.Service
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -201,7 +214,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	// Check if resource is up to date
-	upToDate := isAdminUserUpToDate(cr.Spec.ForProvider, *user)
+	upToDate := isAdminUserUpToDate(cr.Spec.ForProvider, *user, clients.Owner(cr, user.CustomClaims))
 
 	cr.Status.SetConditions(xpv1.Available())
 
@@ -225,7 +238,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		Locale:       cr.Spec.ForProvider.Locale,
 		Disabled:     cr.Spec.ForProvider.Disabled,
 		IsAdmin:      true, // AdminUser resources create admin users
-		CustomClaims: cr.Spec.ForProvider.CustomClaims,
+		CustomClaims: pocketid.WithOwner(cr.Spec.ForProvider.CustomClaims, clients.Creator(cr)),
 	}
 
 	user, err := c.service.CreateUser(ctx, req)
@@ -256,15 +269,19 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		LastName:     cr.Spec.ForProvider.LastName,
 		Locale:       cr.Spec.ForProvider.Locale,
 		Disabled:     cr.Spec.ForProvider.Disabled,
-		CustomClaims: cr.Spec.ForProvider.CustomClaims,
+		CustomClaims: pocketid.WithOwner(cr.Spec.ForProvider.CustomClaims, clients.Owner(cr, cr.Status.AtProvider.CustomClaims)),
 	}
 
+	// Record what the update changes in the change log, whether it succeeds
+	// or not.
+	u := managed.ExternalUpdate{AdditionalDetails: adminUserChanges(cr.Status.AtProvider, cr.Spec.ForProvider)}
+
 	_, err := c.service.UpdateUser(ctx, cr.Status.AtProvider.ID, req)
 	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update admin user")
+		return u, errors.Wrap(err, "failed to update admin user")
 	}
 
-	return managed.ExternalUpdate{}, nil
+	return u, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
@@ -287,10 +304,24 @@ func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// adminUserChanges describes the fields an update of the supplied observed
+// admin user to the supplied spec changes.
+func adminUserChanges(observed apisv1alpha1.AdminUserObservation, spec apisv1alpha1.AdminUserParameters) managed.AdditionalDetails {
+	return changelog.Details(
+		changelog.Field("username", observed.Username, spec.Username),
+		changelog.Field("email", observed.Email, spec.Email),
+		changelog.Field("firstName", observed.FirstName, spec.FirstName),
+		changelog.Field("lastName", observed.LastName, spec.LastName),
+		changelog.Field("locale", observed.Locale, spec.Locale),
+		changelog.Field("disabled", observed.Disabled, spec.Disabled),
+		changelog.Claims("customClaims", observed.CustomClaims, spec.CustomClaims),
+	)
+}
+
 // isAdminUserUpToDate compares the desired spec with the actual admin user state
 //
 //nolint:gocyclo
-func isAdminUserUpToDate(spec apisv1alpha1.AdminUserParameters, user pocketid.User) bool {
+func isAdminUserUpToDate(spec apisv1alpha1.AdminUserParameters, user pocketid.User, owner string) bool {
 	if spec.Username != user.Username {
 		return false
 	}
@@ -310,11 +341,13 @@ func isAdminUserUpToDate(spec apisv1alpha1.AdminUserParameters, user pocketid.Us
 		return false
 	}
 
-	// Compare custom claims
-	if len(spec.CustomClaims) != len(user.CustomClaims) {
+	// Compare custom claims, along with the owner claim of users created by
+	// the provider, which is re-stamped by the managed resource adopting them
+	claims := pocketid.WithOwner(spec.CustomClaims, owner)
+	if len(claims) != len(user.CustomClaims) {
 		return false
 	}
-	for k, v := range spec.CustomClaims {
+	for k, v := range claims {
 		if userVal, exists := user.CustomClaims[k]; !exists || userVal != v {
 			return false
 		}
@@ -18,14 +18,23 @@ package adminuser
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+	"github.com/crossplane/provider-pocketid/internal/controller/controllertest"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,15 +45,39 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type fields struct {
-		service *pocketid.Client
+// adminUser returns an AdminUser managing the root user, modified by the
+// supplied functions.
+func adminUser(fns ...func(cr *apisv1alpha1.AdminUser)) *apisv1alpha1.AdminUser {
+	cr := &apisv1alpha1.AdminUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "root", UID: "0b3e5a1c"},
+		Spec: apisv1alpha1.AdminUserSpec{
+			ForProvider: apisv1alpha1.AdminUserParameters{Username: "root", Email: "root@example.com", FirstName: "Root"},
+		},
 	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+	for _, fn := range fns {
+		fn(cr)
 	}
+	return cr
+}
+
+// withID sets the ID of the Pocket ID user of an AdminUser, as observed.
+func withID(id string) func(cr *apisv1alpha1.AdminUser) {
+	return func(cr *apisv1alpha1.AdminUser) { cr.Status.AtProvider.ID = id }
+}
+
+// seedUser returns a seed adding the supplied Pocket ID user.
+func seedUser(u pocketid.User) controllertest.Seed {
+	return func(s *pocketidfake.Server) { s.AddUser(u) }
+}
+
+// root is the Pocket ID admin user managed by adminUser().
+var root = pocketid.User{ID: "root-id", Username: "root", Email: "root@example.com", FirstName: "Root", IsAdmin: true}
+
+func TestObserve(t *testing.T) {
+	drifted := root
+	drifted.Email = "admin@example.com"
+	regular := root
+	regular.IsAdmin = false
 
 	type want struct {
 		o   managed.ExternalObservation
@@ -53,17 +86,51 @@ func TestObserve(t *testing.T) {
 
 	cases := map[string]struct {
 		reason string
-		fields fields
-		args   args
+		seeds  []controllertest.Seed
+		mg     resource.Managed
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotFound": {
+			reason: "An admin user missing from Pocket ID should be reported as not existing.",
+			mg:     adminUser(),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"UpToDate": {
+			reason: "An admin user matching its spec should be reported as up to date.",
+			seeds:  []controllertest.Seed{seedUser(root)},
+			mg:     adminUser(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"Drifted": {
+			reason: "An admin user changed in Pocket ID should be reported as needing an update.",
+			seeds:  []controllertest.Seed{seedUser(drifted)},
+			mg:     adminUser(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"NotAdmin": {
+			reason: "A regular user should never be managed as an admin user.",
+			seeds:  []controllertest.Seed{seedUser(regular)},
+			mg:     adminUser(),
+			want:   want{err: errors.New("user exists but is not an admin user")},
+		},
+		"APIError": {
+			reason: "Errors getting the admin user should be returned.",
+			seeds:  []controllertest.Seed{controllertest.Fail(http.MethodGet, "/api/users", http.StatusInternalServerError)},
+			mg:     adminUser(),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to get admin user")},
+		},
+		"NotAdminUser": {
+			reason: "Managed resources other than AdminUsers should be rejected.",
+			mg:     &fake.Managed{},
+			want:   want{err: errors.New(errNotAdminUser)},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc}
+			got, err := e.Observe(context.Background(), tc.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
@@ -73,3 +140,128 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		want   error
+	}{
+		"Created": {
+			reason: "An admin user should be created as an admin.",
+		},
+		"APIError": {
+			reason: "Errors creating the admin user should be returned.",
+			seeds:  []controllertest.Seed{controllertest.Fail(http.MethodPost, "/api/users", http.StatusInternalServerError)},
+			want:   errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to create admin user"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc}
+			_, err := e.Create(context.Background(), adminUser())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if u, _ := svc.GetUserByExternalName(context.Background(), "root"); u == nil || !u.IsAdmin {
+				t.Errorf("\n%s\ne.Create(...): want an admin user created, got %+v", tc.reason, u)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	drifted := root
+	drifted.Email = "admin@example.com"
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		mg     *apisv1alpha1.AdminUser
+		want   error
+	}{
+		"Updated": {
+			reason: "A drifted admin user should be updated to match its spec.",
+			seeds:  []controllertest.Seed{seedUser(drifted)},
+			mg:     adminUser(withID("root-id")),
+		},
+		"NoID": {
+			reason: "An admin user whose ID was never observed cannot be updated.",
+			mg:     adminUser(),
+			want:   errors.New("admin user ID not found in status"),
+		},
+		"APIError": {
+			reason: "Errors updating the admin user should be returned.",
+			seeds:  []controllertest.Seed{seedUser(drifted), controllertest.Fail(http.MethodPut, "/api/users", http.StatusInternalServerError)},
+			mg:     adminUser(withID("root-id")),
+			want:   errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to update admin user"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if u, _ := srv.User("root-id"); !isAdminUserUpToDate(tc.mg.Spec.ForProvider, u, clients.Owner(tc.mg, u.CustomClaims)) {
+				t.Errorf("\n%s\ne.Update(...): want the admin user up to date, got %+v", tc.reason, u)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		mg     *apisv1alpha1.AdminUser
+		want   want
+	}{
+		"Deleted": {
+			reason: "An admin user should be deleted from Pocket ID.",
+			seeds:  []controllertest.Seed{seedUser(root)},
+			mg:     adminUser(withID("root-id")),
+			want:   want{calls: []string{http.MethodDelete}},
+		},
+		"NeverObserved": {
+			reason: "An admin user whose ID was never observed was never created, and should not be deleted.",
+			mg:     adminUser(),
+		},
+		"APIError": {
+			reason: "Errors deleting the admin user should be returned.",
+			seeds:  []controllertest.Seed{seedUser(root), controllertest.Fail(http.MethodDelete, "/api/users", http.StatusInternalServerError)},
+			mg:     adminUser(withID("root-id")),
+			want:   want{calls: []string{http.MethodDelete}, err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to delete admin user")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc}
+			_, err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
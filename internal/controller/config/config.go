@@ -17,18 +17,130 @@ limitations under the License.
 package config
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+)
+
+const (
+	errGetPC        = "cannot get ProviderConfig"
+	errListPCs      = "cannot list ProviderConfigs"
+	errListPCUs     = "cannot list ProviderConfigUsages"
+	errGetUser      = "cannot get ProviderConfig user"
+	errDeletePCU    = "cannot delete stale ProviderConfigUsage"
+	errGetCreds     = "cannot get credentials"
+	errNewClient    = "cannot create new Service"
+	errHealthCheck  = "cannot reach Pocket ID API"
+	errUpdateStatus = "cannot update ProviderConfig status"
+)
+
+// typeInsecureTLS is the condition reported by ProviderConfigs that disable
+// verification of the Pocket ID server certificate.
+const typeInsecureTLS xpv1.ConditionType = "InsecureTLS"
+
+// reasonInsecureTLS is the event reason used to warn about ProviderConfigs that
+// disable verification of the Pocket ID server certificate.
+const reasonInsecureTLS event.Reason = "InsecureTLS"
+
+// typeHealthy is the condition reporting whether the Pocket ID API can be
+// reached with the credentials of a ProviderConfig.
+const typeHealthy xpv1.ConditionType = "Healthy"
+
+// reasonUnhealthy is the event reason used to warn about ProviderConfigs whose
+// Pocket ID API cannot be reached.
+const reasonUnhealthy event.Reason = "Unhealthy"
+
+// reasonUnauthorized is the reason of the Healthy condition of ProviderConfigs
+// whose credentials are rejected by Pocket ID.
+const reasonUnauthorized xpv1.ConditionReason = "Unauthorized"
+
+// reasonNotIssuer is the reason of the Healthy condition of ProviderConfigs
+// whose endpoint does not serve an OpenID Connect issuer.
+const reasonNotIssuer xpv1.ConditionReason = "NotAnIssuer"
+
+// typePreviousAPIKeyInUse is the condition reported by ProviderConfigs whose
+// current API key is rejected while their previous one is still accepted.
+const typePreviousAPIKeyInUse xpv1.ConditionType = "PreviousAPIKeyInUse"
+
+// reasonPreviousAPIKeyInUse is the event reason used to warn about
+// ProviderConfigs that fall back to their previous API key.
+const reasonPreviousAPIKeyInUse event.Reason = "PreviousAPIKeyInUse"
+
+// typeIncompatibleVersion is the condition reported by ProviderConfigs whose
+// Pocket ID server is of a major version the provider is not built for.
+const typeIncompatibleVersion xpv1.ConditionType = "IncompatibleVersion"
+
+// reasonIncompatibleVersion is the event reason used to warn about
+// ProviderConfigs whose Pocket ID server is of an unsupported major version.
+const reasonIncompatibleVersion event.Reason = "IncompatibleVersion"
+
+// serverCompatible reports whether the Pocket ID server of each ProviderConfig
+// is of a version the provider is built for.
+var serverCompatible = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "pocketid_server_compatible",
+	Help: "Whether the Pocket ID server of a ProviderConfig is of a major version the provider is built for, by ProviderConfig and server version.",
+}, []string{"providerconfig", "version"})
+
+// Collectors returns the collectors of the metrics of the ProviderConfig
+// controller, to be registered with the metrics of the provider.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{serverCompatible}
+}
+
+// A healthChecker calls cheap Pocket ID API endpoints.
+type healthChecker interface {
+	Healthz(ctx context.Context) error
+	GetCurrentUser(ctx context.Context) (*pocketid.User, error)
+	GetVersion(ctx context.Context) (string, error)
+	GetOpenIDConfiguration(ctx context.Context) (*pocketid.OpenIDConfiguration, error)
+	UsingPreviousAPIKey() bool
+}
+
+// health is the result of a successful health check.
+type health struct {
+	// previousAPIKey is whether only the previous API key was accepted.
+	previousAPIKey bool
+
+	// version of the Pocket ID server, empty if it does not report it.
+	version string
+}
+
+// newPocketIDService creates a new Pocket ID service
+var (
+	newPocketIDService = func(cfg pocketid.Config) (healthChecker, error) {
+		return clients.NewClient(cfg)
+	}
 )
 
 // Setup adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
+// their current usage and periodically checking the health of their Pocket ID
+// API.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := providerconfig.ControllerName(apisv1alpha1.ProviderConfigGroupKind)
 
@@ -37,9 +149,17 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		UsageList: apisv1alpha1.ProviderConfigUsageListGroupVersionKind,
 	}
 
-	r := providerconfig.NewReconciler(mgr, of,
-		providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
-		providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	r := &reconciler{
+		usage: providerconfig.NewReconciler(mgr, of,
+			providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
+			providerconfig.WithRecorder(recorder)),
+		kube:         mgr.GetClient(),
+		record:       recorder,
+		newServiceFn: newPocketIDService,
+		pollInterval: o.PollInterval,
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -48,3 +168,364 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Watches(&apisv1alpha1.ProviderConfigUsage{}, &resource.EnqueueRequestForProviderConfig{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
+
+// A reconciler accounts for the usage of a ProviderConfig, then reports
+// whether it disables TLS verification and whether its Pocket ID API is
+// healthy.
+type reconciler struct {
+	usage        reconcile.Reconciler
+	kube         client.Client
+	record       event.Recorder
+	newServiceFn func(cfg pocketid.Config) (healthChecker, error)
+	pollInterval time.Duration
+}
+
+// pruneRateLimiters drops the rate limiters shared by the clients of the
+// ProviderConfigs that were deleted. Their UIDs are gone along with them, so
+// the limiters of every ProviderConfig that still exists are kept instead.
+func (r *reconciler) pruneRateLimiters(ctx context.Context) error {
+	l := &apisv1alpha1.ProviderConfigList{}
+	if err := r.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListPCs)
+	}
+	live := make([]types.UID, 0, len(l.Items))
+	for _, pc := range l.Items {
+		live = append(live, pc.GetUID())
+	}
+	clients.PruneRateLimiters(live...)
+	return nil
+}
+
+// Reconcile a ProviderConfig.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.usage.Reconcile(ctx, req)
+	if err != nil {
+		return result, err
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			serverCompatible.DeletePartialMatch(prometheus.Labels{"providerconfig": req.Name})
+			return result, r.pruneRateLimiters(ctx)
+		}
+		return result, errors.Wrap(err, errGetPC)
+	}
+
+	before := pc.Status.DeepCopy()
+
+	usages, err := r.accountUsages(ctx, pc)
+	if err != nil {
+		return result, err
+	}
+	pc.Status.UsagesByKind = usages
+
+	insecure := pc.Spec.TLS != nil && pc.Spec.TLS.InsecureSkipTLSVerify
+	current := pc.Status.GetCondition(typeInsecureTLS)
+
+	switch {
+	case insecure && current.Status != corev1.ConditionTrue:
+		r.record.Event(pc, event.Warning(reasonInsecureTLS, errors.New("TLS certificate verification of the Pocket ID server is disabled")))
+		pc.Status.SetConditions(insecureTLS())
+	case !insecure && current.Status == corev1.ConditionTrue:
+		pc.Status.SetConditions(secureTLS())
+	}
+
+	// The Pocket ID API of paused ProviderConfigs is not called, e.g. during
+	// maintenance windows. Their health is checked again once resumed.
+	if meta.IsPaused(pc) {
+		pc.Status.SetConditions(xpv1.ReconcilePaused())
+	} else {
+		conditions.Clear(pc, xpv1.ReconcileSuccess())
+	}
+
+	if !meta.WasDeleted(pc) && !meta.IsPaused(pc) {
+		h, err := r.checkHealth(ctx, pc)
+		if err != nil {
+			if pc.Status.GetCondition(typeHealthy).Status != corev1.ConditionFalse {
+				r.record.Event(pc, event.Warning(reasonUnhealthy, err))
+			}
+			c := unhealthy(err)
+			rejected := true
+			var ce *clients.CredentialsError
+			switch {
+			case errors.Is(err, pocketid.ErrNotAdmin):
+				c = notAdmin()
+			case errors.Is(err, pocketid.ErrUnauthorized):
+				c.Reason = reasonUnauthorized
+			case errors.Is(err, pocketid.ErrNotIssuer):
+				c.Reason = reasonNotIssuer
+				rejected = false
+			case errors.As(err, &ce):
+				c.Reason = ce.Reason
+			default:
+				rejected = false
+			}
+			pc.Status.SetConditions(c)
+			if rejected {
+				pc.Status.SetConditions(conditions.CredentialsInvalid(err))
+			}
+		} else {
+			pc.Status.SetConditions(healthy())
+			conditions.Clear(pc, conditions.CredentialsValid())
+			pc.Status.ServerVersion = h.version
+			r.checkCompatibility(pc, h.version)
+		}
+
+		switch inUse := pc.Status.GetCondition(typePreviousAPIKeyInUse).Status; {
+		case err == nil && h.previousAPIKey && inUse != corev1.ConditionTrue:
+			r.record.Event(pc, event.Warning(reasonPreviousAPIKeyInUse, errors.New("the current API key is rejected, falling back to the previous one")))
+			pc.Status.SetConditions(previousAPIKeyInUse())
+		case err == nil && !h.previousAPIKey && inUse == corev1.ConditionTrue:
+			pc.Status.SetConditions(currentAPIKeyInUse())
+		}
+
+		// Keep checking the health of the API even if nothing else changes.
+		if result.IsZero() {
+			result.RequeueAfter = r.pollInterval
+			if pc.Spec.PollInterval != nil {
+				result.RequeueAfter = pc.Spec.PollInterval.Duration
+			}
+		}
+	}
+
+	if equality.Semantic.DeepEqual(before, &pc.Status) {
+		return result, nil
+	}
+
+	return result, errors.Wrap(r.kube.Status().Update(ctx, pc), errUpdateStatus)
+}
+
+// checkCompatibility reports whether the Pocket ID server of the supplied
+// ProviderConfig is of a major version the provider is built for, so that an
+// upgrade breaking the API is flagged before individual calls fail.
+func (r *reconciler) checkCompatibility(pc *apisv1alpha1.ProviderConfig, v string) {
+	compatible := pocketid.CompatibleVersion(v)
+
+	serverCompatible.DeletePartialMatch(prometheus.Labels{"providerconfig": pc.GetName()})
+	value := 0.0
+	if compatible {
+		value = 1
+	}
+	serverCompatible.WithLabelValues(pc.GetName(), v).Set(value)
+
+	switch current := pc.Status.GetCondition(typeIncompatibleVersion).Status; {
+	case !compatible && current != corev1.ConditionTrue:
+		c := incompatibleVersion(v)
+		r.record.Event(pc, event.Warning(reasonIncompatibleVersion, errors.New(c.Message)))
+		pc.Status.SetConditions(c)
+	case compatible && current == corev1.ConditionTrue:
+		pc.Status.SetConditions(compatibleVersion())
+	}
+}
+
+// accountUsages counts the resources using the supplied ProviderConfig by
+// kind. It deletes usages whose resource no longer exists, which the garbage
+// collector would otherwise only remove eventually, if at all.
+func (r *reconciler) accountUsages(ctx context.Context, pc *apisv1alpha1.ProviderConfig) ([]apisv1alpha1.KindUsage, error) {
+	l := &apisv1alpha1.ProviderConfigUsageList{}
+	if err := r.kube.List(ctx, l, client.MatchingLabels{xpv1.LabelKeyProviderName: pc.GetName()}); err != nil {
+		return nil, errors.Wrap(err, errListPCUs)
+	}
+
+	counts := map[string]int64{}
+	for i := range l.Items {
+		pcu := &l.Items[i]
+		gone, err := r.userGone(ctx, pcu)
+		if err != nil {
+			return nil, err
+		}
+		if gone {
+			if err := r.kube.Delete(ctx, pcu); resource.IgnoreNotFound(err) != nil {
+				return nil, errors.Wrap(err, errDeletePCU)
+			}
+			continue
+		}
+		counts[pcu.ResourceReference.Kind]++
+	}
+
+	usages := make([]apisv1alpha1.KindUsage, 0, len(counts))
+	for kind, count := range counts {
+		usages = append(usages, apisv1alpha1.KindUsage{Kind: kind, Count: count})
+	}
+	slices.SortFunc(usages, func(a, b apisv1alpha1.KindUsage) int { return strings.Compare(a.Kind, b.Kind) })
+	if len(usages) == 0 {
+		return nil, nil
+	}
+	return usages, nil
+}
+
+// userGone returns whether the resource recorded by the supplied usage no
+// longer exists. Usages are named after the UID of their resource, so one
+// recreated with the same name does not count.
+func (r *reconciler) userGone(ctx context.Context, pcu *apisv1alpha1.ProviderConfigUsage) (bool, error) {
+	ref := pcu.ResourceReference
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false, nil
+	}
+	obj, err := r.kube.Scheme().New(gv.WithKind(ref.Kind))
+	if err != nil {
+		// Not a kind of this provider, leave it to the garbage collector.
+		return false, nil
+	}
+	mg, ok := obj.(client.Object)
+	if !ok {
+		return false, nil
+	}
+
+	if err := r.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, mg); err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, errors.Wrap(err, errGetUser)
+	}
+	return string(mg.GetUID()) != pcu.GetName(), nil
+}
+
+// checkHealth checks the Pocket ID server is healthy, calls its API with the
+// credentials of the supplied ProviderConfig, ensures they belong to an admin,
+// detects the version of the server and ensures it serves an OpenID Connect
+// issuer.
+func (r *reconciler) checkHealth(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (health, error) {
+	data, err := clients.ExtractCredentials(ctx, r.kube, pc)
+	if err != nil {
+		return health{}, errors.Wrap(err, errGetCreds)
+	}
+
+	cfg, err := clients.NewConfig(ctx, r.kube, pc, data)
+	if err != nil {
+		return health{}, errors.Wrap(err, errNewClient)
+	}
+
+	svc, err := r.newServiceFn(cfg)
+	if err != nil {
+		return health{}, errors.Wrap(err, errNewClient)
+	}
+	ctx = pocketid.WithRequestSource(ctx, apisv1alpha1.ProviderConfigKind+"/"+pc.GetName())
+
+	if err := svc.Healthz(ctx); err != nil {
+		return health{}, errors.Wrap(err, errHealthCheck)
+	}
+
+	user, err := svc.GetCurrentUser(ctx)
+	if err != nil {
+		return health{}, errors.Wrap(err, errHealthCheck)
+	}
+	if !user.IsAdmin {
+		return health{}, pocketid.ErrNotAdmin
+	}
+
+	v, err := svc.GetVersion(ctx)
+	if err != nil {
+		return health{}, errors.Wrap(err, errHealthCheck)
+	}
+
+	if _, err := svc.GetOpenIDConfiguration(ctx); err != nil {
+		return health{}, errors.Wrap(err, errHealthCheck)
+	}
+	return health{previousAPIKey: svc.UsingPreviousAPIKey(), version: v}, nil
+}
+
+// insecureTLS returns a condition indicating that TLS verification is
+// disabled.
+func insecureTLS() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typeInsecureTLS,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "CertificateVerificationDisabled",
+		Message:            "TLS certificate verification of the Pocket ID server is disabled; do not use outside lab environments",
+	}
+}
+
+// secureTLS returns a condition indicating that TLS verification is enabled.
+func secureTLS() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typeInsecureTLS,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "CertificateVerificationEnabled",
+	}
+}
+
+// healthy returns a condition indicating that the Pocket ID API is reachable
+// with the credentials of the ProviderConfig.
+func healthy() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typeHealthy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Healthy",
+	}
+}
+
+// unhealthy returns a condition indicating that the Pocket ID API cannot be
+// reached with the credentials of the ProviderConfig.
+func unhealthy(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typeHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Unhealthy",
+		Message:            err.Error(),
+	}
+}
+
+// notAdmin returns a condition indicating that the API key of the
+// ProviderConfig cannot manage Pocket ID because it lacks admin rights.
+func notAdmin() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typeHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "InsufficientPermissions",
+		Message:            pocketid.ErrNotAdmin.Error(),
+	}
+}
+
+// previousAPIKeyInUse returns a condition indicating that only the previous
+// API key of the ProviderConfig is accepted.
+func previousAPIKeyInUse() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typePreviousAPIKeyInUse,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "CurrentAPIKeyRejected",
+		Message:            "The current API key is rejected by Pocket ID; requests are authenticated with the previous API key",
+	}
+}
+
+// currentAPIKeyInUse returns a condition indicating that the current API key
+// of the ProviderConfig is accepted.
+func currentAPIKeyInUse() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typePreviousAPIKeyInUse,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "CurrentAPIKeyAccepted",
+	}
+}
+
+// incompatibleVersion returns a condition indicating that the Pocket ID server
+// is of a major version the provider is not built for.
+func incompatibleVersion(v string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typeIncompatibleVersion,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "UnsupportedMajorVersion",
+		Message:            fmt.Sprintf("Pocket ID %s is not supported by this provider, which is built for major version %d; requests may fail", v, pocketid.SupportedMajorVersion),
+	}
+}
+
+// compatibleVersion returns a condition indicating that the Pocket ID server
+// is of a major version the provider is built for.
+func compatibleVersion() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               typeIncompatibleVersion,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "SupportedMajorVersion",
+	}
+}
@@ -0,0 +1,520 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+)
+
+var errBoom = errors.New("boom")
+
+type fakeHealthChecker struct {
+	user      *pocketid.User
+	previous  bool
+	version   string
+	notIssuer bool
+	down      bool
+	err       error
+}
+
+func (f *fakeHealthChecker) Healthz(context.Context) error {
+	if f.down {
+		return errBoom
+	}
+	return nil
+}
+
+func (f *fakeHealthChecker) GetCurrentUser(context.Context) (*pocketid.User, error) {
+	return f.user, f.err
+}
+
+func (f *fakeHealthChecker) GetVersion(context.Context) (string, error) {
+	return f.version, nil
+}
+
+func (f *fakeHealthChecker) GetOpenIDConfiguration(context.Context) (*pocketid.OpenIDConfiguration, error) {
+	if f.notIssuer {
+		return nil, pocketid.ErrNotIssuer
+	}
+	return &pocketid.OpenIDConfiguration{Issuer: "https://id.example.com"}, nil
+}
+
+func (f *fakeHealthChecker) UsingPreviousAPIKey() bool {
+	return f.previous
+}
+
+func withService(err error) func(pocketid.Config) (healthChecker, error) {
+	return func(pocketid.Config) (healthChecker, error) {
+		return &fakeHealthChecker{user: &pocketid.User{IsAdmin: true}, err: err}, nil
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	usage := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	})
+
+	withTLS := func(tls *apisv1alpha1.TLSConfig, conditions ...xpv1.Condition) test.ObjectFn {
+		return func(obj client.Object) error {
+			pc := obj.(*apisv1alpha1.ProviderConfig)
+			pc.Spec.TLS = tls
+			pc.Spec.Credentials.Source = xpv1.CredentialsSourceEnvironment
+			pc.Spec.Credentials.Env = &xpv1.EnvSelector{Name: "POCKET_ID_API_KEY"}
+			pc.Status.SetConditions(conditions...)
+			return nil
+		}
+	}
+
+	type want struct {
+		status  corev1.ConditionStatus
+		healthy corev1.ConditionStatus
+		reason  xpv1.ConditionReason
+		// previous is the PreviousAPIKeyInUse status, Unknown if unset.
+		previous corev1.ConditionStatus
+		// incompatible is the IncompatibleVersion status, Unknown if unset.
+		incompatible corev1.ConditionStatus
+		// credentials is the CredentialsValid status, not checked if unset.
+		credentials corev1.ConditionStatus
+		version     string
+		// synced is the Synced reason, not checked if unset.
+		synced xpv1.ConditionReason
+		result reconcile.Result
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		usage   reconcile.Reconciler
+		get     test.ObjectFn
+		service func(pocketid.Config) (healthChecker, error)
+		want    want
+	}{
+		"Deleted": {
+			reason: "A deleted ProviderConfig should be ignored once the rate limiters of its clients are dropped.",
+			usage:  usage,
+			get: func(client.Object) error {
+				return kerrors.NewNotFound(schema.GroupResource{}, "default")
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionUnknown},
+		},
+		"InsecureSkipTLSVerify": {
+			reason: "A ProviderConfig that disables TLS verification should report the InsecureTLS condition.",
+			usage:  usage,
+			get:    withTLS(&apisv1alpha1.TLSConfig{InsecureSkipTLSVerify: true}, healthy()),
+			want:   want{status: corev1.ConditionTrue, healthy: corev1.ConditionTrue, reason: "Healthy", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"VerificationReenabled": {
+			reason: "A ProviderConfig that enables TLS verification again should clear the InsecureTLS condition.",
+			usage:  usage,
+			get:    withTLS(nil, insecureTLS(), healthy()),
+			want:   want{status: corev1.ConditionFalse, healthy: corev1.ConditionTrue, reason: "Healthy", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"Secure": {
+			reason: "A healthy ProviderConfig that never disabled TLS verification should not be updated.",
+			usage:  usage,
+			get:    withTLS(nil, healthy()),
+			want:   want{status: corev1.ConditionUnknown, healthy: corev1.ConditionUnknown, result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"Healthy": {
+			reason: "A ProviderConfig whose API is reachable should report the Healthy condition.",
+			usage:  usage,
+			get:    withTLS(nil),
+			want:   want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"Unhealthy": {
+			reason:  "A ProviderConfig whose API cannot be reached should report it is unhealthy.",
+			usage:   usage,
+			get:     withTLS(nil, healthy()),
+			service: withService(errBoom),
+			want:    want{status: corev1.ConditionUnknown, healthy: corev1.ConditionFalse, reason: "Unhealthy", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"Unauthorized": {
+			reason:  "A ProviderConfig whose credentials are rejected should report it is unauthorized.",
+			usage:   usage,
+			get:     withTLS(nil, healthy()),
+			service: withService(&pocketid.APIError{StatusCode: http.StatusUnauthorized}),
+			want:    want{status: corev1.ConditionUnknown, healthy: corev1.ConditionFalse, reason: "Unauthorized", credentials: corev1.ConditionFalse, result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"CredentialsAccepted": {
+			reason: "A ProviderConfig whose credentials are accepted again should report them valid.",
+			usage:  usage,
+			get:    withTLS(nil, healthy(), conditions.CredentialsInvalid(errBoom)),
+			want:   want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", credentials: corev1.ConditionTrue, result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"NotAdmin": {
+			reason: "A ProviderConfig whose API key lacks admin rights should report it is unhealthy.",
+			usage:  usage,
+			get:    withTLS(nil, healthy()),
+			service: func(pocketid.Config) (healthChecker, error) {
+				return &fakeHealthChecker{user: &pocketid.User{}}, nil
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionFalse, reason: "InsufficientPermissions", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"ServerDown": {
+			reason: "A ProviderConfig whose server is unhealthy should report it is unhealthy.",
+			usage:  usage,
+			get:    withTLS(nil, healthy()),
+			service: func(pocketid.Config) (healthChecker, error) {
+				return &fakeHealthChecker{user: &pocketid.User{IsAdmin: true}, down: true}, nil
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionFalse, reason: "Unhealthy", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"NotAnIssuer": {
+			reason: "A ProviderConfig whose endpoint does not serve an OpenID Connect issuer should report it is unhealthy.",
+			usage:  usage,
+			get:    withTLS(nil, healthy()),
+			service: func(pocketid.Config) (healthChecker, error) {
+				return &fakeHealthChecker{user: &pocketid.User{IsAdmin: true}, notIssuer: true}, nil
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionFalse, reason: "NotAnIssuer", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"PreviousAPIKeyInUse": {
+			reason: "A ProviderConfig whose previous API key is used should report the PreviousAPIKeyInUse condition.",
+			usage:  usage,
+			get:    withTLS(nil, healthy()),
+			service: func(pocketid.Config) (healthChecker, error) {
+				return &fakeHealthChecker{user: &pocketid.User{IsAdmin: true}, previous: true}, nil
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", previous: corev1.ConditionTrue, result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"RotationCompleted": {
+			reason: "A ProviderConfig whose current API key is accepted again should clear the PreviousAPIKeyInUse condition.",
+			usage:  usage,
+			get:    withTLS(nil, healthy(), previousAPIKeyInUse()),
+			want:   want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", previous: corev1.ConditionFalse, result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"ServerVersion": {
+			reason: "The version of the Pocket ID server should be recorded.",
+			usage:  usage,
+			get:    withTLS(nil, healthy()),
+			service: func(pocketid.Config) (healthChecker, error) {
+				return &fakeHealthChecker{user: &pocketid.User{IsAdmin: true}, version: "1.2.3"}, nil
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", version: "1.2.3", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"IncompatibleVersion": {
+			reason: "A ProviderConfig whose server is of an unsupported major version should report it.",
+			usage:  usage,
+			get:    withTLS(nil, healthy()),
+			service: func(pocketid.Config) (healthChecker, error) {
+				return &fakeHealthChecker{user: &pocketid.User{IsAdmin: true}, version: "2.0.0"}, nil
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", incompatible: corev1.ConditionTrue, version: "2.0.0", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"CompatibleVersion": {
+			reason: "A ProviderConfig whose server is downgraded to a supported major version should clear the IncompatibleVersion condition.",
+			usage:  usage,
+			get:    withTLS(nil, healthy(), incompatibleVersion("2.0.0")),
+			service: func(pocketid.Config) (healthChecker, error) {
+				return &fakeHealthChecker{user: &pocketid.User{IsAdmin: true}, version: "1.2.3"}, nil
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", incompatible: corev1.ConditionFalse, version: "1.2.3", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"SecretNotFound": {
+			reason: "A ProviderConfig whose credentials secret does not exist should report it precisely.",
+			usage:  usage,
+			get: func(obj client.Object) error {
+				if _, ok := obj.(*corev1.Secret); ok {
+					return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "pocketid")
+				}
+				pc := obj.(*apisv1alpha1.ProviderConfig)
+				pc.Spec.Credentials = apisv1alpha1.ProviderCredentials{
+					Source: xpv1.CredentialsSourceSecret,
+					CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "pocketid", Namespace: "crossplane-system"},
+						Key:             "apiKey",
+					}},
+				}
+				return nil
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionFalse, reason: clients.ReasonSecretNotFound, result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"NewClientError": {
+			reason: "A ProviderConfig whose client cannot be created should report it is unhealthy.",
+			usage:  usage,
+			get:    withTLS(nil, healthy()),
+			service: func(pocketid.Config) (healthChecker, error) {
+				return nil, errBoom
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionFalse, reason: "Unhealthy", result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"Paused": {
+			reason: "A paused ProviderConfig should report it is paused without its health being checked.",
+			usage:  usage,
+			get: func(obj client.Object) error {
+				_ = withTLS(nil, healthy())(obj)
+				meta.AddAnnotations(obj, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+				return nil
+			},
+			service: func(pocketid.Config) (healthChecker, error) {
+				return nil, errBoom
+			},
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", synced: xpv1.ReasonReconcilePaused},
+		},
+		"Resumed": {
+			reason: "A ProviderConfig no longer paused should report it is synced again.",
+			usage:  usage,
+			get:    withTLS(nil, healthy(), xpv1.ReconcilePaused()),
+			want:   want{status: corev1.ConditionUnknown, healthy: corev1.ConditionTrue, reason: "Healthy", synced: xpv1.ReasonReconcileSuccess, result: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"UsageRequeue": {
+			reason: "The requeue requested when accounting for ProviderConfig usage should be kept.",
+			usage: reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+			}),
+			get:  withTLS(nil, healthy()),
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionUnknown, result: reconcile.Result{RequeueAfter: 30 * time.Second}},
+		},
+		"UsageError": {
+			reason: "Errors accounting for ProviderConfig usage should be returned.",
+			usage: reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, errBoom
+			}),
+			get:  withTLS(&apisv1alpha1.TLSConfig{InsecureSkipTLSVerify: true}),
+			want: want{status: corev1.ConditionUnknown, healthy: corev1.ConditionUnknown, err: errBoom},
+		},
+	}
+
+	t.Setenv("POCKET_ID_API_KEY", "key")
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.service == nil {
+				tc.service = withService(nil)
+			}
+			got, gotHealthy := corev1.ConditionUnknown, corev1.ConditionUnknown
+			gotPrevious := corev1.ConditionUnknown
+			if tc.want.previous == "" {
+				tc.want.previous = corev1.ConditionUnknown
+			}
+			gotIncompatible := corev1.ConditionUnknown
+			if tc.want.incompatible == "" {
+				tc.want.incompatible = corev1.ConditionUnknown
+			}
+			gotCredentials := corev1.ConditionUnknown
+			var gotReason xpv1.ConditionReason
+			var gotVersion string
+			var gotSynced xpv1.ConditionReason
+			r := &reconciler{
+				usage: tc.usage,
+				kube: &test.MockClient{
+					MockGet:  test.NewMockGetFn(nil, tc.get),
+					MockList: test.NewMockListFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+						pc := obj.(*apisv1alpha1.ProviderConfig)
+						got = pc.Status.GetCondition(typeInsecureTLS).Status
+						gotHealthy = pc.Status.GetCondition(typeHealthy).Status
+						gotReason = pc.Status.GetCondition(typeHealthy).Reason
+						gotPrevious = pc.Status.GetCondition(typePreviousAPIKeyInUse).Status
+						gotIncompatible = pc.Status.GetCondition(typeIncompatibleVersion).Status
+						gotCredentials = pc.Status.GetCondition(conditions.TypeCredentialsValid).Status
+						gotVersion = pc.Status.ServerVersion
+						gotSynced = pc.Status.GetCondition(xpv1.TypeSynced).Reason
+						return nil
+					}),
+				},
+				record:       event.NewNopRecorder(),
+				newServiceFn: tc.service,
+				pollInterval: time.Minute,
+			}
+
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want InsecureTLS status, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.healthy, gotHealthy); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Healthy status, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, gotReason); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Healthy reason, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.previous, gotPrevious); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want PreviousAPIKeyInUse status, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.incompatible, gotIncompatible); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want IncompatibleVersion status, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.credentials, gotCredentials); tc.want.credentials != "" && diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want CredentialsValid status, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.version, gotVersion); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want server version, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.synced, gotSynced); tc.want.synced != "" && diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Synced reason, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, result); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileDeleted(t *testing.T) {
+	serverCompatible.WithLabelValues("deleted", "1.2.3").Set(1)
+	serverCompatible.WithLabelValues("other", "1.2.3").Set(1)
+	defer serverCompatible.DeletePartialMatch(prometheus.Labels{"providerconfig": "other"})
+
+	r := &reconciler{
+		usage: reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, nil
+		}),
+		kube: &test.MockClient{
+			MockGet:  test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "deleted")),
+			MockList: test.NewMockListFn(nil),
+		},
+		record: event.NewNopRecorder(),
+	}
+
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "deleted"}}); err != nil {
+		t.Fatalf("r.Reconcile(...): unexpected error: %v", err)
+	}
+	if serverCompatible.DeleteLabelValues("deleted", "1.2.3") {
+		t.Errorf("r.Reconcile(...): want the server compatibility of a deleted ProviderConfig to no longer be reported")
+	}
+	if !serverCompatible.DeleteLabelValues("other", "1.2.3") {
+		t.Errorf("r.Reconcile(...): want the server compatibility of other ProviderConfigs to still be reported")
+	}
+}
+
+func TestAccountUsages(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apisv1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	usage := func(uid, kind, name string) apisv1alpha1.ProviderConfigUsage {
+		pcu := apisv1alpha1.ProviderConfigUsage{}
+		pcu.SetName(uid)
+		pcu.ResourceReference = xpv1.TypedReference{APIVersion: apisv1alpha1.SchemeGroupVersion.String(), Kind: kind, Name: name}
+		return pcu
+	}
+	list := test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		obj.(*apisv1alpha1.ProviderConfigUsageList).Items = []apisv1alpha1.ProviderConfigUsage{
+			usage("uid-a", apisv1alpha1.GroupKind, "a"),
+			usage("uid-b", apisv1alpha1.GroupKind, "b"),
+			usage("uid-c", apisv1alpha1.UserKind, "c"),
+		}
+		return nil
+	})
+
+	type want struct {
+		usages  []apisv1alpha1.KindUsage
+		deleted []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		list   test.MockListFn
+		want   want
+	}{
+		"NoUsages": {
+			reason: "A ProviderConfig nothing uses should report no usages.",
+			list:   test.NewMockListFn(nil),
+		},
+		"CountByKind": {
+			reason: "Usages should be counted by kind.",
+			get: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				obj.SetUID(types.UID("uid-" + key.Name))
+				return nil
+			},
+			list: list,
+			want: want{usages: []apisv1alpha1.KindUsage{{Kind: apisv1alpha1.GroupKind, Count: 2}, {Kind: apisv1alpha1.UserKind, Count: 1}}},
+		},
+		"StaleUsages": {
+			reason: "Usages of deleted or recreated resources should be deleted and not counted.",
+			get: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				switch key.Name {
+				case "a":
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				case "b":
+					obj.SetUID("recreated")
+				default:
+					obj.SetUID(types.UID("uid-" + key.Name))
+				}
+				return nil
+			},
+			list: list,
+			want: want{
+				usages:  []apisv1alpha1.KindUsage{{Kind: apisv1alpha1.UserKind, Count: 1}},
+				deleted: []string{"uid-a", "uid-b"},
+			},
+		},
+		"ListError": {
+			reason: "Errors listing usages should be returned.",
+			list:   test.NewMockListFn(errBoom),
+			want:   want{err: errors.Wrap(errBoom, errListPCUs)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			r := &reconciler{kube: &test.MockClient{
+				MockGet:    tc.get,
+				MockList:   tc.list,
+				MockScheme: test.NewMockSchemeFn(scheme),
+				MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+					deleted = append(deleted, obj.GetName())
+					return nil
+				},
+			}}
+
+			pc := &apisv1alpha1.ProviderConfig{}
+			pc.SetName("default")
+			got, err := r.accountUsages(context.Background(), pc)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.accountUsages(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.usages, got); diff != "" {
+				t.Errorf("\n%s\nr.accountUsages(...): -want usages, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nr.accountUsages(...): -want deleted usages, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
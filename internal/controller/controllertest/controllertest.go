@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controllertest is the harness of the unit tests of the managed
+// resource controllers. It serves the Pocket ID API from memory, along with
+// the Kubernetes objects the controllers read, so that their Observe, Create,
+// Update and Delete methods are tested against the Service they use in
+// production.
+package controllertest
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+)
+
+// A Seed adds the objects a test starts from to a fake Pocket ID API, or
+// makes its requests fail.
+type Seed func(s *pocketidfake.Server)
+
+// NewService starts a fake Pocket ID API seeded by the supplied functions,
+// returning it along with a Service using it. The API is closed once the test
+// completes.
+func NewService(t *testing.T, seeds ...Seed) (*pocketidfake.Server, pocketid.Service) {
+	t.Helper()
+	srv := pocketidfake.NewServer()
+	t.Cleanup(srv.Close)
+	for _, seed := range seeds {
+		seed(srv)
+	}
+	svc, err := pocketid.NewClientFromConfig(srv.Config())
+	if err != nil {
+		t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+	}
+	return srv, svc
+}
+
+// Fail returns a seed making every request with the supplied method whose
+// path starts with the supplied prefix fail with the supplied status.
+func Fail(method, path string, status int) Seed {
+	return func(s *pocketidfake.Server) {
+		s.Fail(method, path, status, 0)
+	}
+}
+
+// APIError returns the error returned by the Service for requests failed by
+// Fail with the supplied status.
+func APIError(status int) error {
+	return &pocketid.APIError{StatusCode: status, Message: http.StatusText(status)}
+}
+
+// NewKube returns a Kubernetes client getting the supplied objects, by type
+// and name, and answering that any other object does not exist. It lists no
+// objects.
+func NewKube(objs ...client.Object) *test.MockClient {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			for _, o := range objs {
+				if reflect.TypeOf(o) == reflect.TypeOf(obj) && o.GetName() == key.Name && o.GetNamespace() == key.Namespace {
+					reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(o.DeepCopyObject()).Elem())
+					return nil
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		},
+		MockList: test.NewMockListFn(nil),
+	}
+}
+
+// Mutations returns the methods of the supplied requests that change Pocket
+// ID objects.
+func Mutations(requests []string) []string {
+	var methods []string
+	for _, r := range requests {
+		if m, _, _ := strings.Cut(r, " "); m != http.MethodGet {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
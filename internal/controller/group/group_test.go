@@ -18,14 +18,30 @@ package group
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/apis"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+	"github.com/crossplane/provider-pocketid/internal/controller/controllertest"
+	"github.com/crossplane/provider-pocketid/internal/inuse"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,40 +52,424 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type fields struct {
-		service *pocketid.Client
+// group returns a Group managing the admins group, modified by the supplied
+// functions.
+func group(fns ...func(cr *apisv1beta1.Group)) *apisv1beta1.Group {
+	cr := &apisv1beta1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "admins", UID: "0b3e5a1c"},
+		Spec:       apisv1beta1.GroupSpec{ForProvider: apisv1beta1.GroupParameters{Name: "admins", FriendlyName: "Admins"}},
 	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+	for _, fn := range fns {
+		fn(cr)
 	}
+	return cr
+}
+
+// withID sets the ID of the Pocket ID group of a Group, as observed.
+func withID(id string) func(cr *apisv1beta1.Group) {
+	return func(cr *apisv1beta1.Group) { cr.Status.AtProvider.ID = id }
+}
+
+// seedGroup returns a seed adding the supplied Pocket ID group.
+func seedGroup(g pocketid.Group) controllertest.Seed {
+	return func(s *pocketidfake.Server) { s.AddGroup(g) }
+}
+
+// admins is the Pocket ID group managed by group().
+var admins = pocketid.Group{ID: "admins-id", GroupName: "admins", FriendlyName: "Admins"}
+
+func TestObserve(t *testing.T) {
+	drifted := admins
+	drifted.FriendlyName = "Administrators"
 
 	type want struct {
 		o   managed.ExternalObservation
+		id  string
 		err error
 	}
 
 	cases := map[string]struct {
 		reason string
-		fields fields
-		args   args
+		seeds  []controllertest.Seed
+		mg     resource.Managed
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotFound": {
+			reason: "A group missing from Pocket ID should be reported as not existing.",
+			mg:     group(),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"UpToDate": {
+			reason: "A group matching its spec should be reported as up to date, along with its ID.",
+			seeds:  []controllertest.Seed{seedGroup(admins)},
+			mg:     group(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, id: "admins-id"},
+		},
+		"Drifted": {
+			reason: "A group changed in Pocket ID should be reported as needing an update.",
+			seeds:  []controllertest.Seed{seedGroup(drifted)},
+			mg:     group(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}, id: "admins-id"},
+		},
+		"ClaimsDrifted": {
+			reason: "A group whose custom claims changed in Pocket ID should be reported as needing an update.",
+			seeds: []controllertest.Seed{seedGroup(pocketid.Group{
+				ID: "admins-id", GroupName: "admins", FriendlyName: "Admins", CustomClaims: map[string]string{"team": "ops"},
+			})},
+			mg:   group(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true}, id: "admins-id"},
+		},
+		"APIError": {
+			reason: "Errors getting the group should be returned.",
+			seeds:  []controllertest.Seed{controllertest.Fail(http.MethodGet, "/api/groups", http.StatusInternalServerError)},
+			mg:     group(),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to get group")},
+		},
+		"NotGroup": {
+			reason: "Managed resources other than Groups should be rejected.",
+			mg:     &fake.Managed{},
+			want:   want{err: errors.New(errNotGroup)},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube()}
+			got, err := e.Observe(context.Background(), tc.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if cr, ok := tc.mg.(*apisv1beta1.Group); ok {
+				if diff := cmp.Diff(tc.want.id, cr.Status.AtProvider.ID); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want ID, +got:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		want   error
+	}{
+		"Created": {
+			reason: "A group should be created.",
+		},
+		"APIError": {
+			reason: "Errors creating the group should be returned.",
+			seeds:  []controllertest.Seed{controllertest.Fail(http.MethodPost, "/api/groups", http.StatusInternalServerError)},
+			want:   errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to create group"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube()}
+			_, err := e.Create(context.Background(), group())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	drifted := admins
+	drifted.FriendlyName = "Administrators"
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		mg     *apisv1beta1.Group
+		want   error
+	}{
+		"Updated": {
+			reason: "A drifted group should be updated to match its spec.",
+			seeds:  []controllertest.Seed{seedGroup(drifted)},
+			mg:     group(withID("admins-id")),
+		},
+		"NoID": {
+			reason: "A group whose ID was never observed cannot be updated.",
+			mg:     group(),
+			want:   errors.New("group ID not found in status"),
+		},
+		"APIError": {
+			reason: "Errors updating the group should be returned.",
+			seeds:  []controllertest.Seed{seedGroup(drifted), controllertest.Fail(http.MethodPut, "/api/groups", http.StatusInternalServerError)},
+			mg:     group(withID("admins-id")),
+			want:   errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to update group"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube()}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if g, _ := srv.Group("admins-id"); !isGroupUpToDate(tc.mg.Spec.ForProvider, g, clients.Owner(tc.mg, g.CustomClaims)) {
+				t.Errorf("\n%s\ne.Update(...): want the group up to date, got %+v", tc.reason, g)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		kube   client.Client
+		mg     *apisv1beta1.Group
+		want   want
+	}{
+		"Deleted": {
+			reason: "A group should be deleted from Pocket ID.",
+			seeds:  []controllertest.Seed{seedGroup(admins)},
+			mg:     group(withID("admins-id")),
+			want:   want{calls: []string{http.MethodDelete}},
+		},
+		"InUse": {
+			reason: "A group still allowed to an OIDC client should not be deleted.",
+			seeds:  []controllertest.Seed{seedGroup(admins)},
+			kube: &test.MockClient{MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+				if l, ok := obj.(*apisv1beta1.OIDCClientGroupBindingList); ok {
+					l.Items = []apisv1beta1.OIDCClientGroupBinding{{
+						ObjectMeta: metav1.ObjectMeta{Name: "app-admins"},
+						Spec: apisv1beta1.OIDCClientGroupBindingSpec{
+							ForProvider: apisv1beta1.OIDCClientGroupBindingParameters{GroupIDRef: &xpv1.Reference{Name: "admins"}},
+						},
+					}}
+				}
+				return nil
+			})},
+			mg:   group(withID("admins-id")),
+			want: want{err: inuse.NewInUseError("group", []string{"OIDCClientGroupBinding/app-admins"})},
+		},
+		"APIError": {
+			reason: "Errors deleting the group should be returned.",
+			seeds:  []controllertest.Seed{seedGroup(admins), controllertest.Fail(http.MethodDelete, "/api/groups", http.StatusInternalServerError)},
+			mg:     group(withID("admins-id")),
+			want:   want{calls: []string{http.MethodDelete}, err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to delete group")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			if tc.kube == nil {
+				tc.kube = controllertest.NewKube()
+			}
+			e := external{service: svc, kube: tc.kube}
+			_, err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	srv := pocketidfake.NewServer()
+	defer srv.Close()
+
+	svc, err := pocketid.NewClientFromConfig(srv.Config())
+	if err != nil {
+		t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+	}
+	e := external{service: svc, kube: &test.MockClient{MockList: test.NewMockListFn(nil)}}
+	ctx := context.Background()
+	cr := &apisv1beta1.Group{
+		ObjectMeta: metav1.ObjectMeta{UID: "0b3e5a1c"},
+		Spec:       apisv1beta1.GroupSpec{ForProvider: apisv1beta1.GroupParameters{Name: "admins", FriendlyName: "Admins"}},
+	}
+
+	observe := func(want managed.ExternalObservation) {
+		t.Helper()
+		got, err := e.Observe(ctx, cr)
+		if err != nil {
+			t.Fatalf("e.Observe(...): %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("e.Observe(...): -want, +got:\n%s\n", diff)
+		}
+	}
+
+	observe(managed.ExternalObservation{})
+	if _, err := e.Create(ctx, cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true})
+
+	cr.Spec.ForProvider.FriendlyName = "Administrators"
+	observe(managed.ExternalObservation{ResourceExists: true})
+	if _, err := e.Update(ctx, cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	g, _ := srv.Group(cr.Status.AtProvider.ID)
+	if g.FriendlyName != "Administrators" {
+		t.Errorf("e.Update(...): want friendly name Administrators, got %q", g.FriendlyName)
+	}
+	if owner := pocketid.Owner(g.CustomClaims); owner != "0b3e5a1c" {
+		t.Errorf("e.Update(...): want the group owned by its managed resource, got owner %q", owner)
+	}
+	observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true})
+
+	// A group adopted by a re-created managed resource is re-stamped.
+	cr.SetUID("7c41d9e2")
+	observe(managed.ExternalObservation{ResourceExists: true})
+	if _, err := e.Update(ctx, cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	g, _ = srv.Group(cr.Status.AtProvider.ID)
+	if owner := pocketid.Owner(g.CustomClaims); owner != "7c41d9e2" {
+		t.Errorf("e.Update(...): want the group owned by the managed resource adopting it, got owner %q", owner)
+	}
+	observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true})
+
+	if _, err := e.Delete(ctx, cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	observe(managed.ExternalObservation{})
+}
+
+func TestManagementPolicies(t *testing.T) {
+	observeCreate := xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate}
+	noDelete := xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionLateInitialize}
+
+	type want struct {
+		calls []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		policies xpv1.ManagementPolicies
+		exists   bool
+		drifted  bool
+		deleted  bool
+		want     want
+	}{
+		"ObserveOnlyNotCreated": {
+			reason:   "An Observe only group should never be created.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+		},
+		"ObserveOnlyNotUpdated": {
+			reason:   "An Observe only group should never be updated.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			exists:   true,
+			drifted:  true,
+		},
+		"ObserveOnlyNotDeleted": {
+			reason:   "Deleting an Observe only group should leave it in Pocket ID.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			exists:   true,
+			deleted:  true,
+		},
+		"CreateOnlyCreated": {
+			reason:   "A group with the Create policy should be created.",
+			policies: observeCreate,
+			want:     want{calls: []string{http.MethodPost}},
+		},
+		"CreateOnlyNotUpdated": {
+			reason:   "A group without the Update policy should never be updated.",
+			policies: observeCreate,
+			exists:   true,
+			drifted:  true,
+		},
+		"NoDeleteUpdated": {
+			reason:   "A group with the Update policy should be updated.",
+			policies: noDelete,
+			exists:   true,
+			drifted:  true,
+			want:     want{calls: []string{http.MethodPut}},
+		},
+		"NoDeleteNotDeleted": {
+			reason:   "Deleting a group without the Delete policy should leave it in Pocket ID.",
+			policies: noDelete,
+			exists:   true,
+			deleted:  true,
+		},
+		"FullyManagedDeleted": {
+			reason:   "Deleting a fully managed group should delete it from Pocket ID.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionAll},
+			exists:   true,
+			deleted:  true,
+			want:     want{calls: []string{http.MethodDelete}},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := pocketidfake.NewServer()
+			defer srv.Close()
+			if tc.exists {
+				friendlyName := "Admins"
+				if tc.drifted {
+					friendlyName = "Administrators"
+				}
+				srv.AddGroup(pocketid.Group{GroupName: "admins", FriendlyName: friendlyName})
+			}
+			svc, err := pocketid.NewClientFromConfig(srv.Config())
+			if err != nil {
+				t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+			}
+
+			cr := &apisv1beta1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+				Spec: apisv1beta1.GroupSpec{
+					ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: tc.policies, DeletionPolicy: xpv1.DeletionDelete},
+					ForProvider:  apisv1beta1.GroupParameters{Name: "admins", FriendlyName: "Admins"},
+				},
+			}
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			kube := test.NewMockClient()
+			kube.MockGet = test.NewMockGetFn(nil, func(obj client.Object) error {
+				cr.DeepCopyInto(obj.(*apisv1beta1.Group))
+				return nil
+			})
+
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(apisv1beta1.GroupGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					return &external{service: svc, kube: kube}, nil
+				})),
+				managed.WithManagementPolicies(),
+			)
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "admins"}}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
 		})
 	}
 }
@@ -17,14 +17,24 @@ limitations under the License.
 package oidcclient
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	kevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
@@ -37,8 +47,16 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
 
 	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/async"
+	"github.com/crossplane/provider-pocketid/internal/changelog"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
 	"github.com/crossplane/provider-pocketid/internal/features"
+	"github.com/crossplane/provider-pocketid/internal/inuse"
+	"github.com/crossplane/provider-pocketid/internal/paused"
+	"github.com/crossplane/provider-pocketid/internal/readonly"
 )
 
 const (
@@ -46,34 +64,79 @@ const (
 	errTrackPCUsage  = "cannot track ProviderConfig usage"
 	errGetPC         = "cannot get ProviderConfig"
 	errGetCreds      = "cannot get credentials"
+	errCheckInUse    = "cannot check whether the resource is in use"
+	errRestoreGroups = "cannot restore OIDC client group bindings"
+	errUnsupported   = "the Pocket ID server does not support"
+	errLogoURI       = "invalid ConfigMap logo URI, want cm://namespace/name/key"
+	errGetLogo       = "cannot get logo ConfigMap"
+	errLogoKey       = "logo ConfigMap has no key"
+	errDeleteLogo    = "cannot delete logo"
+	errAddLogoQueue  = "cannot add logo upload queue"
 
 	errNewClient = "cannot create new Service"
 )
 
+// sideEffectLogo is the name of the logo uploads of OIDCClients, run in the
+// background as Pocket ID may have to download them first.
+const sideEffectLogo = "logo"
+
+// A versionedField is an OIDCClient field only supported from a given Pocket
+// ID version.
+type versionedField struct {
+	name    string
+	version *version.Version
+	set     func(p apisv1beta1.OIDCClientParameters) bool
+}
+
+// versionedFields are the OIDCClient fields not supported by every Pocket ID
+// version the provider works with.
+var versionedFields = []versionedField{
+	{name: "launchURL", version: version.MustParseGeneric("0.35.0"), set: func(p apisv1beta1.OIDCClientParameters) bool { return p.LaunchURL != "" }},
+}
+
 // newPocketIDService creates a new Pocket ID service
 var (
-	newPocketIDService = func(endpoint string, creds []byte) (interface{}, error) {
-		return pocketid.NewClientFromCredentials(endpoint, string(creds))
+	newPocketIDService = func(cfg pocketid.Config) (pocketid.Service, error) {
+		return clients.NewClient(cfg)
 	}
 )
 
 // Setup adds a controller that reconciles Client managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
-	name := managed.ControllerName(apisv1alpha1.OIDCClientGroupKind)
+	name := managed.ControllerName(apisv1beta1.OIDCClientGroupKind)
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	// Clients are reconciled whenever an upload of their logo completes, to
+	// report whether it succeeded. The logos of paused clients are not
+	// uploaded until they are resumed.
+	uploaded := make(chan kevent.GenericEvent)
+	logos := async.NewQueue(
+		async.WithEvents(uploaded),
+		async.WithPauseCheck(paused.Check(mgr.GetClient())),
+		async.WithLogger(o.Logger.WithValues("controller", name, "sideEffect", sideEffectLogo)),
+	)
+	if err := mgr.Add(logos); err != nil {
+		return errors.Wrap(err, errAddLogoQueue)
+	}
+
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: newPocketIDService}),
+			newServiceFn: newPocketIDService,
+			recorder:     recorder,
+			logos:        logos}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(clients.PollIntervalHook(mgr.GetClient())),
+		managed.WithCriticalAnnotationUpdater(clients.NewCriticalAnnotationUpdater(mgr.GetClient())),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...),
 		managed.WithManagementPolicies(),
 	}
@@ -88,21 +151,23 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
 		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
-			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &apisv1alpha1.OIDCClientList{}, o.MetricOptions.PollStateMetricInterval,
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &apisv1beta1.OIDCClientList{}, o.MetricOptions.PollStateMetricInterval,
 		)
 		if err := mgr.Add(stateMetricsRecorder); err != nil {
-			return errors.Wrap(err, "cannot register MR state metrics recorder for kind apisv1alpha1.OIDCClientList")
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind apisv1beta1.OIDCClientList")
 		}
 	}
 
-	r := managed.NewReconciler(mgr, resource.ManagedKind(apisv1alpha1.OIDCClientGroupVersionKind), opts...)
+	r := managed.NewReconciler(mgr, resource.ManagedKind(apisv1beta1.OIDCClientGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
-		For(&apisv1alpha1.OIDCClient{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(&apisv1beta1.OIDCClient{}).
+		Watches(&apisv1beta1.OIDCClientGroupBinding{}, handler.EnqueueRequestsFromMapFunc(inuse.ReleasedOIDCClients(mgr.GetClient())), builder.WithPredicates(inuse.BindingGone())).
+		WatchesRawSource(source.Channel(uploaded, &handler.EnqueueRequestForObject{})).
+		Complete(ratelimiter.NewReconciler(name, clients.HonorRetryAfter(apisv1beta1.OIDCClientKind, r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -110,7 +175,9 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(endpoint string, creds []byte) (interface{}, error)
+	newServiceFn func(cfg pocketid.Config) (pocketid.Service, error)
+	recorder     event.Recorder
+	logos        *async.Queue
 }
 
 // Connect typically produces an ExternalClient by:
@@ -119,7 +186,7 @@ type connector struct {
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClient)
+	cr, ok := mg.(*apisv1beta1.OIDCClient)
 	if !ok {
 		return nil, errors.New(errNotOIDCClient)
 	}
@@ -133,32 +200,51 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	data, err := clients.ExtractCredentials(ctx, c.kube, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	svc, err := c.newServiceFn(pc.Spec.Endpoint, data)
+	cfg, err := clients.NewConfig(ctx, c.kube, pc, data)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc.(*pocketid.Client)}, nil
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return readonly.Wrap(pc, clients.AttributeRequests(apisv1beta1.OIDCClientKind, &external{
+		service:       svc,
+		kube:          c.kube,
+		logos:         c.logos,
+		serverVersion: pc.Status.ServerVersion,
+	}), c.recorder), nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service *pocketid.Client
+	service pocketid.Service
+	kube    client.Client
+
+	// logos uploads the logos of clients in the background.
+	logos *async.Queue
+
+	// serverVersion of the Pocket ID server, empty if unknown.
+	serverVersion string
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClient)
+	cr, ok := mg.(*apisv1beta1.OIDCClient)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotOIDCClient)
 	}
 
+	setUnsupportedFields(cr, c.serverVersion)
+	c.setLogoSynced(cr)
+
 	// Use external-name annotation if present, otherwise use name
 	externalName := meta.GetExternalName(cr)
 	if externalName == "" {
@@ -177,7 +263,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	// Update status with observed values
-	cr.Status.AtProvider = apisv1alpha1.OIDCClientObservation{
+	cr.Status.AtProvider = apisv1beta1.OIDCClientObservation{
 		ID:                 client.ID,
 		Name:               client.ClientName,
 		CallbackURLs:       client.RedirectURIs,
@@ -193,8 +279,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		meta.SetExternalName(cr, client.ClientName)
 	}
 
-	// Check if resource is up to date
-	upToDate := isOIDCClientUpToDate(cr.Spec.ForProvider, *client)
+	// Check if resource is up to date, re-stamping the owner claim of a client
+	// created by the provider once adopted by another managed resource
+	upToDate := isOIDCClientUpToDate(cr.Spec.ForProvider, *client) &&
+		pocketid.Owner(client.CustomClaims) == clients.Owner(cr, client.CustomClaims)
 
 	cr.Status.SetConditions(xpv1.Available())
 
@@ -205,11 +293,15 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClient)
+	cr, ok := mg.(*apisv1beta1.OIDCClient)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotOIDCClient)
 	}
 
+	if fields := unsupportedFields(cr.Spec.ForProvider, c.serverVersion); len(fields) > 0 {
+		return managed.ExternalCreation{}, errors.Errorf("%s %s", errUnsupported, strings.Join(fields, ", "))
+	}
+
 	req := pocketid.CreateOIDCClientRequest{
 		ClientName:     cr.Spec.ForProvider.Name,
 		RedirectURIs:   cr.Spec.ForProvider.CallbackURLs,
@@ -217,6 +309,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		LaunchURL:      cr.Spec.ForProvider.LaunchURL,
 		IsPublic:       cr.Spec.ForProvider.IsPublic,
 		RequirePKCE:    cr.Spec.ForProvider.PkceEnabled,
+		CustomClaims:   pocketid.WithOwner(nil, clients.Creator(cr)),
 	}
 
 	client, err := c.service.CreateOIDCClient(ctx, req)
@@ -227,13 +320,9 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	// Set external name to clientName
 	meta.SetExternalName(cr, client.ClientName)
 
-	// Handle logo upload if specified
+	// Upload the logo in the background, retrying it apart from the client
 	if cr.Spec.ForProvider.LogoURL != "" {
-		//nolint:staticcheck
-		if err := c.service.UploadOIDCClientLogo(ctx, client.ID, cr.Spec.ForProvider.LogoURL); err != nil {
-			// Log the error but don't fail the creation
-			// The logo can be uploaded later during update
-		}
+		c.submitLogo(cr, client.ID)
 	}
 
 	// Return client secret as connection detail if not public
@@ -242,13 +331,21 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		connectionDetails["clientSecret"] = []byte(client.ClientSecret)
 	}
 
+	// Return the endpoints of the issuer along with it, on a best effort
+	// basis as the client was created already
+	if config, err := c.service.GetOpenIDConfiguration(ctx); err == nil {
+		for k, v := range issuerDetails(config) {
+			connectionDetails[k] = v
+		}
+	}
+
 	return managed.ExternalCreation{
 		ConnectionDetails: connectionDetails,
 	}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClient)
+	cr, ok := mg.(*apisv1beta1.OIDCClient)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotOIDCClient)
 	}
@@ -257,6 +354,14 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New("OIDC client ID not found in status")
 	}
 
+	if fields := unsupportedFields(cr.Spec.ForProvider, c.serverVersion); len(fields) > 0 {
+		return managed.ExternalUpdate{}, errors.Errorf("%s %s", errUnsupported, strings.Join(fields, ", "))
+	}
+
+	// Record what the update changes in the change log, whether it succeeds
+	// or not.
+	u := managed.ExternalUpdate{AdditionalDetails: oidcClientChanges(cr.Status.AtProvider, cr.Spec.ForProvider)}
+
 	req := pocketid.UpdateOIDCClientRequest{
 		ClientName:     cr.Spec.ForProvider.Name,
 		RedirectURIs:   cr.Spec.ForProvider.CallbackURLs,
@@ -266,45 +371,210 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		RequirePKCE:    cr.Spec.ForProvider.PkceEnabled,
 	}
 
-	_, err := c.service.UpdateOIDCClient(ctx, cr.Status.AtProvider.ID, req)
+	// Pocket ID may reset the allowed groups of a client when it is updated, so
+	// remember them to restore any group bindings the update dropped.
+	current, err := c.service.GetOIDCClient(ctx, cr.Status.AtProvider.ID)
+	if err != nil {
+		return u, errors.Wrap(err, "failed to get OIDC client")
+	}
+	// Custom claims are not managed, keep them along with the owner claim,
+	// marking the client as owned by the managed resource managing it now.
+	if current != nil {
+		req.CustomClaims = pocketid.WithOwner(current.CustomClaims, clients.Owner(cr, current.CustomClaims))
+	}
+
+	updated, err := c.service.UpdateOIDCClient(ctx, cr.Status.AtProvider.ID, req)
 	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update OIDC client")
+		return u, errors.Wrap(err, "failed to update OIDC client")
 	}
 
-	// Handle logo upload if specified and different from current state
+	if current != nil && updated != nil {
+		if err := c.restoreGroups(ctx, cr.Status.AtProvider.ID, current.GroupIDs, updated.GroupIDs); err != nil {
+			return u, errors.Wrap(err, errRestoreGroups)
+		}
+	}
+
+	// Upload the logo on update in the background, superseding any upload
+	// of a previous logo still pending. The logo is only uploaded if Pocket ID
+	// does not serve the same one already
 	if cr.Spec.ForProvider.LogoURL != "" {
-		// Always try to upload logo on update - API will handle if it's the same
-		//nolint:staticcheck
-		if err := c.service.UploadOIDCClientLogo(ctx, cr.Status.AtProvider.ID, cr.Spec.ForProvider.LogoURL); err != nil {
-			// Log the error but don't fail the update
+		c.submitLogo(cr, cr.Status.AtProvider.ID)
+	}
+
+	// Remove the logo once it is no longer specified
+	if cr.Spec.ForProvider.LogoURL == "" && cr.Status.AtProvider.HasLogo {
+		if err := c.service.DeleteOIDCClientLogo(ctx, cr.Status.AtProvider.ID); err != nil {
+			return u, errors.Wrap(err, errDeleteLogo)
+		}
+	}
+
+	return u, nil
+}
+
+// submitLogo uploads the logo of an OIDC client in the background.
+func (c *external) submitLogo(cr *apisv1beta1.OIDCClient, clientID string) {
+	logoURL := cr.Spec.ForProvider.LogoURL
+	c.logos.Submit(cr, sideEffectLogo, func(ctx context.Context) error {
+		return c.uploadLogo(ctx, clientID, logoURL)
+	})
+}
+
+// setLogoSynced reports whether the last logo submitted for the supplied
+// OIDCClient was uploaded.
+func (c *external) setLogoSynced(cr *apisv1beta1.OIDCClient) {
+	s, ok := c.logos.Status(cr, sideEffectLogo)
+	switch {
+	case !ok:
+		return
+	case !s.Pending:
+		cr.Status.SetConditions(conditions.LogoUploaded())
+	case s.Err != nil:
+		cr.Status.SetConditions(conditions.LogoUploadFailed(s.Attempts, s.Err))
+	default:
+		cr.Status.SetConditions(conditions.LogoUploading())
+	}
+}
+
+// uploadLogo uploads the logo of an OIDC client from its URL, reading the
+// logos stored in ConfigMaps, referenced as cm://namespace/name/key, itself.
+func (c *external) uploadLogo(ctx context.Context, clientID, logoURL string) error {
+	ref, ok := strings.CutPrefix(logoURL, "cm://")
+	if !ok {
+		return c.service.UploadOIDCClientLogo(ctx, clientID, logoURL)
+	}
+
+	parts := strings.Split(ref, "/")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return errors.New(errLogoURI)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, cm); err != nil {
+		return errors.Wrap(err, errGetLogo)
+	}
+	logo, ok := cm.BinaryData[parts[2]]
+	if !ok {
+		s, ok := cm.Data[parts[2]]
+		if !ok {
+			return errors.Errorf("%s %s", errLogoKey, parts[2])
 		}
+		logo = []byte(s)
 	}
 
-	return managed.ExternalUpdate{}, nil
+	return c.service.UploadOIDCClientLogoContent(ctx, clientID, bytes.NewReader(logo), "")
+}
+
+// restoreGroups re-allows the OIDC client in any of the groups with the IDs it
+// had before an update that it lost, so that bindings dropped by the update
+// are re-created immediately instead of at the next poll of the binding. All
+// groups are restored in a single call.
+func (c *external) restoreGroups(ctx context.Context, clientID string, before, after []string) error {
+	want := append(slices.Clone(after), before...)
+	slices.Sort(want)
+	want = slices.Compact(want)
+	if len(want) == len(after) {
+		return nil
+	}
+	return c.service.SetClientGroups(ctx, clientID, want)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClient)
+	cr, ok := mg.(*apisv1beta1.OIDCClient)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotOIDCClient)
 	}
 
+	// Refuse to delete the OIDC client while bindings still use it so that they are
+	// always removed first. The deletion is retried as soon as the last of them
+	// is gone.
+	users, err := inuse.UsersOfOIDCClient(ctx, c.kube, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, errors.Wrap(err, errCheckInUse)
+	}
+	if len(users) > 0 {
+		cr.SetConditions(conditions.InUse(users))
+		return managed.ExternalDelete{}, inuse.NewInUseError("OIDC client", users)
+	}
+	conditions.Clear(cr, conditions.NotInUse())
+
 	if cr.Status.AtProvider.ID != "" {
 		err := c.service.DeleteOIDCClient(ctx, cr.Status.AtProvider.ID)
 		if err != nil {
 			return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete OIDC client")
 		}
 	}
+	c.logos.Forget(cr, sideEffectLogo)
 
 	return managed.ExternalDelete{}, nil
 }
 
+// unsupportedFields returns the fields set in the supplied parameters that the
+// Pocket ID server of the supplied version does not support. Every field is
+// assumed to be supported if the version is unknown.
+func unsupportedFields(p apisv1beta1.OIDCClientParameters, serverVersion string) []string {
+	v, err := version.ParseGeneric(serverVersion)
+	if err != nil {
+		return nil
+	}
+
+	var fields []string
+	for _, f := range versionedFields {
+		if f.set(p) && !v.AtLeast(f.version) {
+			fields = append(fields, fmt.Sprintf("%s (requires %s)", f.name, f.version))
+		}
+	}
+	return fields
+}
+
+// setUnsupportedFields reports whether the supplied OIDCClient uses fields the
+// Pocket ID server does not support.
+func setUnsupportedFields(cr *apisv1beta1.OIDCClient, serverVersion string) {
+	if fields := unsupportedFields(cr.Spec.ForProvider, serverVersion); len(fields) > 0 {
+		cr.Status.SetConditions(conditions.UnsupportedField(serverVersion, fields))
+		return
+	}
+	conditions.Clear(cr, conditions.FieldsSupported())
+}
+
 func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// issuerDetails returns the connection details describing the endpoints of
+// the supplied OpenID Connect issuer, omitting those it does not serve.
+func issuerDetails(config *pocketid.OpenIDConfiguration) managed.ConnectionDetails {
+	d := managed.ConnectionDetails{}
+	for k, v := range map[string]string{
+		"issuer":                config.Issuer,
+		"authorizationEndpoint": config.AuthorizationEndpoint,
+		"tokenEndpoint":         config.TokenEndpoint,
+		"userinfoEndpoint":      config.UserinfoEndpoint,
+		"jwksUri":               config.JWKSURI,
+	} {
+		if v != "" {
+			d[k] = []byte(v)
+		}
+	}
+	return d
+}
+
+// oidcClientChanges describes the fields an update of the supplied observed
+// OIDC client to the supplied spec changes. The content of the logo is only
+// compared when it is uploaded, so only its removal is described.
+func oidcClientChanges(observed apisv1beta1.OIDCClientObservation, spec apisv1beta1.OIDCClientParameters) managed.AdditionalDetails {
+	return changelog.Details(
+		changelog.Field("name", observed.Name, spec.Name),
+		changelog.URLs("callbackURLs", observed.CallbackURLs, spec.CallbackURLs),
+		changelog.URLs("logoutCallbackURLs", observed.LogoutCallbackURLs, spec.LogoutCallbackURLs),
+		changelog.URL("launchURL", observed.LaunchURL, spec.LaunchURL),
+		changelog.Field("isPublic", observed.IsPublic, spec.IsPublic),
+		changelog.Field("pkceEnabled", observed.PkceEnabled, spec.PkceEnabled),
+		changelog.Field("hasLogo", observed.HasLogo, spec.LogoURL != ""),
+	)
+}
+
 // isOIDCClientUpToDate compares the desired spec with the actual OIDC client state
-func isOIDCClientUpToDate(spec apisv1alpha1.OIDCClientParameters, client pocketid.OIDCClient) bool {
+func isOIDCClientUpToDate(spec apisv1beta1.OIDCClientParameters, client pocketid.OIDCClient) bool {
 	if spec.Name != client.ClientName {
 		return false
 	}
@@ -326,8 +596,11 @@ func isOIDCClientUpToDate(spec apisv1alpha1.OIDCClientParameters, client pocketi
 		return false
 	}
 
-	// Logo is handled separately and doesn't affect up-to-date status
-	// since logos are uploaded asynchronously
+	// Logos are uploaded on every update rather than compared, but a logo
+	// that is no longer specified must be deleted
+	if spec.LogoURL == "" && client.HasLogo {
+		return false
+	}
 
 	return true
 }
@@ -18,14 +18,35 @@ package oidcclient
 
 import (
 	"context"
+	"io"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/apis"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/async"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+	"github.com/crossplane/provider-pocketid/internal/controller/controllertest"
+	"github.com/crossplane/provider-pocketid/internal/inuse"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,40 +57,699 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type fields struct {
-		service *pocketid.Client
+// oidcClient returns an OIDCClient managing the app client, modified by the
+// supplied functions.
+func oidcClient(fns ...func(cr *apisv1beta1.OIDCClient)) *apisv1beta1.OIDCClient {
+	cr := &apisv1beta1.OIDCClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", UID: "0b3e5a1c"},
+		Spec: apisv1beta1.OIDCClientSpec{
+			ForProvider: apisv1beta1.OIDCClientParameters{
+				Name:         "app",
+				CallbackURLs: []string{"https://app.example.com/callback"},
+				LaunchURL:    "https://app.example.com",
+			},
+		},
 	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+	for _, fn := range fns {
+		fn(cr)
 	}
+	return cr
+}
+
+// withID sets the ID of the Pocket ID client of an OIDCClient, as observed.
+func withID(id string) func(cr *apisv1beta1.OIDCClient) {
+	return func(cr *apisv1beta1.OIDCClient) { cr.Status.AtProvider.ID = id }
+}
+
+// seedClient returns a seed adding the supplied Pocket ID OIDC client.
+func seedClient(c pocketid.OIDCClient) controllertest.Seed {
+	return func(s *pocketidfake.Server) { s.AddOIDCClient(c) }
+}
+
+// app is the Pocket ID OIDC client managed by oidcClient().
+var app = pocketid.OIDCClient{
+	ID:           "app-id",
+	ClientName:   "app",
+	RedirectURIs: []string{"https://app.example.com/callback"},
+	LaunchURL:    "https://app.example.com",
+}
+
+func TestObserve(t *testing.T) {
+	drifted := app
+	drifted.LaunchURL = "https://old.example.com"
 
 	type want struct {
 		o   managed.ExternalObservation
+		id  string
 		err error
 	}
 
 	cases := map[string]struct {
 		reason string
-		fields fields
-		args   args
+		seeds  []controllertest.Seed
+		mg     resource.Managed
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotFound": {
+			reason: "A client missing from Pocket ID should be reported as not existing.",
+			mg:     oidcClient(),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"UpToDate": {
+			reason: "A client matching its spec should be reported as up to date, along with its ID.",
+			seeds:  []controllertest.Seed{seedClient(app)},
+			mg:     oidcClient(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, id: "app-id"},
+		},
+		"Drifted": {
+			reason: "A client changed in Pocket ID should be reported as needing an update.",
+			seeds:  []controllertest.Seed{seedClient(drifted)},
+			mg:     oidcClient(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}, id: "app-id"},
+		},
+		"APIError": {
+			reason: "Errors getting the client should be returned.",
+			seeds:  []controllertest.Seed{controllertest.Fail(http.MethodGet, "/api/oidc/clients", http.StatusInternalServerError)},
+			mg:     oidcClient(),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to get OIDC client")},
+		},
+		"NotOIDCClient": {
+			reason: "Managed resources other than OIDCClients should be rejected.",
+			mg:     &fake.Managed{},
+			want:   want{err: errors.New(errNotOIDCClient)},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube(), logos: async.NewQueue()}
+			got, err := e.Observe(context.Background(), tc.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if cr, ok := tc.mg.(*apisv1beta1.OIDCClient); ok {
+				if diff := cmp.Diff(tc.want.id, cr.Status.AtProvider.ID); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want ID, +got:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		secret bool
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason        string
+		seeds         []controllertest.Seed
+		serverVersion string
+		mg            *apisv1beta1.OIDCClient
+		want          want
+	}{
+		"Confidential": {
+			reason: "The secret of a confidential client should be returned as a connection detail.",
+			mg:     oidcClient(),
+			want:   want{secret: true},
+		},
+		"Public": {
+			reason: "A public client has no secret to return.",
+			mg:     oidcClient(func(cr *apisv1beta1.OIDCClient) { cr.Spec.ForProvider.IsPublic = true }),
+		},
+		"UnsupportedField": {
+			reason:        "A client using fields its server does not support should not be created.",
+			serverVersion: "0.20.1",
+			mg:            oidcClient(),
+			want:          want{err: errors.Errorf("%s %s", errUnsupported, "launchURL (requires 0.35.0)")},
+		},
+		"APIError": {
+			reason: "Errors creating the client should be returned.",
+			seeds:  []controllertest.Seed{controllertest.Fail(http.MethodPost, "/api/oidc/clients", http.StatusInternalServerError)},
+			mg:     oidcClient(),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to create OIDC client")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube(), logos: async.NewQueue(), serverVersion: tc.serverVersion}
+			got, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if _, secret := got.ConnectionDetails["clientSecret"]; secret != tc.want.secret {
+				t.Errorf("\n%s\ne.Create(...): want client secret returned %t, got %t", tc.reason, tc.want.secret, secret)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	drifted := app
+	drifted.LaunchURL = "https://old.example.com"
+
+	// allowed allows the developers group to use the client, whose groups
+	// are dropped by updates.
+	allowed := func(s *pocketidfake.Server) {
+		s.AddGroup(pocketid.Group{ID: "developers-id", GroupName: "developers"})
+		s.AddClientToGroup("app-id", "developers-id")
+		s.ResetClientGroupsOnUpdate()
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		mg     *apisv1beta1.OIDCClient
+		want   error
+		// requests are the requests expected once the client was
+		// updated, not checked if unset.
+		requests []string
+		// groups are the IDs of the groups of the client once updated.
+		groups []string
+	}{
+		"Updated": {
+			reason:   "A drifted client should be updated to match its spec.",
+			seeds:    []controllertest.Seed{seedClient(drifted)},
+			mg:       oidcClient(withID("app-id")),
+			requests: []string{"GET /api/oidc/clients/app-id", "PUT /api/oidc/clients/app-id"},
+		},
+		"GroupsRestored": {
+			reason:   "The groups an update dropped should be restored in a single request.",
+			seeds:    []controllertest.Seed{seedClient(drifted), allowed},
+			mg:       oidcClient(withID("app-id")),
+			requests: []string{"GET /api/oidc/clients/app-id", "PUT /api/oidc/clients/app-id", "PUT /api/oidc/clients/app-id/allowed-user-groups"},
+			groups:   []string{"developers-id"},
+		},
+		"NoID": {
+			reason: "A client whose ID was never observed cannot be updated.",
+			mg:     oidcClient(),
+			want:   errors.New("OIDC client ID not found in status"),
+		},
+		"GetError": {
+			reason: "Errors getting the client before updating it should be returned.",
+			seeds:  []controllertest.Seed{seedClient(drifted), controllertest.Fail(http.MethodGet, "/api/oidc/clients", http.StatusInternalServerError)},
+			mg:     oidcClient(withID("app-id")),
+			want:   errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to get OIDC client"),
+		},
+		"APIError": {
+			reason: "Errors updating the client should be returned.",
+			seeds:  []controllertest.Seed{seedClient(drifted), controllertest.Fail(http.MethodPut, "/api/oidc/clients", http.StatusInternalServerError)},
+			mg:     oidcClient(withID("app-id")),
+			want:   errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to update OIDC client"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube(), logos: async.NewQueue()}
+			before := len(srv.Requests())
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			c, _ := srv.OIDCClient("app-id")
+			if !isOIDCClientUpToDate(tc.mg.Spec.ForProvider, c) {
+				t.Errorf("\n%s\ne.Update(...): want the client up to date, got %+v", tc.reason, c)
+			}
+			if diff := cmp.Diff(tc.groups, c.GroupIDs); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want groups, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.requests, srv.Requests()[before:]); tc.requests != nil && diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want requests, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOIDCClientChanges(t *testing.T) {
+	observed := apisv1beta1.OIDCClientObservation{
+		Name:         "app",
+		CallbackURLs: []string{"https://app.example.com/callback"},
+		LaunchURL:    "https://app.example.com",
+		HasLogo:      true,
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   apisv1beta1.OIDCClientParameters
+		want   managed.AdditionalDetails
+	}{
+		"Unchanged": {
+			reason: "Nothing should be recorded for an update that changes nothing.",
+			spec: apisv1beta1.OIDCClientParameters{
+				Name:         "app",
+				CallbackURLs: []string{"https://app.example.com/callback"},
+				LaunchURL:    "https://app.example.com",
+				LogoURL:      "https://cdn.example.com/app.png",
+			},
+		},
+		"Changed": {
+			reason: "Changed fields should be recorded, the query values of URLs redacted.",
+			spec: apisv1beta1.OIDCClientParameters{
+				Name:         "app",
+				CallbackURLs: []string{"https://app.example.com/callback?tenant=acme"},
+				LaunchURL:    "https://app.example.com",
+				PkceEnabled:  true,
+			},
+			want: managed.AdditionalDetails{
+				"diff.callbackURLs": `{"before":["https://app.example.com/callback"],"after":["https://app.example.com/callback?tenant=REDACTED"]}`,
+				"diff.pkceEnabled":  `{"before":false,"after":true}`,
+				"diff.hasLogo":      `{"before":true,"after":false}`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := oidcClientChanges(observed, tc.spec)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\noidcClientChanges(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		kube   client.Client
+		mg     *apisv1beta1.OIDCClient
+		want   want
+	}{
+		"Deleted": {
+			reason: "A client should be deleted from Pocket ID.",
+			seeds:  []controllertest.Seed{seedClient(app)},
+			mg:     oidcClient(withID("app-id")),
+			want:   want{calls: []string{http.MethodDelete}},
+		},
+		"InUse": {
+			reason: "A client still bound to groups should not be deleted.",
+			seeds:  []controllertest.Seed{seedClient(app)},
+			kube: &test.MockClient{MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+				if l, ok := obj.(*apisv1beta1.OIDCClientGroupBindingList); ok {
+					l.Items = []apisv1beta1.OIDCClientGroupBinding{{
+						ObjectMeta: metav1.ObjectMeta{Name: "app-admins"},
+						Spec: apisv1beta1.OIDCClientGroupBindingSpec{
+							ForProvider: apisv1beta1.OIDCClientGroupBindingParameters{ClientIDRef: &xpv1.Reference{Name: "app"}},
+						},
+					}}
+				}
+				return nil
+			})},
+			mg:   oidcClient(withID("app-id")),
+			want: want{err: inuse.NewInUseError("OIDC client", []string{"OIDCClientGroupBinding/app-admins"})},
+		},
+		"APIError": {
+			reason: "Errors deleting the client should be returned.",
+			seeds:  []controllertest.Seed{seedClient(app), controllertest.Fail(http.MethodDelete, "/api/oidc/clients", http.StatusInternalServerError)},
+			mg:     oidcClient(withID("app-id")),
+			want:   want{calls: []string{http.MethodDelete}, err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to delete OIDC client")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			if tc.kube == nil {
+				tc.kube = controllertest.NewKube()
+			}
+			e := external{service: svc, kube: tc.kube, logos: async.NewQueue()}
+			_, err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUnsupportedFields(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		params  apisv1beta1.OIDCClientParameters
+		version string
+		want    []string
+	}{
+		"UnknownVersion": {
+			reason: "Every field should be assumed supported if the server version is unknown.",
+			params: apisv1beta1.OIDCClientParameters{LaunchURL: "https://app.example.com"},
+		},
+		"Supported": {
+			reason:  "Fields supported by the server version should not be reported.",
+			params:  apisv1beta1.OIDCClientParameters{LaunchURL: "https://app.example.com"},
+			version: "v1.0.0",
+		},
+		"Unsupported": {
+			reason:  "Fields set but not supported by the server version should be reported.",
+			params:  apisv1beta1.OIDCClientParameters{LaunchURL: "https://app.example.com"},
+			version: "0.20.1",
+			want:    []string{"launchURL (requires 0.35.0)"},
+		},
+		"Unset": {
+			reason:  "Fields not supported by the server version but unset should not be reported.",
+			version: "0.20.1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := unsupportedFields(tc.params, tc.version)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nunsupportedFields(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// logoService records the logos uploaded through it.
+type logoService struct {
+	pocketid.Service
+	url     string
+	content string
+}
+
+func (s *logoService) UploadOIDCClientLogo(_ context.Context, _, logoURL string) error {
+	s.url = logoURL
+	return nil
+}
+
+func (s *logoService) UploadOIDCClientLogoContent(_ context.Context, _ string, logo io.Reader, _ string) error {
+	b, err := io.ReadAll(logo)
+	s.content = string(b)
+	return err
+}
+
+func TestUploadLogo(t *testing.T) {
+	errBoom := errors.New("boom")
+	kube := &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Namespace != "identity" || key.Name != "logos" {
+			return errBoom
+		}
+		obj.(*corev1.ConfigMap).Data = map[string]string{"app.svg": "<svg></svg>"}
+		obj.(*corev1.ConfigMap).BinaryData = map[string][]byte{"app.png": []byte("PNG")}
+		return nil
+	}}
+
+	type want struct {
+		url     string
+		content string
+		err     error
+	}
+	cases := map[string]struct {
+		reason  string
+		logoURL string
+		want    want
+	}{
+		"URL": {
+			reason:  "Logos not stored in ConfigMaps should be uploaded from their URL.",
+			logoURL: "data:image/png;base64,iVBORw0KGgo=",
+			want:    want{url: "data:image/png;base64,iVBORw0KGgo="},
+		},
+		"BinaryData": {
+			reason:  "Logos stored as binary data of a ConfigMap should be uploaded.",
+			logoURL: "cm://identity/logos/app.png",
+			want:    want{content: "PNG"},
+		},
+		"Data": {
+			reason:  "Logos stored as text data of a ConfigMap should be uploaded.",
+			logoURL: "cm://identity/logos/app.svg",
+			want:    want{content: "<svg></svg>"},
+		},
+		"MissingKey": {
+			reason:  "Logos missing from their ConfigMap should not be uploaded.",
+			logoURL: "cm://identity/logos/other.png",
+			want:    want{err: errors.Errorf("%s %s", errLogoKey, "other.png")},
+		},
+		"GetError": {
+			reason:  "Errors getting the ConfigMap of a logo should be returned.",
+			logoURL: "cm://identity/other/app.png",
+			want:    want{err: errors.Wrap(errBoom, errGetLogo)},
+		},
+		"InvalidURI": {
+			reason:  "ConfigMap logo URIs missing their key should be rejected.",
+			logoURL: "cm://identity/logos",
+			want:    want{err: errors.New(errLogoURI)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			svc := &logoService{}
+			e := external{service: svc, kube: kube}
+			err := e.uploadLogo(context.Background(), "id", tc.logoURL)
+			got := want{url: svc.url, content: svc.content, err: err}
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors(), cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.uploadLogo(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetLogoSynced(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		upload func(ctx context.Context) error
+		want   xpv1.Condition
+	}{
+		"Uploaded": {
+			reason: "Clients whose logo was uploaded should report it synced.",
+			upload: func(_ context.Context) error { return nil },
+			want:   xpv1.Condition{Type: conditions.TypeLogoSynced, Status: corev1.ConditionTrue, Reason: "LogoUploaded"},
+		},
+		"Failed": {
+			reason: "Clients whose logo cannot be uploaded should report why.",
+			upload: func(_ context.Context) error { return errBoom },
+			want:   xpv1.Condition{Type: conditions.TypeLogoSynced, Status: corev1.ConditionFalse, Reason: "LogoUploadFailed", Message: "cannot upload logo after 1 attempts, retrying: boom"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{logos: async.NewQueue()}
+			cr := &apisv1beta1.OIDCClient{ObjectMeta: metav1.ObjectMeta{UID: "0b3e5a1c"}}
+
+			e.setLogoSynced(cr)
+			if c := cr.Status.GetCondition(conditions.TypeLogoSynced); c.Status != corev1.ConditionUnknown {
+				t.Errorf("\n%s\ne.setLogoSynced(...): want no condition before any upload, got %+v", tc.reason, c)
+			}
+
+			e.logos.Submit(cr, sideEffectLogo, tc.upload)
+			e.setLogoSynced(cr)
+			if c := cr.Status.GetCondition(conditions.TypeLogoSynced); c.Reason != "LogoUploading" {
+				t.Errorf("\n%s\ne.setLogoSynced(...): want the logo uploading, got %+v", tc.reason, c)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				// Stop the queue before the upload is retried.
+				time.Sleep(100 * time.Millisecond)
+				cancel()
+			}()
+			_ = e.logos.Start(ctx)
+
+			e.setLogoSynced(cr)
+			if diff := cmp.Diff(tc.want, cr.Status.GetCondition(conditions.TypeLogoSynced), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\ne.setLogoSynced(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsOIDCClientUpToDateLogo(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   apisv1beta1.OIDCClientParameters
+		client pocketid.OIDCClient
+		want   bool
+	}{
+		"LogoRemoved": {
+			reason: "A client with a logo no longer specified should be updated to delete it.",
+			client: pocketid.OIDCClient{HasLogo: true},
+			want:   false,
+		},
+		"NoLogo": {
+			reason: "A client without a logo should be up to date when none is specified.",
+			want:   true,
+		},
+		"LogoSpecified": {
+			reason: "Specified logos are uploaded on updates rather than compared.",
+			spec:   apisv1beta1.OIDCClientParameters{LogoURL: "https://example.com/logo.png"},
+			client: pocketid.OIDCClient{HasLogo: true},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := isOIDCClientUpToDate(tc.spec, tc.client)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nisOIDCClientUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIssuerDetails(t *testing.T) {
+	config := &pocketid.OpenIDConfiguration{
+		Issuer:                "https://id.example.com",
+		AuthorizationEndpoint: "https://id.example.com/authorize",
+		TokenEndpoint:         "https://id.example.com/api/oidc/token",
+		JWKSURI:               "https://id.example.com/.well-known/jwks.json",
+	}
+	want := managed.ConnectionDetails{
+		"issuer":                []byte("https://id.example.com"),
+		"authorizationEndpoint": []byte("https://id.example.com/authorize"),
+		"tokenEndpoint":         []byte("https://id.example.com/api/oidc/token"),
+		"jwksUri":               []byte("https://id.example.com/.well-known/jwks.json"),
+	}
+	if diff := cmp.Diff(want, issuerDetails(config)); diff != "" {
+		t.Errorf("issuerDetails(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestManagementPolicies(t *testing.T) {
+	observeCreate := xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate}
+	noDelete := xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionLateInitialize}
+
+	type want struct {
+		calls []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		policies xpv1.ManagementPolicies
+		exists   bool
+		drifted  bool
+		deleted  bool
+		want     want
+	}{
+		"ObserveOnlyNotCreated": {
+			reason:   "An Observe only client should never be created.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+		},
+		"ObserveOnlyNotUpdated": {
+			reason:   "An Observe only client should never be updated.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			exists:   true,
+			drifted:  true,
+		},
+		"ObserveOnlyNotDeleted": {
+			reason:   "Deleting an Observe only client should leave it in Pocket ID.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			exists:   true,
+			deleted:  true,
+		},
+		"CreateOnlyCreated": {
+			reason:   "A client with the Create policy should be created.",
+			policies: observeCreate,
+			want:     want{calls: []string{http.MethodPost}},
+		},
+		"CreateOnlyNotUpdated": {
+			reason:   "A client without the Update policy should never be updated.",
+			policies: observeCreate,
+			exists:   true,
+			drifted:  true,
+		},
+		"NoDeleteUpdated": {
+			reason:   "A client with the Update policy should be updated.",
+			policies: noDelete,
+			exists:   true,
+			drifted:  true,
+			want:     want{calls: []string{http.MethodPut}},
+		},
+		"NoDeleteNotDeleted": {
+			reason:   "Deleting a client without the Delete policy should leave it in Pocket ID.",
+			policies: noDelete,
+			exists:   true,
+			deleted:  true,
+		},
+		"FullyManagedDeleted": {
+			reason:   "Deleting a fully managed client should delete it from Pocket ID.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionAll},
+			exists:   true,
+			deleted:  true,
+			want:     want{calls: []string{http.MethodDelete}},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := pocketidfake.NewServer()
+			defer srv.Close()
+			if tc.exists {
+				launchURL := "https://app.example.com"
+				if tc.drifted {
+					launchURL = "https://old.example.com"
+				}
+				srv.AddOIDCClient(pocketid.OIDCClient{ClientName: "app", RedirectURIs: []string{"https://app.example.com/callback"}, LaunchURL: launchURL})
+			}
+			svc, err := pocketid.NewClientFromConfig(srv.Config())
+			if err != nil {
+				t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+			}
+
+			cr := &apisv1beta1.OIDCClient{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: apisv1beta1.OIDCClientSpec{
+					ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: tc.policies, DeletionPolicy: xpv1.DeletionDelete},
+					ForProvider: apisv1beta1.OIDCClientParameters{
+						Name:         "app",
+						CallbackURLs: []string{"https://app.example.com/callback"},
+						LaunchURL:    "https://app.example.com",
+					},
+				},
+			}
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			kube := test.NewMockClient()
+			kube.MockGet = test.NewMockGetFn(nil, func(obj client.Object) error {
+				cr.DeepCopyInto(obj.(*apisv1beta1.OIDCClient))
+				return nil
+			})
+
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(apisv1beta1.OIDCClientGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					return &external{service: svc, kube: kube, logos: async.NewQueue()}, nil
+				})),
+				managed.WithManagementPolicies(),
+			)
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "app"}}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
 		})
 	}
 }
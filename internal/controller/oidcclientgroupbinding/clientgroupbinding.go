@@ -18,13 +18,18 @@ package clientgroupbinding
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
@@ -37,8 +42,12 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
 
 	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
 	"github.com/crossplane/provider-pocketid/internal/features"
+	"github.com/crossplane/provider-pocketid/internal/readonly"
 )
 
 const (
@@ -49,33 +58,53 @@ const (
 	errNewClient             = "cannot create new Service"
 	errResolveClientID       = "cannot resolve client ID"
 	errResolveGroupID        = "cannot resolve group ID"
+	errIndexExternalName     = "cannot index OIDCClientGroupBindings by external name"
+	errCheckDuplicate        = "cannot check for duplicate bindings"
 )
 
+// reasonTargetGone is the event reason used when a binding is deleted after
+// the objects it binds have already been removed.
+const reasonTargetGone event.Reason = "TargetGone"
+
+// externalNameIndex indexes OIDCClientGroupBindings by the <clientID>:<groupID>
+// external name they are bound to.
+const externalNameIndex = "metadata.annotations.externalName"
+
 // newPocketIDService creates a new Pocket ID service
 var (
-	newPocketIDService = func(endpoint string, creds []byte) (interface{}, error) {
-		return pocketid.NewClientFromCredentials(endpoint, string(creds))
+	newPocketIDService = func(cfg pocketid.Config) (pocketid.Service, error) {
+		return clients.NewClient(cfg)
 	}
 )
 
 // Setup adds a controller that reconciles OIDCClientGroupBinding managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
-	name := managed.ControllerName(apisv1alpha1.OIDCClientGroupBindingGroupKind)
+	name := managed.ControllerName(apisv1beta1.OIDCClientGroupBindingGroupKind)
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apisv1beta1.OIDCClientGroupBinding{}, externalNameIndex, indexExternalName); err != nil {
+		return errors.Wrap(err, errIndexExternalName)
+	}
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			recorder:     recorder,
 			newServiceFn: newPocketIDService,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(clients.PollIntervalHook(mgr.GetClient())),
+		managed.WithCriticalAnnotationUpdater(clients.NewCriticalAnnotationUpdater(mgr.GetClient())),
+		managed.WithReferenceResolver(clients.NewReferenceResolver(mgr.GetClient(), resolvedIDs, recorder)),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...),
 		managed.WithManagementPolicies(),
 	}
@@ -90,21 +119,51 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
 		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
-			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &apisv1alpha1.OIDCClientGroupBindingList{}, o.MetricOptions.PollStateMetricInterval,
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &apisv1beta1.OIDCClientGroupBindingList{}, o.MetricOptions.PollStateMetricInterval,
 		)
 		if err := mgr.Add(stateMetricsRecorder); err != nil {
-			return errors.Wrap(err, "cannot register MR state metrics recorder for kind apisv1alpha1.OIDCClientGroupBindingList")
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind apisv1beta1.OIDCClientGroupBindingList")
 		}
 	}
 
-	r := managed.NewReconciler(mgr, resource.ManagedKind(apisv1alpha1.OIDCClientGroupBindingGroupVersionKind), opts...)
+	r := managed.NewReconciler(mgr, resource.ManagedKind(apisv1beta1.OIDCClientGroupBindingGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
-		WithEventFilter(resource.DesiredStateChanged()).
-		For(&apisv1alpha1.OIDCClientGroupBinding{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(&apisv1beta1.OIDCClientGroupBinding{}, builder.WithPredicates(resource.DesiredStateChanged())).
+		Watches(&apisv1beta1.OIDCClient{}, handler.EnqueueRequestsFromMapFunc(clients.EnqueueReferencing(mgr.GetClient(), newList, clientIDReference)), builder.WithPredicates(clients.ReferenceChanged())).
+		Watches(&apisv1beta1.Group{}, handler.EnqueueRequestsFromMapFunc(clients.EnqueueReferencing(mgr.GetClient(), newList, groupIDReference)), builder.WithPredicates(clients.ReferenceChanged())).
+		Complete(ratelimiter.NewReconciler(name, clients.HonorRetryAfter(apisv1beta1.OIDCClientGroupBindingKind, r), o.GlobalRateLimiter))
+}
+
+// indexExternalName returns the <clientID>:<groupID> external name of an
+// OIDCClientGroupBinding, if it has been set.
+func indexExternalName(o client.Object) []string {
+	en := meta.GetExternalName(o)
+	if _, _, ok := parseExternalName(en); !ok {
+		return nil
+	}
+	return []string{en}
+}
+
+// newList returns an empty list of OIDCClientGroupBindings.
+func newList() resource.ManagedList {
+	return &apisv1beta1.OIDCClientGroupBindingList{}
+}
+
+// clientIDReference returns the OIDC client ID of an OIDCClientGroupBinding, along with the
+// reference and selector it is resolved from.
+func clientIDReference(mg resource.Managed) (string, *xpv1.Reference, *xpv1.Selector) {
+	p := mg.(*apisv1beta1.OIDCClientGroupBinding).Spec.ForProvider
+	return p.ClientID, p.ClientIDRef, p.ClientIDSelector
+}
+
+// groupIDReference returns the group ID of an OIDCClientGroupBinding, along with the
+// reference and selector it is resolved from.
+func groupIDReference(mg resource.Managed) (string, *xpv1.Reference, *xpv1.Selector) {
+	p := mg.(*apisv1beta1.OIDCClientGroupBinding).Spec.ForProvider
+	return p.GroupID, p.GroupIDRef, p.GroupIDSelector
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -112,7 +171,8 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(endpoint string, creds []byte) (interface{}, error)
+	recorder     event.Recorder
+	newServiceFn func(cfg pocketid.Config) (pocketid.Service, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -121,7 +181,7 @@ type connector struct {
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClientGroupBinding)
+	cr, ok := mg.(*apisv1beta1.OIDCClientGroupBinding)
 	if !ok {
 		return nil, errors.New(errNotClientGroupBinding)
 	}
@@ -135,74 +195,139 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	data, err := clients.ExtractCredentials(ctx, c.kube, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	svc, err := c.newServiceFn(pc.Spec.Endpoint, data)
+	cfg, err := clients.NewConfig(ctx, c.kube, pc, data)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{
-		service: svc.(*pocketid.Client),
-		kube:    c.kube,
-	}, nil
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return readonly.Wrap(pc, clients.AttributeRequests(apisv1beta1.OIDCClientGroupBindingKind, &external{
+		service:  svc,
+		kube:     c.kube,
+		recorder: c.recorder,
+	}), c.recorder), nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service *pocketid.Client
-	kube    client.Client
+	service  pocketid.Service
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClientGroupBinding)
+	cr, ok := mg.(*apisv1beta1.OIDCClientGroupBinding)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotClientGroupBinding)
 	}
 
-	// Resolve client ID
-	clientID, err := c.resolveClientID(ctx, cr)
-	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errResolveClientID)
+	// A binding that has already been created or observed records the IDs it
+	// is bound to in its external name, so they don't need to be resolved.
+	clientID, groupID, ok := parseExternalName(meta.GetExternalName(cr))
+	if !ok {
+		var err error
+
+		// Resolve client ID
+		clientID, err = c.resolveClientID(ctx, cr)
+		if meta.WasDeleted(cr) && clients.IsTargetGone(err) {
+			c.recorder.Event(cr, event.Normal(reasonTargetGone, "Referenced OIDC client no longer exists, nothing to remove"))
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		if clients.IsDependencyNotReady(err) {
+			return clients.WaitForDependency(cr, errors.Wrap(err, errResolveClientID)), nil
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errResolveClientID)
+		}
+
+		// Resolve group ID
+		groupID, err = c.resolveGroupID(ctx, cr)
+		if meta.WasDeleted(cr) && clients.IsTargetGone(err) {
+			c.recorder.Event(cr, event.Normal(reasonTargetGone, "Referenced group no longer exists, nothing to remove"))
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		if clients.IsDependencyNotReady(err) {
+			return clients.WaitForDependency(cr, errors.Wrap(err, errResolveGroupID)), nil
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errResolveGroupID)
+		}
 	}
 
-	// Resolve group ID
-	groupID, err := c.resolveGroupID(ctx, cr)
+	conditions.Clear(cr, conditions.DependencyReady())
+	cr.Status.AtProvider.ResolvedClientID = clientID
+	cr.Status.AtProvider.ResolvedGroupID = groupID
+
+	// Only the oldest binding for a client and group manages the membership, so
+	// that deleting a duplicate doesn't remove it from under the other.
+	owner, err := c.olderDuplicate(ctx, cr, clientID+":"+groupID)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errResolveGroupID)
+		return managed.ExternalObservation{}, errors.Wrap(err, errCheckDuplicate)
+	}
+	if owner != "" {
+		cr.Status.SetConditions(conditions.DuplicateBinding(apisv1beta1.OIDCClientGroupBindingKind, owner))
+		return managed.ExternalObservation{ResourceExists: !meta.WasDeleted(cr), ResourceUpToDate: true}, nil
 	}
+	conditions.Clear(cr, conditions.NoConflict())
 
-	// Check if binding exists
-	exists, err := c.service.IsClientInGroup(ctx, clientID, groupID)
+	// The client lists the names of the groups it is bound to, so it must be
+	// fetched on every observation.
+	client, err := c.service.GetOIDCClient(ctx, clientID)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, "failed to check client group binding")
+		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get OIDC client")
 	}
 
-	if !exists {
+	if client == nil {
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
-	// Get client and group details for status
-	client, err := c.service.GetOIDCClient(ctx, clientID)
-	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get OIDC client")
+	// The group details only need to be fetched when they are not already
+	// known from a previous observation.
+	group := cr.Status.AtProvider.Group
+	if group.ID != groupID || group.Name == "" {
+		g, err := c.service.GetGroup(ctx, groupID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, "failed to get group")
+		}
+
+		if g == nil {
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+
+		group = apisv1beta1.GroupObservation{
+			ID:           g.ID,
+			Name:         g.GroupName,
+			FriendlyName: g.FriendlyName,
+			CustomClaims: g.CustomClaims,
+		}
 	}
 
-	group, err := c.service.GetGroup(ctx, groupID)
-	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get group")
+	// Check if binding exists
+	if !slices.Contains(client.GroupNames, group.Name) {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
 	}
 
 	// Update status with observed values
-	cr.Status.AtProvider = apisv1alpha1.OIDCClientGroupBindingObservation{
-		Client: apisv1alpha1.OIDCClientObservation{
+	cr.Status.AtProvider = apisv1beta1.OIDCClientGroupBindingObservation{
+		ResolvedClientID: clientID,
+		ResolvedGroupID:  groupID,
+		Client: apisv1beta1.OIDCClientObservation{
 			ID:                 client.ID,
 			Name:               client.ClientName,
 			CallbackURLs:       client.RedirectURIs,
@@ -212,16 +337,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 			PkceEnabled:        client.RequirePKCE,
 			HasLogo:            client.HasLogo,
 		},
-		Group: apisv1alpha1.GroupObservation{
-			ID:           group.ID,
-			Name:         group.GroupName,
-			FriendlyName: group.FriendlyName,
-			CustomClaims: group.CustomClaims,
-		},
+		Group: group,
 	}
 
 	// Set external name combining client and group IDs
-	if meta.GetExternalName(cr) == "" {
+	if !ok {
 		meta.SetExternalName(cr, clientID+":"+groupID)
 	}
 
@@ -234,7 +354,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClientGroupBinding)
+	cr, ok := mg.(*apisv1beta1.OIDCClientGroupBinding)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotClientGroupBinding)
 	}
@@ -269,21 +389,39 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	cr, ok := mg.(*apisv1alpha1.OIDCClientGroupBinding)
+	cr, ok := mg.(*apisv1beta1.OIDCClientGroupBinding)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotClientGroupBinding)
 	}
 
-	// Resolve client ID
-	clientID, err := c.resolveClientID(ctx, cr)
-	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, errResolveClientID)
+	// Prefer the IDs recorded when the binding was observed, as the referenced
+	// client or group may already be gone.
+	clientID, groupID, ok := parseExternalName(meta.GetExternalName(cr))
+	if !ok {
+		clientID, groupID = cr.Status.AtProvider.ResolvedClientID, cr.Status.AtProvider.ResolvedGroupID
 	}
 
-	// Resolve group ID
-	groupID, err := c.resolveGroupID(ctx, cr)
-	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, errResolveGroupID)
+	var err error
+	if clientID == "" {
+		clientID, err = c.resolveClientID(ctx, cr)
+		if clients.IsTargetGone(err) {
+			c.recorder.Event(cr, event.Normal(reasonTargetGone, "Referenced OIDC client no longer exists, nothing to remove"))
+			return managed.ExternalDelete{}, nil
+		}
+		if err != nil {
+			return managed.ExternalDelete{}, errors.Wrap(err, errResolveClientID)
+		}
+	}
+
+	if groupID == "" {
+		groupID, err = c.resolveGroupID(ctx, cr)
+		if clients.IsTargetGone(err) {
+			c.recorder.Event(cr, event.Normal(reasonTargetGone, "Referenced group no longer exists, nothing to remove"))
+			return managed.ExternalDelete{}, nil
+		}
+		if err != nil {
+			return managed.ExternalDelete{}, errors.Wrap(err, errResolveGroupID)
+		}
 	}
 
 	// Remove client from group
@@ -299,44 +437,109 @@ func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// olderDuplicate returns the name of an older OIDCClientGroupBinding bound to
+// the supplied external name, or an empty string if there is none.
+func (c *external) olderDuplicate(ctx context.Context, cr *apisv1beta1.OIDCClientGroupBinding, en string) (string, error) {
+	l := &apisv1beta1.OIDCClientGroupBindingList{}
+	if err := c.kube.List(ctx, l, client.MatchingFields{externalNameIndex: en}); err != nil {
+		return "", err
+	}
+
+	for _, b := range l.Items {
+		if b.GetName() == cr.GetName() {
+			continue
+		}
+		if b.CreationTimestamp.Before(&cr.CreationTimestamp) ||
+			(b.CreationTimestamp.Equal(&cr.CreationTimestamp) && b.GetName() < cr.GetName()) {
+			return b.GetName(), nil
+		}
+	}
+
+	return "", nil
+}
+
+// parseExternalName splits an external name of the form <clientID>:<groupID>.
+func parseExternalName(en string) (clientID, groupID string, ok bool) {
+	clientID, groupID, ok = strings.Cut(en, ":")
+	return clientID, groupID, ok && clientID != "" && groupID != ""
+}
+
+// resolvedIDs sets the client and group IDs of the supplied binding to those its
+// references last resolved to, as recorded in its status, wherever they are
+// referenced or selected but not resolved. It reports whether both IDs are
+// known.
+func resolvedIDs(mg resource.Managed) bool {
+	cr, ok := mg.(*apisv1beta1.OIDCClientGroupBinding)
+	if !ok {
+		return false
+	}
+	p := &cr.Spec.ForProvider
+	if p.ClientID == "" && (p.ClientIDRef != nil || p.ClientIDSelector != nil) {
+		p.ClientID = cr.Status.AtProvider.ResolvedClientID
+	}
+	if p.GroupID == "" && (p.GroupIDRef != nil || p.GroupIDSelector != nil) {
+		p.GroupID = cr.Status.AtProvider.ResolvedGroupID
+	}
+	return (p.ClientID != "" || p.ClientIDRef == nil && p.ClientIDSelector == nil) &&
+		(p.GroupID != "" || p.GroupIDRef == nil && p.GroupIDSelector == nil)
+}
+
 // resolveClientID resolves the client ID from the binding spec
-func (c *external) resolveClientID(ctx context.Context, cr *apisv1alpha1.OIDCClientGroupBinding) (string, error) {
+func (c *external) resolveClientID(ctx context.Context, cr *apisv1beta1.OIDCClientGroupBinding) (string, error) {
 	if cr.Spec.ForProvider.ClientID != "" {
 		return cr.Spec.ForProvider.ClientID, nil
 	}
 
-	if cr.Spec.ForProvider.ClientIDRef != nil {
-		oidcClient := &apisv1alpha1.OIDCClient{}
-		if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.Spec.ForProvider.ClientIDRef.Name}, oidcClient); err != nil {
-			return "", errors.Wrap(err, "failed to get referenced OIDC client")
+	if name := cr.Spec.ForProvider.ClientName; name != "" {
+		oidcClient, err := c.service.GetOIDCClientByExternalName(ctx, name)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to look up OIDC client by name")
 		}
-		if oidcClient.Status.AtProvider.ID == "" {
-			return "", errors.New("referenced OIDC client ID is not available")
+		if oidcClient == nil {
+			return "", clients.DependencyNotReady(fmt.Sprintf("OIDC client %q does not exist", name))
 		}
-		return oidcClient.Status.AtProvider.ID, nil
+		return oidcClient.ID, nil
 	}
 
-	// TODO: Implement selector logic if needed
-	return "", errors.New("client ID, clientIdRef, or clientIdSelector must be specified")
+	// References and selectors are resolved into ClientID before the binding is
+	// observed, unless it is deleted first, in which case the ID they last
+	// resolved to is used.
+	if cr.Spec.ForProvider.ClientIDRef != nil || cr.Spec.ForProvider.ClientIDSelector != nil {
+		if id := cr.Status.AtProvider.ResolvedClientID; id != "" {
+			return id, nil
+		}
+		return "", clients.DependencyNotReady("referenced client ID has not been resolved")
+	}
+
+	return "", errors.New("client ID, clientName, clientIdRef, or clientIdSelector must be specified")
 }
 
 // resolveGroupID resolves the group ID from the binding spec
-func (c *external) resolveGroupID(ctx context.Context, cr *apisv1alpha1.OIDCClientGroupBinding) (string, error) {
+func (c *external) resolveGroupID(ctx context.Context, cr *apisv1beta1.OIDCClientGroupBinding) (string, error) {
 	if cr.Spec.ForProvider.GroupID != "" {
 		return cr.Spec.ForProvider.GroupID, nil
 	}
 
-	if cr.Spec.ForProvider.GroupIDRef != nil {
-		group := &apisv1alpha1.Group{}
-		if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.Spec.ForProvider.GroupIDRef.Name}, group); err != nil {
-			return "", errors.Wrap(err, "failed to get referenced group")
+	if name := cr.Spec.ForProvider.GroupName; name != "" {
+		group, err := c.service.GetGroupByExternalName(ctx, name)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to look up group by name")
+		}
+		if group == nil {
+			return "", clients.DependencyNotReady(fmt.Sprintf("group %q does not exist", name))
 		}
-		if group.Status.AtProvider.ID == "" {
-			return "", errors.New("referenced group ID is not available")
+		return group.ID, nil
+	}
+
+	// References and selectors are resolved into GroupID before the binding is
+	// observed, unless it is deleted first, in which case the ID they last
+	// resolved to is used.
+	if cr.Spec.ForProvider.GroupIDRef != nil || cr.Spec.ForProvider.GroupIDSelector != nil {
+		if id := cr.Status.AtProvider.ResolvedGroupID; id != "" {
+			return id, nil
 		}
-		return group.Status.AtProvider.ID, nil
+		return "", clients.DependencyNotReady("referenced group ID has not been resolved")
 	}
 
-	// TODO: Implement selector logic if needed
-	return "", errors.New("group ID, groupIdRef, or groupIdSelector must be specified")
+	return "", errors.New("group ID, groupName, groupIdRef, or groupIdSelector must be specified")
 }
@@ -18,14 +18,32 @@ package clientgroupbinding
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/apis"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+	"github.com/crossplane/provider-pocketid/internal/controller/controllertest"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,9 +54,59 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+var errBoom = errors.New("boom")
+
+// clientBinding returns an OIDCClientGroupBinding of the app client to the
+// admins group, modified by the supplied functions.
+func clientBinding(fns ...func(cr *apisv1beta1.OIDCClientGroupBinding)) *apisv1beta1.OIDCClientGroupBinding {
+	cr := &apisv1beta1.OIDCClientGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-admins"},
+		Spec: apisv1beta1.OIDCClientGroupBindingSpec{ForProvider: apisv1beta1.OIDCClientGroupBindingParameters{
+			ClientID: "app-id",
+			GroupID:  "admins-id",
+		}},
+	}
+	for _, fn := range fns {
+		fn(cr)
+	}
+	return cr
+}
+
+// seeds adding the app client and admins group, and allowing the group to
+// use the client if it is bound.
+func seeds(bound bool) []controllertest.Seed {
+	return []controllertest.Seed{func(s *pocketidfake.Server) {
+		s.AddOIDCClient(pocketid.OIDCClient{ID: "app-id", ClientName: "app", RedirectURIs: []string{"https://app.example.com/callback"}})
+		s.AddGroup(pocketid.Group{ID: "admins-id", GroupName: "admins", FriendlyName: "Admins"})
+		if bound {
+			s.AddClientToGroup("app-id", "admins-id")
+		}
+	}}
+}
+
 func TestObserve(t *testing.T) {
+	older := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+
+	binding := func(name string, created metav1.Time, deleted bool) *apisv1beta1.OIDCClientGroupBinding {
+		b := &apisv1beta1.OIDCClientGroupBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: created},
+		}
+		meta.SetExternalName(b, "client-id:group-id")
+		if deleted {
+			b.SetDeletionTimestamp(&newer)
+		}
+		return b
+	}
+
+	existing := func(obj client.ObjectList) error {
+		obj.(*apisv1beta1.OIDCClientGroupBindingList).Items = []apisv1beta1.OIDCClientGroupBinding{*binding("older", older, false)}
+		return nil
+	}
+
 	type fields struct {
-		service *pocketid.Client
+		seeds []controllertest.Seed
+		kube  client.Client
 	}
 
 	type args struct {
@@ -57,12 +125,78 @@ func TestObserve(t *testing.T) {
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"Bound": {
+			reason: "A client allowed to the group should be reported as bound.",
+			fields: fields{seeds: seeds(true), kube: controllertest.NewKube()},
+			args:   args{ctx: context.Background(), mg: clientBinding()},
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"NotBound": {
+			reason: "A client not allowed to the group should be reported as not bound.",
+			fields: fields{seeds: seeds(false), kube: controllertest.NewKube()},
+			args:   args{ctx: context.Background(), mg: clientBinding()},
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"ClientNotFound": {
+			reason: "A binding of a client missing from Pocket ID should be reported as not bound.",
+			fields: fields{kube: controllertest.NewKube()},
+			args:   args{ctx: context.Background(), mg: clientBinding()},
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"APIError": {
+			reason: "Errors getting the client should be returned.",
+			fields: fields{
+				seeds: append(seeds(true), controllertest.Fail(http.MethodGet, "/api/oidc/clients", http.StatusInternalServerError)),
+				kube:  controllertest.NewKube(),
+			},
+			args: args{ctx: context.Background(), mg: clientBinding()},
+			want: want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to get OIDC client")},
+		},
+		"Duplicate": {
+			reason: "A newer binding for the same client and group should report success without managing the membership.",
+			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil, existing)},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  binding("newer", newer, false),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DuplicateDeleted": {
+			reason: "Deleting a duplicate binding should not remove the membership owned by the older binding.",
+			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil, existing)},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  binding("newer", newer, true),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false, ResourceUpToDate: true},
+			},
+		},
+		"ListError": {
+			reason: "Errors listing bindings should be returned.",
+			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  binding("newer", newer, false),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCheckDuplicate),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
+			_, svc := controllertest.NewService(t, tc.fields.seeds...)
+			e := external{service: svc, kube: tc.fields.kube}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -73,3 +207,294 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		want   want
+	}{
+		"Created": {
+			reason: "The group should be allowed to use the client, the binding named after both.",
+			seeds:  seeds(false),
+			want:   want{externalName: "app-id:admins-id"},
+		},
+		"APIError": {
+			reason: "Errors allowing the group to use the client should be returned.",
+			seeds:  append(seeds(false), controllertest.Fail(http.MethodPost, "/api/oidc/clients", http.StatusInternalServerError)),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to create client group binding")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube(), recorder: event.NewNopRecorder()}
+			cr := clientBinding()
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got:\n%s\n", tc.reason, diff)
+			}
+			if c, _ := srv.OIDCClient("app-id"); err == nil && len(c.GroupNames) != 1 {
+				t.Errorf("\n%s\ne.Create(...): want the group allowed to use the client, got groups %v", tc.reason, c.GroupNames)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		mg     *apisv1beta1.OIDCClientGroupBinding
+		want   want
+	}{
+		"Deleted": {
+			reason: "The group should no longer be allowed to use the client.",
+			seeds:  seeds(true),
+			mg:     clientBinding(),
+			want:   want{calls: []string{http.MethodDelete}},
+		},
+		"ObservedIDs": {
+			reason: "The IDs recorded in the external name should be unbound, whatever the spec refers to.",
+			seeds:  seeds(true),
+			mg: clientBinding(func(cr *apisv1beta1.OIDCClientGroupBinding) {
+				cr.Spec.ForProvider.ClientID = ""
+				cr.Spec.ForProvider.ClientIDRef = &xpv1.Reference{Name: "app"}
+				meta.SetExternalName(cr, "app-id:admins-id")
+			}),
+			want: want{calls: []string{http.MethodDelete}},
+		},
+		"APIError": {
+			reason: "Errors removing the group from the client should be returned.",
+			seeds:  append(seeds(true), controllertest.Fail(http.MethodDelete, "/api/oidc/clients", http.StatusInternalServerError)),
+			mg:     clientBinding(),
+			want:   want{calls: []string{http.MethodDelete}, err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to delete client group binding")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube(), recorder: event.NewNopRecorder()}
+			_, err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManagementPolicies(t *testing.T) {
+	observeCreate := xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate}
+
+	type want struct {
+		calls []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		policies xpv1.ManagementPolicies
+		bound    bool
+		deleted  bool
+		want     want
+	}{
+		"ObserveOnlyNotCreated": {
+			reason:   "An Observe only binding should never allow the client for the group.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+		},
+		"ObserveOnlyNotRemoved": {
+			reason:   "Deleting an Observe only binding should leave the client allowed for the group.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			bound:    true,
+			deleted:  true,
+		},
+		"CreateOnlyCreated": {
+			reason:   "A binding with the Create policy should allow the client for the group.",
+			policies: observeCreate,
+			want:     want{calls: []string{http.MethodPost}},
+		},
+		"CreateOnlyNotRemoved": {
+			reason:   "Deleting a binding without the Delete policy should leave the client allowed for the group.",
+			policies: observeCreate,
+			bound:    true,
+			deleted:  true,
+		},
+		"FullyManagedRemoved": {
+			reason:   "Deleting a fully managed binding should stop allowing the client for the group.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionAll},
+			bound:    true,
+			deleted:  true,
+			want:     want{calls: []string{http.MethodDelete}},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := pocketidfake.NewServer()
+			defer srv.Close()
+			group := srv.AddGroup(pocketid.Group{GroupName: "developers", FriendlyName: "Developers"})
+			oc := srv.AddOIDCClient(pocketid.OIDCClient{ClientName: "app"})
+			svc, err := pocketid.NewClientFromConfig(srv.Config())
+			if err != nil {
+				t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+			}
+			if tc.bound {
+				if err := svc.AddClientToGroup(context.Background(), oc.ID, group.ID); err != nil {
+					t.Fatalf("svc.AddClientToGroup(...): %v", err)
+				}
+			}
+			before := len(srv.Requests())
+
+			cr := &apisv1beta1.OIDCClientGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding"},
+				Spec: apisv1beta1.OIDCClientGroupBindingSpec{
+					ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: tc.policies, DeletionPolicy: xpv1.DeletionDelete},
+					ForProvider: apisv1beta1.OIDCClientGroupBindingParameters{
+						ClientID: oc.ID,
+						GroupID:  group.ID,
+					},
+				},
+			}
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			kube := test.NewMockClient()
+			kube.MockGet = test.NewMockGetFn(nil, func(obj client.Object) error {
+				cr.DeepCopyInto(obj.(*apisv1beta1.OIDCClientGroupBinding))
+				return nil
+			})
+
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(apisv1beta1.OIDCClientGroupBindingGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					return &external{service: svc, kube: kube, recorder: event.NewNopRecorder()}, nil
+				})),
+				managed.WithManagementPolicies(),
+			)
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "binding"}}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests()[before:])); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBindingsForGroup(t *testing.T) {
+	bindings := func(obj client.ObjectList) error {
+		l := obj.(*apisv1beta1.OIDCClientGroupBindingList)
+		l.Items = []apisv1beta1.OIDCClientGroupBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-ref"},
+				Spec: apisv1beta1.OIDCClientGroupBindingSpec{ForProvider: apisv1beta1.OIDCClientGroupBindingParameters{
+					GroupIDRef: &xpv1.Reference{Name: "developers"},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-selector"},
+				Spec: apisv1beta1.OIDCClientGroupBindingSpec{ForProvider: apisv1beta1.OIDCClientGroupBindingParameters{
+					GroupIDSelector: &xpv1.Selector{MatchLabels: map[string]string{"tier": "admin"}},
+				}},
+			},
+		}
+		return nil
+	}
+
+	cases := map[string]struct {
+		reason string
+		group  client.Object
+		want   []reconcile.Request
+	}{
+		"ReferencedByName": {
+			reason: "A binding whose groupIdRef names the group should be enqueued.",
+			group:  &apisv1beta1.Group{ObjectMeta: metav1.ObjectMeta{Name: "developers"}},
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "by-ref"}}},
+		},
+		"SelectedByLabels": {
+			reason: "A binding whose groupIdSelector matches the group's labels should be enqueued.",
+			group:  &apisv1beta1.Group{ObjectMeta: metav1.ObjectMeta{Name: "admins", Labels: map[string]string{"tier": "admin"}}},
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "by-selector"}}},
+		},
+		"Unrelated": {
+			reason: "No binding should be enqueued for a group nothing refers to.",
+			group:  &apisv1beta1.Group{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+			want:   nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockList: test.NewMockListFn(nil, bindings)}
+			got := clients.EnqueueReferencing(kube, newList, groupIDReference)(context.Background(), tc.group)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nclients.EnqueueReferencing(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseExternalName(t *testing.T) {
+	type want struct {
+		clientID string
+		groupID  string
+		ok       bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		en     string
+		want   want
+	}{
+		"Valid": {
+			reason: "An external name of the form clientID:groupID should be split.",
+			en:     "client:group",
+			want:   want{clientID: "client", groupID: "group", ok: true},
+		},
+		"ObjectName": {
+			reason: "An external name defaulted from the object name should not parse.",
+			en:     "grafana-developers",
+			want:   want{clientID: "grafana-developers"},
+		},
+		"MissingGroup": {
+			reason: "An external name without a group ID should not parse.",
+			en:     "client:",
+			want:   want{clientID: "client"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clientID, groupID, ok := parseExternalName(tc.en)
+			got := want{clientID: clientID, groupID: groupID, ok: ok}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nparseExternalName(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
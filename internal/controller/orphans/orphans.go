@@ -0,0 +1,323 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orphans detects the Pocket ID objects created by the provider whose
+// managed resource no longer exists.
+package orphans
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/features"
+	"github.com/crossplane/provider-pocketid/internal/paused"
+	"github.com/crossplane/provider-pocketid/internal/readonly"
+)
+
+const (
+	errGetPC       = "cannot get ProviderConfig"
+	errGetCreds    = "cannot get credentials"
+	errNewClient   = "cannot create new Service"
+	errListMRs     = "cannot list managed resources"
+	errListObjects = "cannot list Pocket ID objects"
+	errDelete      = "cannot delete orphaned Pocket ID object"
+)
+
+// Event reasons.
+const (
+	reasonOrphaned        event.Reason = "OrphanedObject"
+	reasonDeletedOrphaned event.Reason = "DeletedOrphanedObject"
+)
+
+// sweepInterval is how often the Pocket ID server of each ProviderConfig is
+// swept for orphaned objects. Sweeps list every user, group and OIDC client.
+const sweepInterval = time.Hour
+
+// orphanedObjects reports how many orphaned objects were left in Pocket ID by
+// the last sweep.
+var orphanedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "pocketid_orphaned_objects",
+	Help: "Number of Pocket ID objects created by the provider whose managed resource no longer exists, by ProviderConfig and kind.",
+}, []string{"providerconfig", "kind"})
+
+// Collectors returns the collectors of the metrics of the orphan detection
+// controller, to be registered with the metrics of the provider.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{orphanedObjects}
+}
+
+// An object of Pocket ID created by the provider.
+type object struct {
+	kind  string
+	id    string
+	name  string
+	owner string
+}
+
+// Setup adds a controller that periodically sweeps the Pocket ID server of
+// each ProviderConfig that is not paused for orphaned objects, if orphan
+// detection is enabled.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	if !o.Features.Enabled(features.EnableAlphaOrphanDetection) {
+		return nil
+	}
+	name := "orphans/" + strings.ToLower(apisv1alpha1.ProviderConfigGroupKind)
+
+	r := &reconciler{
+		kube:    mgr.GetClient(),
+		reader:  mgr.GetAPIReader(),
+		record:  event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+		log:     o.Logger.WithValues("controller", name),
+		connect: connect(mgr.GetClient()),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&apisv1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, paused.Unpaused()))).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// connect returns a function connecting to the Pocket ID server of a
+// ProviderConfig with its credentials.
+func connect(kube client.Client) func(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (pocketid.Service, error) {
+	return func(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (pocketid.Service, error) {
+		data, err := clients.ExtractCredentials(ctx, kube, pc)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetCreds)
+		}
+		cfg, err := clients.NewConfig(ctx, kube, pc, data)
+		if err != nil {
+			return nil, errors.Wrap(err, errNewClient)
+		}
+		svc, err := clients.NewClient(cfg)
+		return svc, errors.Wrap(err, errNewClient)
+	}
+}
+
+// A reconciler sweeps the Pocket ID server of a ProviderConfig for the
+// objects marked as created by a managed resource that no longer exists.
+type reconciler struct {
+	kube client.Client
+	// reader reads the managed resources from the API server rather than
+	// the cache, which may lag behind.
+	reader  client.Reader
+	record  event.Recorder
+	log     logging.Logger
+	connect func(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (pocketid.Service, error)
+}
+
+// Reconcile a ProviderConfig.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerr := resource.IgnoreNotFound(err); kerr != nil {
+			return reconcile.Result{}, errors.Wrap(kerr, errGetPC)
+		}
+		orphanedObjects.DeletePartialMatch(prometheus.Labels{"providerconfig": req.Name})
+		return reconcile.Result{}, nil
+	}
+	// Paused ProviderConfigs are swept again as soon as they are resumed.
+	if meta.WasDeleted(pc) || meta.IsPaused(pc) {
+		return reconcile.Result{}, nil
+	}
+
+	svc, err := r.connect(ctx, pc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	ctx = pocketid.WithRequestSource(ctx, apisv1alpha1.ProviderConfigKind+"/"+pc.GetName())
+
+	// Pocket ID objects are listed before their managed resources, which
+	// exist before the objects they create.
+	objects, err := list(ctx, svc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	managed, err := r.managed(ctx, r.kube)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	var orphans []object
+	for _, o := range objects {
+		if o.owner != "" && !managed.manages(o) {
+			orphans = append(orphans, o)
+		}
+	}
+
+	del := pc.Spec.OrphanPolicy == apisv1alpha1.OrphanPolicyDelete && pc.Spec.Mode != apisv1alpha1.ProviderConfigModeReadOnly
+	if del && len(orphans) > 0 {
+		// The cache may not hold the managed resources created moments
+		// ago yet: check with the API server before deleting anything.
+		live, err := r.managed(ctx, r.reader)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		orphans = slices.DeleteFunc(orphans, live.manages)
+	}
+
+	counts := map[string]float64{apisv1beta1.UserKind: 0, apisv1beta1.GroupKind: 0, apisv1beta1.OIDCClientKind: 0}
+	for _, o := range orphans {
+		if !del {
+			r.record.Event(pc, event.Warning(reasonOrphaned, errors.Errorf("%s %s (%s) was created by managed resource %s, which no longer exists", o.kind, o.name, o.id, o.owner)))
+			counts[o.kind]++
+			continue
+		}
+		if readonly.DryRunning() {
+			counts[o.kind]++
+			r.log.Info("Dry run, would have deleted orphaned Pocket ID object", "kind", o.kind, "name", o.name, "id", o.id, "owner", o.owner)
+			r.record.Event(pc, event.Normal(readonly.ReasonWouldDelete, "Dry run, would have deleted "+o.kind+" "+o.name+" ("+o.id+"), created by managed resource "+o.owner+", which no longer exists"))
+			continue
+		}
+		if err := remove(ctx, svc, o); err != nil && !errors.Is(err, pocketid.ErrNotFound) {
+			counts[o.kind]++
+			r.record.Event(pc, event.Warning(reasonOrphaned, errors.Wrapf(err, "%s %s (%s): %s", o.kind, o.name, o.id, errDelete)))
+			continue
+		}
+		r.log.Info("Deleted orphaned Pocket ID object", "kind", o.kind, "name", o.name, "id", o.id, "owner", o.owner)
+		r.record.Event(pc, event.Normal(reasonDeletedOrphaned, o.kind+" "+o.name+" ("+o.id+") was created by managed resource "+o.owner+", which no longer exists"))
+	}
+	for kind, n := range counts {
+		orphanedObjects.WithLabelValues(pc.GetName(), kind).Set(n)
+	}
+
+	return reconcile.Result{RequeueAfter: sweepInterval}, nil
+}
+
+// The managed resources of Pocket ID objects.
+type managedObjects struct {
+	// owners are the UIDs of the managed resources.
+	owners map[string]bool
+	// keys are the kinds of the objects of the managed resources, along with
+	// their external names, IDs and names, which may not be theirs yet.
+	keys map[string]bool
+}
+
+// manages reports whether a Pocket ID object has a managed resource. Objects
+// created by a managed resource that no longer exists may have been adopted
+// by another one, e.g. re-created or restored from a backup, before it
+// re-stamped their owner claim.
+func (m managedObjects) manages(o object) bool {
+	return m.owners[o.owner] || m.keys[o.kind+"/"+o.id] || m.keys[o.kind+"/"+o.name]
+}
+
+// managed returns the managed resources of Pocket ID objects read by the
+// supplied reader.
+func (r *reconciler) managed(ctx context.Context, c client.Reader) (managedObjects, error) {
+	m := managedObjects{owners: map[string]bool{}, keys: map[string]bool{}}
+	for _, l := range []client.ObjectList{
+		&apisv1beta1.UserList{},
+		&apisv1alpha1.AdminUserList{},
+		&apisv1beta1.GroupList{},
+		&apisv1beta1.OIDCClientList{},
+	} {
+		if err := c.List(ctx, l); err != nil {
+			return managedObjects{}, errors.Wrap(err, errListMRs)
+		}
+		if err := kmeta.EachListItem(l, func(o runtime.Object) error {
+			kind, keys := managedKeys(o.(resource.Managed))
+			m.owners[string(o.(client.Object).GetUID())] = true
+			for _, k := range append(keys, meta.GetExternalName(o.(client.Object))) {
+				if k != "" {
+					m.keys[kind+"/"+k] = true
+				}
+			}
+			return nil
+		}); err != nil {
+			return managedObjects{}, errors.Wrap(err, errListMRs)
+		}
+	}
+	return m, nil
+}
+
+// managedKeys returns the kind of the Pocket ID object of a managed resource,
+// along with its ID and name.
+func managedKeys(mg resource.Managed) (string, []string) {
+	switch cr := mg.(type) {
+	case *apisv1beta1.User:
+		return apisv1beta1.UserKind, []string{cr.Status.AtProvider.ID, cr.Spec.ForProvider.Username}
+	case *apisv1alpha1.AdminUser:
+		return apisv1beta1.UserKind, []string{cr.Status.AtProvider.ID, cr.Spec.ForProvider.Username}
+	case *apisv1beta1.Group:
+		return apisv1beta1.GroupKind, []string{cr.Status.AtProvider.ID, cr.Spec.ForProvider.Name}
+	case *apisv1beta1.OIDCClient:
+		return apisv1beta1.OIDCClientKind, []string{cr.Status.AtProvider.ID, cr.Spec.ForProvider.Name}
+	}
+	return "", nil
+}
+
+// list returns the users, groups and OIDC clients of a Pocket ID server.
+func list(ctx context.Context, svc pocketid.Service) ([]object, error) {
+	users, err := svc.ListUsers(ctx, pocketid.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListObjects)
+	}
+	groups, err := svc.ListGroups(ctx, pocketid.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListObjects)
+	}
+	oidcClients, err := svc.ListOIDCClients(ctx, pocketid.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListObjects)
+	}
+
+	objects := make([]object, 0, len(users)+len(groups)+len(oidcClients))
+	for _, u := range users {
+		objects = append(objects, object{kind: apisv1beta1.UserKind, id: u.ID, name: u.Username, owner: pocketid.Owner(u.CustomClaims)})
+	}
+	for _, g := range groups {
+		objects = append(objects, object{kind: apisv1beta1.GroupKind, id: g.ID, name: g.GroupName, owner: pocketid.Owner(g.CustomClaims)})
+	}
+	for _, c := range oidcClients {
+		objects = append(objects, object{kind: apisv1beta1.OIDCClientKind, id: c.ID, name: c.ClientName, owner: pocketid.Owner(c.CustomClaims)})
+	}
+	return objects, nil
+}
+
+// remove deletes an object from Pocket ID.
+func remove(ctx context.Context, svc pocketid.Service, o object) error {
+	switch o.kind {
+	case apisv1beta1.UserKind:
+		return svc.DeleteUser(ctx, o.id)
+	case apisv1beta1.GroupKind:
+		return svc.DeleteGroup(ctx, o.id)
+	default:
+		return svc.DeleteOIDCClient(ctx, o.id)
+	}
+}
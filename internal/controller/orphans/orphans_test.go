@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+	"github.com/crossplane/provider-pocketid/internal/readonly"
+)
+
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func TestReconcile(t *testing.T) {
+	type want struct {
+		result  reconcile.Result
+		reasons []event.Reason
+		deletes []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   apisv1alpha1.ProviderConfigSpec
+		paused bool
+		dryRun bool
+		// adopted re-creates the managed resource of the user alice.
+		adopted bool
+		// created creates the managed resource of the OIDC client app after
+		// the cache was last synced.
+		created bool
+		want    want
+	}{
+		"Report": {
+			reason: "Objects created by a managed resource that no longer exists should only be reported by default.",
+			want:   want{result: reconcile.Result{RequeueAfter: sweepInterval}, reasons: []event.Reason{reasonOrphaned, reasonOrphaned}},
+		},
+		"Delete": {
+			reason: "Orphaned objects should be deleted with the Delete policy.",
+			spec:   apisv1alpha1.ProviderConfigSpec{OrphanPolicy: apisv1alpha1.OrphanPolicyDelete},
+			want: want{
+				result:  reconcile.Result{RequeueAfter: sweepInterval},
+				reasons: []event.Reason{reasonDeletedOrphaned, reasonDeletedOrphaned},
+				deletes: []string{"DELETE /api/users/gone-user", "DELETE /api/oidc/clients/gone-client"},
+			},
+		},
+		"Adopted": {
+			reason:  "Objects adopted by a managed resource re-created since they were created should not be deleted.",
+			spec:    apisv1alpha1.ProviderConfigSpec{OrphanPolicy: apisv1alpha1.OrphanPolicyDelete},
+			adopted: true,
+			want: want{
+				result:  reconcile.Result{RequeueAfter: sweepInterval},
+				reasons: []event.Reason{reasonDeletedOrphaned},
+				deletes: []string{"DELETE /api/oidc/clients/gone-client"},
+			},
+		},
+		"CreatedSinceCacheSync": {
+			reason:  "Objects whose managed resource was created after the cache was last synced should not be deleted.",
+			spec:    apisv1alpha1.ProviderConfigSpec{OrphanPolicy: apisv1alpha1.OrphanPolicyDelete},
+			created: true,
+			want: want{
+				result:  reconcile.Result{RequeueAfter: sweepInterval},
+				reasons: []event.Reason{reasonDeletedOrphaned},
+				deletes: []string{"DELETE /api/users/gone-user"},
+			},
+		},
+		"ReadOnly": {
+			reason: "Orphaned objects should only be reported in ReadOnly mode, whatever the policy.",
+			spec:   apisv1alpha1.ProviderConfigSpec{OrphanPolicy: apisv1alpha1.OrphanPolicyDelete, Mode: apisv1alpha1.ProviderConfigModeReadOnly},
+			want:   want{result: reconcile.Result{RequeueAfter: sweepInterval}, reasons: []event.Reason{reasonOrphaned, reasonOrphaned}},
+		},
+		"DryRun": {
+			reason: "Orphaned objects should be reported as they would have been deleted in dry run.",
+			spec:   apisv1alpha1.ProviderConfigSpec{OrphanPolicy: apisv1alpha1.OrphanPolicyDelete},
+			dryRun: true,
+			want:   want{result: reconcile.Result{RequeueAfter: sweepInterval}, reasons: []event.Reason{readonly.ReasonWouldDelete, readonly.ReasonWouldDelete}},
+		},
+		"Paused": {
+			reason: "The Pocket ID server of a paused ProviderConfig should not be swept until it is resumed.",
+			spec:   apisv1alpha1.ProviderConfigSpec{OrphanPolicy: apisv1alpha1.OrphanPolicyDelete},
+			paused: true,
+			want:   want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.dryRun {
+				readonly.DryRun(logging.NewNopLogger())
+				defer readonly.DryRun(nil)
+			}
+
+			srv := pocketidfake.NewServer()
+			defer srv.Close()
+			srv.AddUser(pocketid.User{ID: "gone-user", Username: "alice", CustomClaims: map[string]string{pocketid.OwnerClaim: "uid-gone"}})
+			srv.AddUser(pocketid.User{ID: "kept-user", Username: "bob", CustomClaims: map[string]string{pocketid.OwnerClaim: "uid-user"}})
+			srv.AddUser(pocketid.User{ID: "human-user", Username: "carol"})
+			srv.AddGroup(pocketid.Group{ID: "kept-group", GroupName: "admins", CustomClaims: map[string]string{pocketid.OwnerClaim: "uid-group"}})
+			srv.AddOIDCClient(pocketid.OIDCClient{ID: "gone-client", ClientName: "app", CustomClaims: map[string]string{pocketid.OwnerClaim: "uid-gone-too"}})
+			svc, err := pocketid.NewClientFromConfig(srv.Config())
+			if err != nil {
+				t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+			}
+
+			list := func(created bool) test.MockListFn {
+				return test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					switch l := obj.(type) {
+					case *apisv1beta1.UserList:
+						l.Items = []apisv1beta1.User{{}}
+						l.Items[0].SetUID("uid-user")
+						if tc.adopted {
+							l.Items = append(l.Items, apisv1beta1.User{})
+							l.Items[1].SetUID("uid-recreated")
+							meta.SetExternalName(&l.Items[1], "alice")
+						}
+					case *apisv1beta1.GroupList:
+						l.Items = []apisv1beta1.Group{{}}
+						l.Items[0].SetUID("uid-group")
+					case *apisv1beta1.OIDCClientList:
+						if created {
+							l.Items = []apisv1beta1.OIDCClient{{}}
+							l.Items[0].SetUID("uid-gone-too")
+						}
+					}
+					return nil
+				})
+			}
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*apisv1alpha1.ProviderConfig).Spec = tc.spec
+					if tc.paused {
+						meta.AddAnnotations(obj, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+					}
+					return nil
+				}),
+				MockList: list(false),
+			}
+			rec := &recorder{}
+			r := &reconciler{
+				kube:   kube,
+				reader: &test.MockClient{MockList: list(tc.created)},
+				record: rec,
+				log:    logging.NewNopLogger(),
+				connect: func(context.Context, *apisv1alpha1.ProviderConfig) (pocketid.Service, error) {
+					return svc, nil
+				},
+			}
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			if err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, rec.reasons); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want events, +got:\n%s\n", tc.reason, diff)
+			}
+			var deletes []string
+			for _, req := range srv.Requests() {
+				if strings.HasPrefix(req, http.MethodDelete+" ") {
+					deletes = append(deletes, req)
+				}
+			}
+			if diff := cmp.Diff(tc.want.deletes, deletes); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want deletes, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -17,7 +17,12 @@ limitations under the License.
 package controller
 
 import (
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/provider-pocketid/internal/controller/adminuser"
@@ -25,23 +30,94 @@ import (
 	"github.com/crossplane/provider-pocketid/internal/controller/group"
 	"github.com/crossplane/provider-pocketid/internal/controller/oidcclient"
 	oidcclientgroupbinding "github.com/crossplane/provider-pocketid/internal/controller/oidcclientgroupbinding"
+	"github.com/crossplane/provider-pocketid/internal/controller/orphans"
+	"github.com/crossplane/provider-pocketid/internal/controller/snapshot"
 	"github.com/crossplane/provider-pocketid/internal/controller/user"
 	"github.com/crossplane/provider-pocketid/internal/controller/usergroupbinding"
 )
 
-// Setup creates all PocketId controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options) error{
-		config.Setup,
-		user.Setup,
-		adminuser.Setup,
-		group.Setup,
-		oidcclient.Setup,
-		usergroupbinding.Setup,
-		oidcclientgroupbinding.Setup,
-	} {
-		if err := setup(mgr, o); err != nil {
+// kinds are the setup functions of the managed resource controllers, by
+// lowercase kind.
+var kinds = map[string]func(ctrl.Manager, controller.Options) error{
+	"user":                   user.Setup,
+	"adminuser":              adminuser.Setup,
+	"group":                  group.Setup,
+	"oidcclient":             oidcclient.Setup,
+	"usergroupbinding":       usergroupbinding.Setup,
+	"oidcclientgroupbinding": oidcclientgroupbinding.Setup,
+}
+
+// KindOptions select the managed resource controllers that are set up.
+type KindOptions struct {
+	// Enabled are the lowercase kinds whose controllers are set up. All of
+	// them are if empty.
+	Enabled []string
+
+	// MaxConcurrentReconciles overrides that of the controller options for
+	// the kinds it holds.
+	MaxConcurrentReconciles map[string]int
+}
+
+// ParseKindOptions parses a comma-separated list of enabled kinds, and the
+// maximum concurrent reconciles of kinds as counts by kind.
+func ParseKindOptions(enabled string, maxReconciles map[string]string) (KindOptions, error) {
+	ko := KindOptions{MaxConcurrentReconciles: map[string]int{}}
+	for _, k := range strings.Split(enabled, ",") {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "" {
+			continue
+		}
+		if _, ok := kinds[k]; !ok {
+			return KindOptions{}, errors.Errorf("unknown kind %q, want one of %s", k, strings.Join(Kinds(), ", "))
+		}
+		ko.Enabled = append(ko.Enabled, k)
+	}
+	for k, v := range maxReconciles {
+		k = strings.ToLower(k)
+		if _, ok := kinds[k]; !ok {
+			return KindOptions{}, errors.Errorf("unknown kind %q, want one of %s", k, strings.Join(Kinds(), ", "))
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return KindOptions{}, errors.Errorf("invalid maximum concurrent reconciles %q of kind %s, want a positive integer", v, k)
+		}
+		ko.MaxConcurrentReconciles[k] = n
+	}
+	return ko, nil
+}
+
+// Kinds returns the lowercase kinds whose controllers may be enabled, sorted.
+func Kinds() []string {
+	ks := make([]string, 0, len(kinds))
+	for k := range kinds {
+		ks = append(ks, k)
+	}
+	slices.Sort(ks)
+	return ks
+}
+
+// Setup creates the PocketId controllers enabled by the supplied kind
+// options with the supplied logger and adds them to the supplied manager. The
+// ProviderConfig and Snapshot controllers are always created.
+func Setup(mgr ctrl.Manager, o controller.Options, ko KindOptions) error {
+	if err := config.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := orphans.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := snapshot.Setup(mgr, o); err != nil {
+		return err
+	}
+	for _, k := range Kinds() {
+		if len(ko.Enabled) > 0 && !slices.Contains(ko.Enabled, k) {
+			continue
+		}
+		ok := o
+		if n, set := ko.MaxConcurrentReconciles[k]; set {
+			ok.MaxConcurrentReconciles = n
+		}
+		if err := kinds[k](mgr, ok); err != nil {
 			return err
 		}
 	}
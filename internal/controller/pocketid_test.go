@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseKindOptions(t *testing.T) {
+	type args struct {
+		enabled       string
+		maxReconciles map[string]string
+	}
+	type want struct {
+		ko  KindOptions
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AllKinds": {
+			reason: "No flags should enable every kind.",
+			want:   want{ko: KindOptions{MaxConcurrentReconciles: map[string]int{}}},
+		},
+		"SomeKinds": {
+			reason: "Listed kinds should be enabled, whatever their case, along with their concurrency.",
+			args: args{
+				enabled:       "OIDCClient, group",
+				maxReconciles: map[string]string{"oidcclient": "5"},
+			},
+			want: want{ko: KindOptions{Enabled: []string{"oidcclient", "group"}, MaxConcurrentReconciles: map[string]int{"oidcclient": 5}}},
+		},
+		"UnknownKind": {
+			reason: "Unknown kinds should be rejected rather than silently ignored.",
+			args:   args{enabled: "client"},
+			want:   want{err: true},
+		},
+		"InvalidCount": {
+			reason: "Concurrent reconciles should be positive integers.",
+			args:   args{maxReconciles: map[string]string{"group": "0"}},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseKindOptions(tc.args.enabled, tc.args.maxReconciles)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nParseKindOptions(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.ko, got); diff != "" {
+				t.Errorf("\n%s\nParseKindOptions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
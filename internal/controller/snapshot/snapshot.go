@@ -0,0 +1,317 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot takes point-in-time backups of the configuration of Pocket
+// ID servers, as requested by Snapshots.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/importer"
+)
+
+const (
+	errGetSnapshot  = "cannot get Snapshot"
+	errGetPC        = "cannot get ProviderConfig"
+	errGetCreds     = "cannot get credentials"
+	errNewClient    = "cannot create new Service"
+	errGetVersion   = "cannot get Pocket ID version"
+	errCapture      = "cannot capture Pocket ID objects"
+	errWrite        = "cannot write snapshot"
+	errStore        = "cannot store snapshot"
+	errGetS3Creds   = "cannot get S3 credentials"
+	errTargetExists = "target already exists and was not written by this Snapshot"
+	errUpdateStatus = "cannot update Snapshot status"
+)
+
+// Event reasons.
+const (
+	reasonTaken      event.Reason = "TakenSnapshot"
+	reasonCannotTake event.Reason = "CannotTakeSnapshot"
+)
+
+// Labels and annotations of the Secrets and ConfigMaps snapshots are stored in.
+const (
+	// LabelSnapshot is the name of the Snapshot a Secret or ConfigMap holds.
+	LabelSnapshot = "pocketid.crossplane.io/snapshot"
+
+	// AnnotationSnapshotUID is the UID of the Snapshot a Secret or ConfigMap
+	// holds, so that retries overwrite what they stored but nothing else.
+	AnnotationSnapshotUID = "pocketid.crossplane.io/snapshot-uid"
+
+	// AnnotationFormat is the version of the format of a snapshot.
+	AnnotationFormat = "pocketid.crossplane.io/snapshot-format"
+
+	// AnnotationTakenAt is when a snapshot was taken, in RFC 3339 format.
+	AnnotationTakenAt = "pocketid.crossplane.io/taken-at"
+
+	// AnnotationPocketIDVersion is the version of the Pocket ID server a
+	// snapshot captured.
+	AnnotationPocketIDVersion = "pocketid.crossplane.io/pocket-id-version"
+)
+
+// Key is the key of the data of the Secrets and ConfigMaps snapshots are
+// stored in.
+const Key = "snapshot.yaml"
+
+// FormatVersion is the version of the format of the snapshots taken. In
+// version v1, a snapshot is a stream of YAML documents: the manifests of the
+// managed resources adopting each captured object, with the Orphan deletion
+// policy, as printed by pocketid-import.
+const FormatVersion = "v1"
+
+// uploadTimeout is the timeout of uploads to S3-compatible object storages.
+const uploadTimeout = 2 * time.Minute
+
+// Setup adds a controller that takes the snapshots requested by Snapshots.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := "snapshot/" + strings.ToLower(apisv1alpha1.SnapshotGroupKind)
+
+	r := &reconciler{
+		kube:    mgr.GetClient(),
+		record:  event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+		connect: connect(mgr.GetClient()),
+		http:    &http.Client{Timeout: uploadTimeout},
+		now:     time.Now,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&apisv1alpha1.Snapshot{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// connect returns a function connecting to the Pocket ID server of a
+// ProviderConfig with its credentials.
+func connect(kube client.Client) func(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (pocketid.Service, error) {
+	return func(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (pocketid.Service, error) {
+		data, err := clients.ExtractCredentials(ctx, kube, pc)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetCreds)
+		}
+		cfg, err := clients.NewConfig(ctx, kube, pc, data)
+		if err != nil {
+			return nil, errors.Wrap(err, errNewClient)
+		}
+		svc, err := clients.NewClient(cfg)
+		return svc, errors.Wrap(err, errNewClient)
+	}
+}
+
+// A reconciler takes the snapshot requested by a Snapshot, once.
+type reconciler struct {
+	kube    client.Client
+	record  event.Recorder
+	connect func(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (pocketid.Service, error)
+	http    *http.Client
+	now     func() time.Time
+}
+
+// Reconcile a Snapshot.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	s := &apisv1alpha1.Snapshot{}
+	if err := r.kube.Get(ctx, req.NamespacedName, s); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetSnapshot)
+	}
+	if meta.WasDeleted(s) || s.Status.TakenAt != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// Paused Snapshots are taken once resumed, which triggers a reconcile.
+	if meta.IsPaused(s) {
+		if s.GetCondition(xpv1.TypeSynced).Reason == xpv1.ReasonReconcilePaused {
+			return reconcile.Result{}, nil
+		}
+		s.SetConditions(xpv1.ReconcilePaused())
+		return reconcile.Result{}, errors.Wrap(r.kube.Status().Update(ctx, s), errUpdateStatus)
+	}
+
+	if err := r.take(ctx, s); err != nil {
+		r.record.Event(s, event.Warning(reasonCannotTake, err))
+		s.SetConditions(xpv1.Creating(), xpv1.ReconcileError(err))
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.kube.Status().Update(ctx, s), errUpdateStatus)
+	}
+
+	r.record.Event(s, event.Normal(reasonTaken, "Stored snapshot at "+s.Status.Location))
+	s.SetConditions(xpv1.Available(), xpv1.ReconcileSuccess())
+	return reconcile.Result{}, errors.Wrap(r.kube.Status().Update(ctx, s), errUpdateStatus)
+}
+
+// take the snapshot requested by the supplied Snapshot, recording what it
+// captured and where it was stored in its status.
+func (r *reconciler) take(ctx context.Context, s *apisv1alpha1.Snapshot) error {
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, types.NamespacedName{Name: s.Spec.ProviderConfigReference.Name}, pc); err != nil {
+		return errors.Wrap(err, errGetPC)
+	}
+	svc, err := r.connect(ctx, pc)
+	if err != nil {
+		return err
+	}
+	ctx = pocketid.WithRequestSource(ctx, apisv1alpha1.SnapshotKind+"/"+s.GetName())
+
+	takenAt := metav1.NewTime(r.now().UTC().Truncate(time.Second))
+	version, err := svc.GetVersion(ctx)
+	if err != nil {
+		return errors.Wrap(err, errGetVersion)
+	}
+	mgs, err := importer.Import(ctx, svc, importer.Options{
+		ProviderConfigName: pc.GetName(),
+		DeletionPolicy:     xpv1.DeletionOrphan,
+		SkipLDAP:           s.Spec.SkipLDAP,
+	})
+	if err != nil {
+		return errors.Wrap(err, errCapture)
+	}
+	b := &bytes.Buffer{}
+	if err := importer.Write(b, mgs); err != nil {
+		return errors.Wrap(err, errWrite)
+	}
+
+	md := map[string]string{
+		AnnotationFormat:  FormatVersion,
+		AnnotationTakenAt: takenAt.Format(time.RFC3339),
+	}
+	if version != "" {
+		md[AnnotationPocketIDVersion] = version
+	}
+	location, err := r.store(ctx, s, b.Bytes(), md)
+	if err != nil {
+		return errors.Wrap(err, errStore)
+	}
+
+	st := apisv1alpha1.SnapshotStatus{
+		ConditionedStatus: s.Status.ConditionedStatus,
+		TakenAt:           &takenAt,
+		PocketIDVersion:   version,
+		Location:          location,
+	}
+	for _, mg := range mgs {
+		switch mg.(type) {
+		case *apisv1beta1.User, *apisv1alpha1.AdminUser:
+			st.Users++
+		case *apisv1beta1.Group:
+			st.Groups++
+		case *apisv1beta1.OIDCClient:
+			st.OIDCClients++
+		default:
+			st.Bindings++
+		}
+	}
+	s.Status = st
+	return nil
+}
+
+// store the supplied snapshot, along with the supplied metadata, in the
+// target of the supplied Snapshot. It returns where it was stored.
+func (r *reconciler) store(ctx context.Context, s *apisv1alpha1.Snapshot, b []byte, md map[string]string) (string, error) {
+	t := s.Spec.Target
+	switch {
+	case t.SecretRef != nil:
+		loc := "Secret/" + t.SecretRef.Namespace + "/" + t.SecretRef.Name
+		return loc, r.write(ctx, s, &corev1.Secret{Data: map[string][]byte{Key: b}}, t.SecretRef, loc, md)
+	case t.ConfigMapRef != nil:
+		loc := "ConfigMap/" + t.ConfigMapRef.Namespace + "/" + t.ConfigMapRef.Name
+		return loc, r.write(ctx, s, &corev1.ConfigMap{Data: map[string]string{Key: string(b)}}, t.ConfigMapRef, loc, md)
+	case t.S3 != nil:
+		key := t.S3.Key
+		if key == "" {
+			key = s.GetName() + ".yaml"
+		}
+		return "s3://" + t.S3.Bucket + "/" + key, r.upload(ctx, s, t.S3, key, b, md)
+	default:
+		return "", errors.New("no target is specified")
+	}
+}
+
+// write the supplied Secret or ConfigMap holding the snapshot of the supplied
+// Snapshot, to be found at the supplied location. Objects that already exist
+// are only overwritten if they hold the snapshot of the same Snapshot, as
+// written by an earlier attempt.
+func (r *reconciler) write(ctx context.Context, s *apisv1alpha1.Snapshot, obj client.Object, ref *apisv1alpha1.SnapshotObjectReference, loc string, md map[string]string) error {
+	obj.SetName(ref.Name)
+	obj.SetNamespace(ref.Namespace)
+	obj.SetLabels(map[string]string{LabelSnapshot: s.GetName()})
+	md[AnnotationSnapshotUID] = string(s.GetUID())
+	obj.SetAnnotations(md)
+
+	err := r.kube.Create(ctx, obj)
+	if !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	cur := obj.DeepCopyObject().(client.Object)
+	if err := r.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cur); err != nil {
+		return err
+	}
+	if cur.GetAnnotations()[AnnotationSnapshotUID] != string(s.GetUID()) {
+		return errors.Errorf("%s: %s", loc, errTargetExists)
+	}
+	obj.SetResourceVersion(cur.GetResourceVersion())
+	return r.kube.Update(ctx, obj)
+}
+
+// upload the supplied snapshot to the supplied S3-compatible object storage,
+// with its metadata as that of the object.
+func (r *reconciler) upload(ctx context.Context, s *apisv1alpha1.Snapshot, t *apisv1alpha1.S3Target, key string, b []byte, md map[string]string) error {
+	id, err := resource.ExtractSecret(ctx, r.kube, xpv1.CommonCredentialSelectors{SecretRef: &t.AccessKeyIDSecretRef})
+	if err != nil {
+		return errors.Wrap(err, errGetS3Creds)
+	}
+	secret, err := resource.ExtractSecret(ctx, r.kube, xpv1.CommonCredentialSelectors{SecretRef: &t.SecretAccessKeySecretRef})
+	if err != nil {
+		return errors.Wrap(err, errGetS3Creds)
+	}
+
+	headers := map[string]string{
+		"Content-Type":            "application/yaml",
+		"X-Amz-Meta-Snapshot":     s.GetName(),
+		"X-Amz-Meta-Snapshot-Uid": string(s.GetUID()),
+	}
+	for k, v := range md {
+		headers["X-Amz-Meta-"+strings.TrimPrefix(k, "pocketid.crossplane.io/")] = v
+	}
+	region := t.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	creds := s3Credentials{accessKeyID: strings.TrimSpace(string(id)), secretAccessKey: strings.TrimSpace(string(secret))}
+	return putObject(ctx, r.http, t.Endpoint, region, t.Bucket, key, b, headers, creds)
+}
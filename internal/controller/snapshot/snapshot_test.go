@@ -0,0 +1,350 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+)
+
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+	takenAt := metav1.NewTime(now)
+	errServer := &pocketid.APIError{StatusCode: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError)}
+
+	type want struct {
+		result  reconcile.Result
+		err     error
+		status  apisv1alpha1.SnapshotStatus
+		stored  client.Object
+		reasons []event.Reason
+	}
+
+	cases := map[string]struct {
+		reason   string
+		target   apisv1alpha1.SnapshotTarget
+		status   apisv1alpha1.SnapshotStatus
+		existing client.Object
+		fail     string
+		paused   bool
+		want     want
+	}{
+		"Secret": {
+			reason: "The snapshot should be written to a new Secret.",
+			target: apisv1alpha1.SnapshotTarget{SecretRef: &apisv1alpha1.SnapshotObjectReference{Namespace: "backups", Name: "pocketid"}},
+			want: want{
+				status: apisv1alpha1.SnapshotStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{Conditions: []xpv1.Condition{xpv1.Available(), xpv1.ReconcileSuccess()}},
+					TakenAt:           &takenAt,
+					PocketIDVersion:   pocketidfake.DefaultVersion,
+					Location:          "Secret/backups/pocketid",
+					Users:             2,
+					Groups:            1,
+					OIDCClients:       1,
+					Bindings:          2,
+				},
+				stored:  &corev1.Secret{},
+				reasons: []event.Reason{reasonTaken},
+			},
+		},
+		"ConfigMap": {
+			reason: "The snapshot should be written to a new ConfigMap.",
+			target: apisv1alpha1.SnapshotTarget{ConfigMapRef: &apisv1alpha1.SnapshotObjectReference{Namespace: "backups", Name: "pocketid"}},
+			want: want{
+				status: apisv1alpha1.SnapshotStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{Conditions: []xpv1.Condition{xpv1.Available(), xpv1.ReconcileSuccess()}},
+					TakenAt:           &takenAt,
+					PocketIDVersion:   pocketidfake.DefaultVersion,
+					Location:          "ConfigMap/backups/pocketid",
+					Users:             2,
+					Groups:            1,
+					OIDCClients:       1,
+					Bindings:          2,
+				},
+				stored:  &corev1.ConfigMap{},
+				reasons: []event.Reason{reasonTaken},
+			},
+		},
+		"AlreadyTaken": {
+			reason: "A Snapshot should only be taken once.",
+			target: apisv1alpha1.SnapshotTarget{SecretRef: &apisv1alpha1.SnapshotObjectReference{Namespace: "backups", Name: "pocketid"}},
+			status: apisv1alpha1.SnapshotStatus{TakenAt: &takenAt},
+			want:   want{status: apisv1alpha1.SnapshotStatus{TakenAt: &takenAt}},
+		},
+		"Paused": {
+			reason: "A paused Snapshot should report it is paused without being taken.",
+			target: apisv1alpha1.SnapshotTarget{SecretRef: &apisv1alpha1.SnapshotObjectReference{Namespace: "backups", Name: "pocketid"}},
+			paused: true,
+			want: want{status: apisv1alpha1.SnapshotStatus{
+				ConditionedStatus: xpv1.ConditionedStatus{Conditions: []xpv1.Condition{xpv1.ReconcilePaused()}},
+			}},
+		},
+		"TargetExists": {
+			reason: "A Secret that was not written by the Snapshot should not be overwritten.",
+			target: apisv1alpha1.SnapshotTarget{SecretRef: &apisv1alpha1.SnapshotObjectReference{Namespace: "backups", Name: "pocketid"}},
+			existing: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{AnnotationSnapshotUID: "someone-else"},
+			}},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+				status: apisv1alpha1.SnapshotStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{Conditions: []xpv1.Condition{
+						xpv1.Creating(),
+						xpv1.ReconcileError(errors.Wrap(errors.New("Secret/backups/pocketid: "+errTargetExists), errStore)),
+					}},
+				},
+				reasons: []event.Reason{reasonCannotTake},
+			},
+		},
+		"RetriedWrite": {
+			reason: "A Secret written by an earlier attempt of the Snapshot should be overwritten.",
+			target: apisv1alpha1.SnapshotTarget{SecretRef: &apisv1alpha1.SnapshotObjectReference{Namespace: "backups", Name: "pocketid"}},
+			existing: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{AnnotationSnapshotUID: "snapshot-uid"},
+			}},
+			want: want{
+				status: apisv1alpha1.SnapshotStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{Conditions: []xpv1.Condition{xpv1.Available(), xpv1.ReconcileSuccess()}},
+					TakenAt:           &takenAt,
+					PocketIDVersion:   pocketidfake.DefaultVersion,
+					Location:          "Secret/backups/pocketid",
+					Users:             2,
+					Groups:            1,
+					OIDCClients:       1,
+					Bindings:          2,
+				},
+				stored:  &corev1.Secret{},
+				reasons: []event.Reason{reasonTaken},
+			},
+		},
+		"CaptureError": {
+			reason: "Errors capturing the objects of Pocket ID should be reported, and the Snapshot retried.",
+			target: apisv1alpha1.SnapshotTarget{SecretRef: &apisv1alpha1.SnapshotObjectReference{Namespace: "backups", Name: "pocketid"}},
+			fail:   "/api/users",
+			want: want{
+				result: reconcile.Result{Requeue: true},
+				status: apisv1alpha1.SnapshotStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{Conditions: []xpv1.Condition{xpv1.Creating(), xpv1.ReconcileError(errors.Wrap(errors.Wrap(errServer, "cannot list users"), errCapture))}},
+				},
+				reasons: []event.Reason{reasonCannotTake},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := pocketidfake.NewServer()
+			defer srv.Close()
+			srv.AddGroup(pocketid.Group{ID: "admins-id", GroupName: "admins"})
+			srv.AddUser(pocketid.User{ID: "jdoe-id", Username: "jdoe"})
+			srv.AddOIDCClient(pocketid.OIDCClient{ID: "app-id", ClientName: "app"})
+			srv.AddUserToGroup("jdoe-id", "admins-id")
+			srv.AddClientToGroup("app-id", "admins-id")
+			if tc.fail != "" {
+				srv.Fail(http.MethodGet, tc.fail, http.StatusInternalServerError, 0)
+			}
+			svc, err := pocketid.NewClientFromConfig(srv.Config())
+			if err != nil {
+				t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+			}
+
+			var status *apisv1alpha1.SnapshotStatus
+			var stored client.Object
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *apisv1alpha1.Snapshot:
+						o.SetName("before-upgrade")
+						o.SetUID("snapshot-uid")
+						o.Spec = apisv1alpha1.SnapshotSpec{ProviderConfigReference: xpv1.Reference{Name: "default"}, Target: tc.target}
+						o.Status = tc.status
+						if tc.paused {
+							meta.AddAnnotations(o, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+						}
+					case *apisv1alpha1.ProviderConfig:
+						o.SetName(key.Name)
+					case *corev1.Secret:
+						tc.existing.(*corev1.Secret).DeepCopyInto(o)
+					}
+					return nil
+				},
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					if tc.existing != nil {
+						return kerrors.NewAlreadyExists(schema.GroupResource{Resource: "secrets"}, obj.GetName())
+					}
+					stored = obj
+					return nil
+				},
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					stored = obj
+					return nil
+				},
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					status = &obj.(*apisv1alpha1.Snapshot).Status
+					return nil
+				},
+			}
+			rec := &recorder{}
+			r := &reconciler{
+				kube:   kube,
+				record: rec,
+				connect: func(context.Context, *apisv1alpha1.ProviderConfig) (pocketid.Service, error) {
+					return svc, nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "before-upgrade"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got:\n%s\n", tc.reason, diff)
+			}
+			if status == nil {
+				status = &tc.status
+			}
+			if diff := cmp.Diff(tc.want.status, *status, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want status, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, rec.reasons); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want events, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.stored == nil {
+				if stored != nil {
+					t.Errorf("\n%s\nr.Reconcile(...): want nothing stored, got %T", tc.reason, stored)
+				}
+				return
+			}
+			if stored == nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): want %T stored, got nothing", tc.reason, tc.want.stored)
+			}
+			wantMeta := metav1.ObjectMeta{
+				Namespace: "backups",
+				Name:      "pocketid",
+				Labels:    map[string]string{LabelSnapshot: "before-upgrade"},
+				Annotations: map[string]string{
+					AnnotationSnapshotUID:     "snapshot-uid",
+					AnnotationFormat:          FormatVersion,
+					AnnotationTakenAt:         "2025-06-01T12:00:00Z",
+					AnnotationPocketIDVersion: pocketidfake.DefaultVersion,
+				},
+			}
+			var data string
+			switch o := stored.(type) {
+			case *corev1.Secret:
+				data = string(o.Data[Key])
+				if diff := cmp.Diff(wantMeta, o.ObjectMeta); diff != "" {
+					t.Errorf("\n%s\nr.Reconcile(...): -want metadata, +got:\n%s\n", tc.reason, diff)
+				}
+			case *corev1.ConfigMap:
+				data = o.Data[Key]
+				if diff := cmp.Diff(wantMeta, o.ObjectMeta); diff != "" {
+					t.Errorf("\n%s\nr.Reconcile(...): -want metadata, +got:\n%s\n", tc.reason, diff)
+				}
+			}
+			if n := strings.Count(data, "\nkind: "); n != 6 {
+				t.Errorf("\n%s\nr.Reconcile(...): want 6 manifests stored, got %d:\n%s", tc.reason, n, data)
+			}
+		})
+	}
+}
+
+func TestUpload(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	s3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = req
+		body, _ = io.ReadAll(req.Body)
+	}))
+	defer s3.Close()
+
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"id": []byte("AKID"), "secret": []byte("SECRET")}
+			return nil
+		}),
+	}
+	r := &reconciler{kube: kube, http: s3.Client()}
+	s := &apisv1alpha1.Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "before-upgrade", UID: "snapshot-uid"}}
+	target := &apisv1alpha1.S3Target{
+		Endpoint:                 s3.URL,
+		Bucket:                   "backups",
+		AccessKeyIDSecretRef:     xpv1.SecretKeySelector{Key: "id"},
+		SecretAccessKeySecretRef: xpv1.SecretKeySelector{Key: "secret"},
+	}
+
+	loc, err := r.store(context.Background(), &apisv1alpha1.Snapshot{ObjectMeta: s.ObjectMeta, Spec: apisv1alpha1.SnapshotSpec{Target: apisv1alpha1.SnapshotTarget{S3: target}}}, []byte("---\n"), map[string]string{AnnotationFormat: FormatVersion})
+	if err != nil {
+		t.Fatalf("r.store(...): %v", err)
+	}
+	if diff := cmp.Diff("s3://backups/before-upgrade.yaml", loc); diff != "" {
+		t.Errorf("r.store(...): -want location, +got:\n%s\n", diff)
+	}
+	if got == nil {
+		t.Fatal("r.store(...): want an upload, got none")
+	}
+	if diff := cmp.Diff(http.MethodPut+" /backups/before-upgrade.yaml", got.Method+" "+got.URL.Path); diff != "" {
+		t.Errorf("r.store(...): -want request, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("---\n", string(body)); diff != "" {
+		t.Errorf("r.store(...): -want body, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(FormatVersion, got.Header.Get("X-Amz-Meta-Snapshot-Format")); diff != "" {
+		t.Errorf("r.store(...): -want format metadata, +got:\n%s\n", diff)
+	}
+	if a := got.Header.Get("Authorization"); !strings.HasPrefix(a, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(a, "/us-east-1/s3/aws4_request") {
+		t.Errorf("r.store(...): want a request signed for us-east-1 with the access key ID, got Authorization %q", a)
+	}
+}
@@ -24,7 +24,9 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
@@ -37,8 +39,14 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
 
 	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/changelog"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
 	"github.com/crossplane/provider-pocketid/internal/features"
+	"github.com/crossplane/provider-pocketid/internal/inuse"
+	"github.com/crossplane/provider-pocketid/internal/readonly"
 )
 
 const (
@@ -46,35 +54,41 @@ const (
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errGetPC        = "cannot get ProviderConfig"
 	errGetCreds     = "cannot get credentials"
+	errCheckInUse   = "cannot check whether the resource is in use"
 
 	errNewClient = "cannot create new Service"
 )
 
 // newPocketIDService creates a new Pocket ID service
 var (
-	newPocketIDService = func(endpoint string, creds []byte) (interface{}, error) {
-		return pocketid.NewClientFromCredentials(endpoint, string(creds))
+	newPocketIDService = func(cfg pocketid.Config) (pocketid.Service, error) {
+		return clients.NewClient(cfg)
 	}
 )
 
 // Setup adds a controller that reconciles User managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
-	name := managed.ControllerName(apisv1alpha1.UserGroupKind)
+	name := managed.ControllerName(apisv1beta1.UserGroupKind)
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			newServiceFn: newPocketIDService,
+			recorder:     recorder,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(clients.PollIntervalHook(mgr.GetClient())),
+		managed.WithCriticalAnnotationUpdater(clients.NewCriticalAnnotationUpdater(mgr.GetClient())),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...),
 		managed.WithManagementPolicies(),
 	}
@@ -89,21 +103,22 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
 		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
-			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &apisv1alpha1.UserList{}, o.MetricOptions.PollStateMetricInterval,
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &apisv1beta1.UserList{}, o.MetricOptions.PollStateMetricInterval,
 		)
 		if err := mgr.Add(stateMetricsRecorder); err != nil {
-			return errors.Wrap(err, "cannot register MR state metrics recorder for kind apisv1alpha1.UserList")
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind apisv1beta1.UserList")
 		}
 	}
 
-	r := managed.NewReconciler(mgr, resource.ManagedKind(apisv1alpha1.UserGroupVersionKind), opts...)
+	r := managed.NewReconciler(mgr, resource.ManagedKind(apisv1beta1.UserGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
-		For(&apisv1alpha1.User{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(&apisv1beta1.User{}).
+		Watches(&apisv1beta1.UserGroupBinding{}, handler.EnqueueRequestsFromMapFunc(inuse.ReleasedUsers(mgr.GetClient())), builder.WithPredicates(inuse.BindingGone())).
+		Complete(ratelimiter.NewReconciler(name, clients.HonorRetryAfter(apisv1beta1.UserKind, r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -111,7 +126,8 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(endpoint string, creds []byte) (interface{}, error)
+	newServiceFn func(cfg pocketid.Config) (pocketid.Service, error)
+	recorder     event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -120,7 +136,7 @@ type connector struct {
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*apisv1alpha1.User)
+	cr, ok := mg.(*apisv1beta1.User)
 	if !ok {
 		return nil, errors.New(errNotUser)
 	}
@@ -134,28 +150,36 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	data, err := clients.ExtractCredentials(ctx, c.kube, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	svc, err := c.newServiceFn(pc.Spec.Endpoint, data)
+	cfg, err := clients.NewConfig(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	svc, err := c.newServiceFn(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc.(*pocketid.Client)}, nil
+	return readonly.Wrap(pc, clients.AttributeRequests(apisv1beta1.UserKind, &external{
+		service: svc,
+		kube:    c.kube,
+	}), c.recorder), nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service *pocketid.Client
+	service pocketid.Service
+	kube    client.Client
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*apisv1alpha1.User)
+	cr, ok := mg.(*apisv1beta1.User)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotUser)
 	}
@@ -178,7 +202,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	// Update status with observed values
-	cr.Status.AtProvider = apisv1alpha1.UserObservation{
+	cr.Status.AtProvider = apisv1beta1.UserObservation{
 		ID:           user.ID,
 		Username:     user.Username,
 		Email:        user.Email,
@@ -197,7 +221,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	// Check if resource is up to date
-	upToDate := isUserUpToDate(cr.Spec.ForProvider, *user)
+	upToDate := isUserUpToDate(cr.Spec.ForProvider, *user, clients.Owner(cr, user.CustomClaims))
 
 	cr.Status.SetConditions(xpv1.Available())
 
@@ -208,7 +232,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*apisv1alpha1.User)
+	cr, ok := mg.(*apisv1beta1.User)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotUser)
 	}
@@ -221,7 +245,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		Locale:       cr.Spec.ForProvider.Locale,
 		Disabled:     cr.Spec.ForProvider.Disabled,
 		IsAdmin:      false, // Regular users are never admin
-		CustomClaims: cr.Spec.ForProvider.CustomClaims,
+		CustomClaims: pocketid.WithOwner(cr.Spec.ForProvider.CustomClaims, clients.Creator(cr)),
 	}
 
 	user, err := c.service.CreateUser(ctx, req)
@@ -236,7 +260,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	cr, ok := mg.(*apisv1alpha1.User)
+	cr, ok := mg.(*apisv1beta1.User)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotUser)
 	}
@@ -252,23 +276,40 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		LastName:     cr.Spec.ForProvider.LastName,
 		Locale:       cr.Spec.ForProvider.Locale,
 		Disabled:     cr.Spec.ForProvider.Disabled,
-		CustomClaims: cr.Spec.ForProvider.CustomClaims,
+		CustomClaims: pocketid.WithOwner(cr.Spec.ForProvider.CustomClaims, clients.Owner(cr, cr.Status.AtProvider.CustomClaims)),
 	}
 
+	// Record what the update changes in the change log, whether it succeeds
+	// or not.
+	u := managed.ExternalUpdate{AdditionalDetails: userChanges(cr.Status.AtProvider, cr.Spec.ForProvider)}
+
 	_, err := c.service.UpdateUser(ctx, cr.Status.AtProvider.ID, req)
 	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update user")
+		return u, errors.Wrap(err, "failed to update user")
 	}
 
-	return managed.ExternalUpdate{}, nil
+	return u, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	cr, ok := mg.(*apisv1alpha1.User)
+	cr, ok := mg.(*apisv1beta1.User)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotUser)
 	}
 
+	// Refuse to delete the user while bindings still use it so that they are
+	// always removed first. The deletion is retried as soon as the last of them
+	// is gone.
+	users, err := inuse.UsersOfUser(ctx, c.kube, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, errors.Wrap(err, errCheckInUse)
+	}
+	if len(users) > 0 {
+		cr.SetConditions(conditions.InUse(users))
+		return managed.ExternalDelete{}, inuse.NewInUseError("user", users)
+	}
+	conditions.Clear(cr, conditions.NotInUse())
+
 	if cr.Status.AtProvider.ID != "" {
 		err := c.service.DeleteUser(ctx, cr.Status.AtProvider.ID)
 		if err != nil {
@@ -283,10 +324,24 @@ func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// userChanges describes the fields an update of the supplied observed user
+// to the supplied spec changes.
+func userChanges(observed apisv1beta1.UserObservation, spec apisv1beta1.UserParameters) managed.AdditionalDetails {
+	return changelog.Details(
+		changelog.Field("username", observed.Username, spec.Username),
+		changelog.Field("email", observed.Email, spec.Email),
+		changelog.Field("firstName", observed.FirstName, spec.FirstName),
+		changelog.Field("lastName", observed.LastName, spec.LastName),
+		changelog.Field("locale", observed.Locale, spec.Locale),
+		changelog.Field("disabled", observed.Disabled, spec.Disabled),
+		changelog.Claims("customClaims", observed.CustomClaims, spec.CustomClaims),
+	)
+}
+
 // isUserUpToDate compares the desired spec with the actual user state
 //
 //nolint:gocyclo
-func isUserUpToDate(spec apisv1alpha1.UserParameters, user pocketid.User) bool {
+func isUserUpToDate(spec apisv1beta1.UserParameters, user pocketid.User, owner string) bool {
 	if spec.Username != user.Username {
 		return false
 	}
@@ -306,11 +361,13 @@ func isUserUpToDate(spec apisv1alpha1.UserParameters, user pocketid.User) bool {
 		return false
 	}
 
-	// Compare custom claims
-	if len(spec.CustomClaims) != len(user.CustomClaims) {
+	// Compare custom claims, along with the owner claim of users created by
+	// the provider, which is re-stamped by the managed resource adopting them
+	claims := pocketid.WithOwner(spec.CustomClaims, owner)
+	if len(claims) != len(user.CustomClaims) {
 		return false
 	}
-	for k, v := range spec.CustomClaims {
+	for k, v := range claims {
 		if userVal, exists := user.CustomClaims[k]; !exists || userVal != v {
 			return false
 		}
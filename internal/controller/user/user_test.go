@@ -18,14 +18,33 @@ package user
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/apis"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
+	"github.com/crossplane/provider-pocketid/internal/controller/controllertest"
+	"github.com/crossplane/provider-pocketid/internal/inuse"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,40 +55,420 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type fields struct {
-		service *pocketid.Client
+// user returns a User managing the jdoe user, modified by the supplied
+// functions.
+func user(fns ...func(cr *apisv1beta1.User)) *apisv1beta1.User {
+	cr := &apisv1beta1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "jdoe", UID: "0b3e5a1c"},
+		Spec: apisv1beta1.UserSpec{
+			ForProvider: apisv1beta1.UserParameters{Username: "jdoe", Email: "jdoe@example.com", FirstName: "Jane"},
+		},
 	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+	for _, fn := range fns {
+		fn(cr)
 	}
+	return cr
+}
+
+// withID sets the ID of the Pocket ID user of a User, as observed.
+func withID(id string) func(cr *apisv1beta1.User) {
+	return func(cr *apisv1beta1.User) { cr.Status.AtProvider.ID = id }
+}
+
+// jdoe is the Pocket ID user managed by user().
+var jdoe = pocketid.User{ID: "jdoe-id", Username: "jdoe", Email: "jdoe@example.com", FirstName: "Jane"}
+
+// seedUser returns a seed adding the supplied Pocket ID user.
+func seedUser(u pocketid.User) controllertest.Seed {
+	return func(s *pocketidfake.Server) { s.AddUser(u) }
+}
+
+func TestObserve(t *testing.T) {
+	drifted := jdoe
+	drifted.FirstName = "Janet"
 
 	type want struct {
 		o   managed.ExternalObservation
+		id  string
 		err error
 	}
 
 	cases := map[string]struct {
 		reason string
-		fields fields
-		args   args
+		seeds  []controllertest.Seed
+		mg     resource.Managed
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotFound": {
+			reason: "A user missing from Pocket ID should be reported as not existing.",
+			mg:     user(),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"UpToDate": {
+			reason: "A user matching its spec should be reported as up to date, along with its ID.",
+			seeds:  []controllertest.Seed{seedUser(jdoe)},
+			mg:     user(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, id: "jdoe-id"},
+		},
+		"Drifted": {
+			reason: "A user changed in Pocket ID should be reported as needing an update.",
+			seeds:  []controllertest.Seed{seedUser(drifted)},
+			mg:     user(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}, id: "jdoe-id"},
+		},
+		"ExternalName": {
+			reason: "A user should be found by its external name rather than its username.",
+			seeds:  []controllertest.Seed{seedUser(pocketid.User{ID: "old-id", Username: "jane", Email: "jdoe@example.com", FirstName: "Jane"})},
+			mg: user(func(cr *apisv1beta1.User) {
+				meta.SetExternalName(cr, "jane")
+			}),
+			want: want{o: managed.ExternalObservation{ResourceExists: true}, id: "old-id"},
+		},
+		"APIError": {
+			reason: "Errors getting the user should be returned.",
+			seeds:  []controllertest.Seed{controllertest.Fail(http.MethodGet, "/api/users", http.StatusInternalServerError)},
+			mg:     user(),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to get user")},
+		},
+		"NotUser": {
+			reason: "Managed resources other than Users should be rejected.",
+			mg:     &fake.Managed{},
+			want:   want{err: errors.New(errNotUser)},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube()}
+			got, err := e.Observe(context.Background(), tc.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if cr, ok := tc.mg.(*apisv1beta1.User); ok {
+				if diff := cmp.Diff(tc.want.id, cr.Status.AtProvider.ID); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want ID, +got:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		mg     *apisv1beta1.User
+		want   want
+	}{
+		"Created": {
+			reason: "A user should be created as a regular user owned by its User, named after its username.",
+			mg:     user(),
+			want:   want{externalName: "jdoe"},
+		},
+		"APIError": {
+			reason: "Errors creating the user should be returned.",
+			seeds:  []controllertest.Seed{controllertest.Fail(http.MethodPost, "/api/users", http.StatusConflict)},
+			mg:     user(),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusConflict), "failed to create user")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube()}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			u, err := svc.GetUserByExternalName(context.Background(), "jdoe")
+			if err != nil || u == nil {
+				t.Fatalf("\n%s\ne.Create(...): want the user created, got %v", tc.reason, err)
+			}
+			if u.IsAdmin || pocketid.Owner(u.CustomClaims) != "0b3e5a1c" {
+				t.Errorf("\n%s\ne.Create(...): want a regular user owned by its User, got %+v", tc.reason, u)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	drifted := jdoe
+	drifted.FirstName = "Janet"
+	observed := func(cr *apisv1beta1.User) {
+		cr.Status.AtProvider = apisv1beta1.UserObservation{ID: "jdoe-id", Username: "jdoe", Email: "jdoe@example.com", FirstName: "Janet"}
+	}
+
+	// Updates record the fields they change in the change log, whether they
+	// succeed or not.
+	changes := managed.AdditionalDetails{"diff.firstName": `{"before":"Janet","after":"Jane"}`}
+
+	type want struct {
+		details managed.AdditionalDetails
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		mg     *apisv1beta1.User
+		want   want
+	}{
+		"Updated": {
+			reason: "A drifted user should be updated to match its spec, the fields it changes recorded in the change log.",
+			seeds:  []controllertest.Seed{seedUser(drifted)},
+			mg:     user(observed),
+			want:   want{details: changes},
+		},
+		"NoID": {
+			reason: "A user whose ID was never observed cannot be updated.",
+			mg:     user(),
+			want:   want{err: errors.New("user ID not found in status")},
+		},
+		"NotFound": {
+			reason: "Errors updating a user removed from Pocket ID should be returned.",
+			mg:     user(observed),
+			want:   want{details: changes, err: errors.Wrap(&pocketid.APIError{StatusCode: http.StatusNotFound, Message: "User not found"}, "failed to update user")},
+		},
+		"APIError": {
+			reason: "Errors updating the user should be returned.",
+			seeds:  []controllertest.Seed{seedUser(drifted), controllertest.Fail(http.MethodPut, "/api/users", http.StatusInternalServerError)},
+			mg:     user(observed),
+			want:   want{details: changes, err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to update user")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube()}
+			got, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.details, got.AdditionalDetails); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want change log details, +got:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if u, _ := srv.User("jdoe-id"); !isUserUpToDate(tc.mg.Spec.ForProvider, u, clients.Owner(tc.mg, u.CustomClaims)) {
+				t.Errorf("\n%s\ne.Update(...): want the user up to date, got %+v", tc.reason, u)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		calls []string
+		inUse corev1.ConditionStatus
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		kube   client.Client
+		mg     *apisv1beta1.User
+		want   want
+	}{
+		"Deleted": {
+			reason: "A user should be deleted from Pocket ID.",
+			seeds:  []controllertest.Seed{seedUser(jdoe)},
+			mg:     user(withID("jdoe-id")),
+			want:   want{calls: []string{http.MethodDelete}, inUse: corev1.ConditionUnknown},
+		},
+		"NoLongerInUse": {
+			reason: "A user no longer used by a binding should report it, and be deleted.",
+			seeds:  []controllertest.Seed{seedUser(jdoe)},
+			mg: user(withID("jdoe-id"), func(cr *apisv1beta1.User) {
+				cr.SetConditions(conditions.InUse([]string{"UserGroupBinding/jdoe-admins"}))
+			}),
+			want: want{calls: []string{http.MethodDelete}, inUse: corev1.ConditionFalse},
+		},
+		"NeverObserved": {
+			reason: "A user whose ID was never observed was never created, and should not be deleted.",
+			mg:     user(),
+			want:   want{inUse: corev1.ConditionUnknown},
+		},
+		"InUse": {
+			reason: "A user still used by a binding should not be deleted.",
+			seeds:  []controllertest.Seed{seedUser(jdoe)},
+			kube: &test.MockClient{MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+				if l, ok := obj.(*apisv1beta1.UserGroupBindingList); ok {
+					l.Items = []apisv1beta1.UserGroupBinding{{
+						ObjectMeta: metav1.ObjectMeta{Name: "jdoe-admins"},
+						Spec:       apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{UserIDRef: &xpv1.Reference{Name: "jdoe"}}},
+					}}
+				}
+				return nil
+			})},
+			mg:   user(withID("jdoe-id")),
+			want: want{inUse: corev1.ConditionTrue, err: inuse.NewInUseError("user", []string{"UserGroupBinding/jdoe-admins"})},
+		},
+		"APIError": {
+			reason: "Errors deleting the user should be returned.",
+			seeds:  []controllertest.Seed{seedUser(jdoe), controllertest.Fail(http.MethodDelete, "/api/users", http.StatusInternalServerError)},
+			mg:     user(withID("jdoe-id")),
+			want:   want{calls: []string{http.MethodDelete}, inUse: corev1.ConditionUnknown, err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to delete user")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			if tc.kube == nil {
+				tc.kube = controllertest.NewKube()
+			}
+			e := external{service: svc, kube: tc.kube}
+			_, err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.inUse, tc.mg.GetCondition(conditions.TypeInUse).Status); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want InUse status, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManagementPolicies(t *testing.T) {
+	observeCreate := xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate}
+	noDelete := xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionLateInitialize}
+
+	type want struct {
+		calls []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		policies xpv1.ManagementPolicies
+		exists   bool
+		drifted  bool
+		deleted  bool
+		want     want
+	}{
+		"ObserveOnlyNotCreated": {
+			reason:   "An Observe only user should never be created.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+		},
+		"ObserveOnlyNotUpdated": {
+			reason:   "An Observe only user should never be updated.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			exists:   true,
+			drifted:  true,
+		},
+		"ObserveOnlyNotDeleted": {
+			reason:   "Deleting an Observe only user should leave it in Pocket ID.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			exists:   true,
+			deleted:  true,
+		},
+		"CreateOnlyCreated": {
+			reason:   "A user with the Create policy should be created.",
+			policies: observeCreate,
+			want:     want{calls: []string{http.MethodPost}},
+		},
+		"CreateOnlyNotUpdated": {
+			reason:   "A user without the Update policy should never be updated.",
+			policies: observeCreate,
+			exists:   true,
+			drifted:  true,
+		},
+		"NoDeleteUpdated": {
+			reason:   "A user with the Update policy should be updated.",
+			policies: noDelete,
+			exists:   true,
+			drifted:  true,
+			want:     want{calls: []string{http.MethodPut}},
+		},
+		"NoDeleteNotDeleted": {
+			reason:   "Deleting a user without the Delete policy should leave it in Pocket ID.",
+			policies: noDelete,
+			exists:   true,
+			deleted:  true,
+		},
+		"FullyManagedDeleted": {
+			reason:   "Deleting a fully managed user should delete it from Pocket ID.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionAll},
+			exists:   true,
+			deleted:  true,
+			want:     want{calls: []string{http.MethodDelete}},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := pocketidfake.NewServer()
+			defer srv.Close()
+			if tc.exists {
+				firstName := "Jane"
+				if tc.drifted {
+					firstName = "Janet"
+				}
+				srv.AddUser(pocketid.User{Username: "jdoe", Email: "jdoe@example.com", FirstName: firstName})
+			}
+			svc, err := pocketid.NewClientFromConfig(srv.Config())
+			if err != nil {
+				t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+			}
+
+			cr := &apisv1beta1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "jdoe"},
+				Spec: apisv1beta1.UserSpec{
+					ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: tc.policies, DeletionPolicy: xpv1.DeletionDelete},
+					ForProvider:  apisv1beta1.UserParameters{Username: "jdoe", Email: "jdoe@example.com", FirstName: "Jane"},
+				},
+			}
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			kube := test.NewMockClient()
+			kube.MockGet = test.NewMockGetFn(nil, func(obj client.Object) error {
+				cr.DeepCopyInto(obj.(*apisv1beta1.User))
+				return nil
+			})
+
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(apisv1beta1.UserGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					return &external{service: svc, kube: kube}, nil
+				})),
+				managed.WithManagementPolicies(),
+			)
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "jdoe"}}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
 		})
 	}
 }
@@ -18,13 +18,18 @@ package usergroupbinding
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
@@ -37,8 +42,12 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
 
 	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	"github.com/crossplane/provider-pocketid/internal/conditions"
 	"github.com/crossplane/provider-pocketid/internal/features"
+	"github.com/crossplane/provider-pocketid/internal/readonly"
 )
 
 const (
@@ -51,31 +60,45 @@ const (
 	errResolveGroupID      = "cannot resolve group ID"
 )
 
+// reasonTargetGone is the event reason used when a binding is deleted after
+// the objects it binds have already been removed.
+const reasonTargetGone event.Reason = "TargetGone"
+
+// reasonLDAPMembership is the event reason used when deleting a binding leaves
+// a membership synchronised from LDAP in place.
+const reasonLDAPMembership event.Reason = "LDAPMembership"
+
 // newPocketIDService creates a new Pocket ID service
 var (
-	newPocketIDService = func(endpoint string, creds []byte) (interface{}, error) {
-		return pocketid.NewClientFromCredentials(endpoint, string(creds))
+	newPocketIDService = func(cfg pocketid.Config) (pocketid.Service, error) {
+		return clients.NewClient(cfg)
 	}
 )
 
 // Setup adds a controller that reconciles UserGroupBinding managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
-	name := managed.ControllerName(apisv1alpha1.UserGroupBindingGroupKind)
+	name := managed.ControllerName(apisv1beta1.UserGroupBindingGroupKind)
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			recorder:     recorder,
 			newServiceFn: newPocketIDService,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(clients.PollIntervalHook(mgr.GetClient())),
+		managed.WithCriticalAnnotationUpdater(clients.NewCriticalAnnotationUpdater(mgr.GetClient())),
+		managed.WithReferenceResolver(clients.NewReferenceResolver(mgr.GetClient(), resolvedIDs, recorder)),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...),
 		managed.WithManagementPolicies(),
 	}
@@ -90,21 +113,41 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
 		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
-			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &apisv1alpha1.UserGroupBindingList{}, o.MetricOptions.PollStateMetricInterval,
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &apisv1beta1.UserGroupBindingList{}, o.MetricOptions.PollStateMetricInterval,
 		)
 		if err := mgr.Add(stateMetricsRecorder); err != nil {
-			return errors.Wrap(err, "cannot register MR state metrics recorder for kind apisv1alpha1.UserGroupBindingList")
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind apisv1beta1.UserGroupBindingList")
 		}
 	}
 
-	r := managed.NewReconciler(mgr, resource.ManagedKind(apisv1alpha1.UserGroupBindingGroupVersionKind), opts...)
+	r := managed.NewReconciler(mgr, resource.ManagedKind(apisv1beta1.UserGroupBindingGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
-		WithEventFilter(resource.DesiredStateChanged()).
-		For(&apisv1alpha1.UserGroupBinding{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(&apisv1beta1.UserGroupBinding{}, builder.WithPredicates(resource.DesiredStateChanged())).
+		Watches(&apisv1beta1.User{}, handler.EnqueueRequestsFromMapFunc(clients.EnqueueReferencing(mgr.GetClient(), newList, userIDReference)), builder.WithPredicates(clients.ReferenceChanged())).
+		Watches(&apisv1beta1.Group{}, handler.EnqueueRequestsFromMapFunc(clients.EnqueueReferencing(mgr.GetClient(), newList, groupIDReference)), builder.WithPredicates(clients.ReferenceChanged())).
+		Complete(ratelimiter.NewReconciler(name, clients.HonorRetryAfter(apisv1beta1.UserGroupBindingKind, r), o.GlobalRateLimiter))
+}
+
+// newList returns an empty list of UserGroupBindings.
+func newList() resource.ManagedList {
+	return &apisv1beta1.UserGroupBindingList{}
+}
+
+// userIDReference returns the user ID of a UserGroupBinding, along with the
+// reference and selector it is resolved from.
+func userIDReference(mg resource.Managed) (string, *xpv1.Reference, *xpv1.Selector) {
+	p := mg.(*apisv1beta1.UserGroupBinding).Spec.ForProvider
+	return p.UserID, p.UserIDRef, p.UserIDSelector
+}
+
+// groupIDReference returns the group ID of a UserGroupBinding, along with the
+// reference and selector it is resolved from.
+func groupIDReference(mg resource.Managed) (string, *xpv1.Reference, *xpv1.Selector) {
+	p := mg.(*apisv1beta1.UserGroupBinding).Spec.ForProvider
+	return p.GroupID, p.GroupIDRef, p.GroupIDSelector
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -112,7 +155,8 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(endpoint string, creds []byte) (interface{}, error)
+	recorder     event.Recorder
+	newServiceFn func(cfg pocketid.Config) (pocketid.Service, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -121,7 +165,7 @@ type connector struct {
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*apisv1alpha1.UserGroupBinding)
+	cr, ok := mg.(*apisv1beta1.UserGroupBinding)
 	if !ok {
 		return nil, errors.New(errNotUserGroupBinding)
 	}
@@ -135,48 +179,72 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	data, err := clients.ExtractCredentials(ctx, c.kube, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	svc, err := c.newServiceFn(pc.Spec.Endpoint, data)
+	cfg, err := clients.NewConfig(ctx, c.kube, pc, data)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{
-		service: svc.(*pocketid.Client),
-		kube:    c.kube,
-	}, nil
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return readonly.Wrap(pc, clients.AttributeRequests(apisv1beta1.UserGroupBindingKind, &external{
+		service:  svc,
+		kube:     c.kube,
+		recorder: c.recorder,
+	}), c.recorder), nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service *pocketid.Client
-	kube    client.Client
+	service  pocketid.Service
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*apisv1alpha1.UserGroupBinding)
+	cr, ok := mg.(*apisv1beta1.UserGroupBinding)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotUserGroupBinding)
 	}
 
 	// Resolve user ID
 	userID, err := c.resolveUserID(ctx, cr)
+	if meta.WasDeleted(cr) && clients.IsTargetGone(err) {
+		c.recorder.Event(cr, event.Normal(reasonTargetGone, "Referenced user no longer exists, nothing to remove"))
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if clients.IsDependencyNotReady(err) {
+		return clients.WaitForDependency(cr, errors.Wrap(err, errResolveUserID)), nil
+	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errResolveUserID)
 	}
 
 	// Resolve group ID
 	groupID, err := c.resolveGroupID(ctx, cr)
+	if meta.WasDeleted(cr) && clients.IsTargetGone(err) {
+		c.recorder.Event(cr, event.Normal(reasonTargetGone, "Referenced group no longer exists, nothing to remove"))
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if clients.IsDependencyNotReady(err) {
+		return clients.WaitForDependency(cr, errors.Wrap(err, errResolveGroupID)), nil
+	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errResolveGroupID)
 	}
 
+	conditions.Clear(cr, conditions.DependencyReady())
+	cr.Status.AtProvider.ResolvedUserID = userID
+	cr.Status.AtProvider.ResolvedGroupID = groupID
+
 	// Check if binding exists
 	exists, err := c.service.IsUserInGroup(ctx, userID, groupID)
 	if err != nil {
@@ -184,11 +252,18 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	if !exists {
+		cr.Status.AtProvider.EstablishedAt = nil
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
+	established := cr.Status.AtProvider.EstablishedAt
+	if established == nil {
+		now := metav1.Now()
+		established = &now
+	}
+
 	// Get user and group details for status
 	user, err := c.service.GetUser(ctx, userID)
 	if err != nil {
@@ -200,9 +275,21 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get group")
 	}
 
+	// The next LDAP sync would immediately re-add a membership that comes from
+	// LDAP, so it is left alone on deletion unless explicitly asked otherwise.
+	origin := membershipOrigin(group)
+	if meta.WasDeleted(cr) && origin == apisv1beta1.MembershipOriginLDAP && !cr.Spec.ForProvider.RemoveLDAPMembership {
+		c.recorder.Event(cr, event.Normal(reasonLDAPMembership, "Membership is synchronised from LDAP, leaving it in place"))
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
 	// Update status with observed values
-	cr.Status.AtProvider = apisv1alpha1.UserGroupBindingObservation{
-		User: apisv1alpha1.UserObservation{
+	cr.Status.AtProvider = apisv1beta1.UserGroupBindingObservation{
+		ResolvedUserID:   userID,
+		ResolvedGroupID:  groupID,
+		MembershipOrigin: origin,
+		EstablishedAt:    established,
+		User: apisv1beta1.UserObservation{
 			ID:           user.ID,
 			Username:     user.Username,
 			Email:        user.Email,
@@ -214,7 +301,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 			UserGroups:   user.UserGroups,
 			CustomClaims: user.CustomClaims,
 		},
-		Group: apisv1alpha1.GroupObservation{
+		Group: apisv1beta1.GroupObservation{
 			ID:           group.ID,
 			Name:         group.GroupName,
 			FriendlyName: group.FriendlyName,
@@ -236,7 +323,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*apisv1alpha1.UserGroupBinding)
+	cr, ok := mg.(*apisv1beta1.UserGroupBinding)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotUserGroupBinding)
 	}
@@ -271,21 +358,39 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	cr, ok := mg.(*apisv1alpha1.UserGroupBinding)
+	cr, ok := mg.(*apisv1beta1.UserGroupBinding)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotUserGroupBinding)
 	}
 
-	// Resolve user ID
-	userID, err := c.resolveUserID(ctx, cr)
-	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, errResolveUserID)
+	// Prefer the IDs recorded when the binding was observed, as the referenced
+	// user or group may already be gone.
+	userID, groupID, ok := parseExternalName(meta.GetExternalName(cr))
+	if !ok {
+		userID, groupID = cr.Status.AtProvider.ResolvedUserID, cr.Status.AtProvider.ResolvedGroupID
 	}
 
-	// Resolve group ID
-	groupID, err := c.resolveGroupID(ctx, cr)
-	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, errResolveGroupID)
+	var err error
+	if userID == "" {
+		userID, err = c.resolveUserID(ctx, cr)
+		if clients.IsTargetGone(err) {
+			c.recorder.Event(cr, event.Normal(reasonTargetGone, "Referenced user no longer exists, nothing to remove"))
+			return managed.ExternalDelete{}, nil
+		}
+		if err != nil {
+			return managed.ExternalDelete{}, errors.Wrap(err, errResolveUserID)
+		}
+	}
+
+	if groupID == "" {
+		groupID, err = c.resolveGroupID(ctx, cr)
+		if clients.IsTargetGone(err) {
+			c.recorder.Event(cr, event.Normal(reasonTargetGone, "Referenced group no longer exists, nothing to remove"))
+			return managed.ExternalDelete{}, nil
+		}
+		if err != nil {
+			return managed.ExternalDelete{}, errors.Wrap(err, errResolveGroupID)
+		}
 	}
 
 	// Remove user from group
@@ -301,44 +406,98 @@ func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// membershipOrigin returns where memberships of the supplied group come from.
+// Pocket ID keeps the members of groups synchronised from LDAP in line with
+// the directory.
+func membershipOrigin(group *pocketid.Group) apisv1beta1.MembershipOrigin {
+	if group.LdapID != "" {
+		return apisv1beta1.MembershipOriginLDAP
+	}
+	return apisv1beta1.MembershipOriginManual
+}
+
+// parseExternalName splits an external name of the form <userID>:<groupID>.
+func parseExternalName(en string) (userID, groupID string, ok bool) {
+	userID, groupID, ok = strings.Cut(en, ":")
+	return userID, groupID, ok && userID != "" && groupID != ""
+}
+
+// resolvedIDs sets the user and group IDs of the supplied binding to those its
+// references last resolved to, as recorded in its status, wherever they are
+// referenced or selected but not resolved. It reports whether both IDs are
+// known.
+func resolvedIDs(mg resource.Managed) bool {
+	cr, ok := mg.(*apisv1beta1.UserGroupBinding)
+	if !ok {
+		return false
+	}
+	p := &cr.Spec.ForProvider
+	if p.UserID == "" && (p.UserIDRef != nil || p.UserIDSelector != nil) {
+		p.UserID = cr.Status.AtProvider.ResolvedUserID
+	}
+	if p.GroupID == "" && (p.GroupIDRef != nil || p.GroupIDSelector != nil) {
+		p.GroupID = cr.Status.AtProvider.ResolvedGroupID
+	}
+	return (p.UserID != "" || p.UserIDRef == nil && p.UserIDSelector == nil) &&
+		(p.GroupID != "" || p.GroupIDRef == nil && p.GroupIDSelector == nil)
+}
+
 // resolveUserID resolves the user ID from the binding spec
-func (c *external) resolveUserID(ctx context.Context, cr *apisv1alpha1.UserGroupBinding) (string, error) {
+func (c *external) resolveUserID(ctx context.Context, cr *apisv1beta1.UserGroupBinding) (string, error) {
 	if cr.Spec.ForProvider.UserID != "" {
 		return cr.Spec.ForProvider.UserID, nil
 	}
 
-	if cr.Spec.ForProvider.UserIDRef != nil {
-		user := &apisv1alpha1.User{}
-		if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.Spec.ForProvider.UserIDRef.Name}, user); err != nil {
-			return "", errors.Wrap(err, "failed to get referenced user")
+	if name := cr.Spec.ForProvider.Username; name != "" {
+		user, err := c.service.GetUserByExternalName(ctx, name)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to look up user by name")
 		}
-		if user.Status.AtProvider.ID == "" {
-			return "", errors.New("referenced user ID is not available")
+		if user == nil {
+			return "", clients.DependencyNotReady(fmt.Sprintf("user %q does not exist", name))
 		}
-		return user.Status.AtProvider.ID, nil
+		return user.ID, nil
 	}
 
-	// TODO: Implement selector logic if needed
-	return "", errors.New("user ID, userIdRef, or userIdSelector must be specified")
+	// References and selectors are resolved into UserID before the binding is
+	// observed, unless it is deleted first, in which case the ID they last
+	// resolved to is used.
+	if cr.Spec.ForProvider.UserIDRef != nil || cr.Spec.ForProvider.UserIDSelector != nil {
+		if id := cr.Status.AtProvider.ResolvedUserID; id != "" {
+			return id, nil
+		}
+		return "", clients.DependencyNotReady("referenced user ID has not been resolved")
+	}
+
+	return "", errors.New("user ID, username, userIdRef, or userIdSelector must be specified")
 }
 
 // resolveGroupID resolves the group ID from the binding spec
-func (c *external) resolveGroupID(ctx context.Context, cr *apisv1alpha1.UserGroupBinding) (string, error) {
+func (c *external) resolveGroupID(ctx context.Context, cr *apisv1beta1.UserGroupBinding) (string, error) {
 	if cr.Spec.ForProvider.GroupID != "" {
 		return cr.Spec.ForProvider.GroupID, nil
 	}
 
-	if cr.Spec.ForProvider.GroupIDRef != nil {
-		group := &apisv1alpha1.Group{}
-		if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.Spec.ForProvider.GroupIDRef.Name}, group); err != nil {
-			return "", errors.Wrap(err, "failed to get referenced group")
+	if name := cr.Spec.ForProvider.GroupName; name != "" {
+		group, err := c.service.GetGroupByExternalName(ctx, name)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to look up group by name")
 		}
-		if group.Status.AtProvider.ID == "" {
-			return "", errors.New("referenced group ID is not available")
+		if group == nil {
+			return "", clients.DependencyNotReady(fmt.Sprintf("group %q does not exist", name))
+		}
+		return group.ID, nil
+	}
+
+	// References and selectors are resolved into GroupID before the binding is
+	// observed, unless it is deleted first, in which case the ID they last
+	// resolved to is used.
+	if cr.Spec.ForProvider.GroupIDRef != nil || cr.Spec.ForProvider.GroupIDSelector != nil {
+		if id := cr.Status.AtProvider.ResolvedGroupID; id != "" {
+			return id, nil
 		}
-		return group.Status.AtProvider.ID, nil
+		return "", clients.DependencyNotReady("referenced group ID has not been resolved")
 	}
 
-	// TODO: Implement selector logic if needed
-	return "", errors.New("group ID, groupIdRef, or groupIdSelector must be specified")
+	return "", errors.New("group ID, groupName, groupIdRef, or groupIdSelector must be specified")
 }
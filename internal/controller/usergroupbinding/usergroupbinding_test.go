@@ -18,14 +18,34 @@ package usergroupbinding
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/apis"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients"
 	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+	"github.com/crossplane/provider-pocketid/internal/controller/controllertest"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,16 +56,37 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type fields struct {
-		service *pocketid.Client
-	}
+var errBoom = errors.New("boom")
 
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+// binding returns a UserGroupBinding of the jdoe user to the admins group,
+// modified by the supplied functions.
+func binding(fns ...func(cr *apisv1beta1.UserGroupBinding)) *apisv1beta1.UserGroupBinding {
+	cr := &apisv1beta1.UserGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "jdoe-admins"},
+		Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+			UserID:  "jdoe-id",
+			GroupID: "admins-id",
+		}},
+	}
+	for _, fn := range fns {
+		fn(cr)
 	}
+	return cr
+}
+
+// seeds adding the jdoe user and admins group, and adding the user to the
+// group if it is bound.
+func seeds(bound bool) []controllertest.Seed {
+	return []controllertest.Seed{func(s *pocketidfake.Server) {
+		s.AddUser(pocketid.User{ID: "jdoe-id", Username: "jdoe", Email: "jdoe@example.com", FirstName: "Jane"})
+		s.AddGroup(pocketid.Group{ID: "admins-id", GroupName: "admins", FriendlyName: "Admins"})
+		if bound {
+			s.AddUserToGroup("jdoe-id", "admins-id")
+		}
+	}}
+}
 
+func TestObserve(t *testing.T) {
 	type want struct {
 		o   managed.ExternalObservation
 		err error
@@ -53,17 +94,69 @@ func TestObserve(t *testing.T) {
 
 	cases := map[string]struct {
 		reason string
-		fields fields
-		args   args
+		seeds  []controllertest.Seed
+		kube   client.Client
+		mg     resource.Managed
 		want   want
 	}{
-		// TODO: Add test cases.
+		"Bound": {
+			reason: "A user member of the group should be reported as bound.",
+			seeds:  seeds(true),
+			mg:     binding(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"NotBound": {
+			reason: "A user not member of the group should be reported as not bound.",
+			seeds:  seeds(false),
+			mg:     binding(),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"UserNotFound": {
+			reason: "A binding of a user missing from Pocket ID should be reported as not bound.",
+			mg:     binding(),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"WaitingForDependency": {
+			reason: "A referenced user without an ID should not be reported as an error.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			mg: binding(func(cr *apisv1beta1.UserGroupBinding) {
+				cr.Spec.ForProvider.UserID = ""
+				cr.Spec.ForProvider.UserIDRef = &xpv1.Reference{Name: "jdoe"}
+			}),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"DeletedResolvedBefore": {
+			reason: "A deleted binding whose reference was never resolved into its spec should use the ID recorded in its status.",
+			seeds:  seeds(true),
+			mg: binding(func(cr *apisv1beta1.UserGroupBinding) {
+				cr.SetDeletionTimestamp(&metav1.Time{})
+				cr.Spec.ForProvider.UserID = ""
+				cr.Spec.ForProvider.UserIDRef = &xpv1.Reference{Name: "jdoe"}
+				cr.Status.AtProvider.ResolvedUserID = "jdoe-id"
+			}),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"APIError": {
+			reason: "Errors checking the membership should be returned.",
+			seeds:  append(seeds(true), controllertest.Fail(http.MethodGet, "/api/users", http.StatusInternalServerError)),
+			mg:     binding(),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to check user group binding")},
+		},
+		"NotUserGroupBinding": {
+			reason: "Managed resources other than UserGroupBindings should be rejected.",
+			mg:     &fake.Managed{},
+			want:   want{err: errors.New(errNotUserGroupBinding)},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			_, svc := controllertest.NewService(t, tc.seeds...)
+			if tc.kube == nil {
+				tc.kube = controllertest.NewKube()
+			}
+			e := external{service: svc, kube: tc.kube, recorder: event.NewNopRecorder()}
+			got, err := e.Observe(context.Background(), tc.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
@@ -73,3 +166,407 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		want   want
+	}{
+		"Created": {
+			reason: "The user should be added to the group, the binding named after both.",
+			seeds:  seeds(false),
+			want:   want{externalName: "jdoe-id:admins-id"},
+		},
+		"APIError": {
+			reason: "Errors adding the user to the group should be returned.",
+			seeds:  append(seeds(false), controllertest.Fail(http.MethodPost, "/api/users", http.StatusInternalServerError)),
+			want:   want{err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to create user group binding")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube(), recorder: event.NewNopRecorder()}
+			cr := binding()
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got:\n%s\n", tc.reason, diff)
+			}
+			if u, _ := srv.User("jdoe-id"); err == nil && len(u.UserGroups) != 1 {
+				t.Errorf("\n%s\ne.Create(...): want the user added to the group, got groups %v", tc.reason, u.UserGroups)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		seeds  []controllertest.Seed
+		mg     *apisv1beta1.UserGroupBinding
+		want   want
+	}{
+		"Deleted": {
+			reason: "The user should be removed from the group.",
+			seeds:  seeds(true),
+			mg:     binding(),
+			want:   want{calls: []string{http.MethodDelete}},
+		},
+		"ReferenceNeverResolved": {
+			reason: "Deleting a binding whose user reference was never resolved should succeed, as it was never created.",
+			mg: binding(func(cr *apisv1beta1.UserGroupBinding) {
+				cr.Spec.ForProvider.UserID = ""
+				cr.Spec.ForProvider.UserIDRef = &xpv1.Reference{Name: "jdoe"}
+			}),
+		},
+		"APIError": {
+			reason: "Errors removing the user from the group should be returned.",
+			seeds:  append(seeds(true), controllertest.Fail(http.MethodDelete, "/api/users", http.StatusInternalServerError)),
+			mg:     binding(),
+			want:   want{calls: []string{http.MethodDelete}, err: errors.Wrap(controllertest.APIError(http.StatusInternalServerError), "failed to delete user group binding")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, svc := controllertest.NewService(t, tc.seeds...)
+			e := external{service: svc, kube: controllertest.NewKube(), recorder: event.NewNopRecorder()}
+			_, err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, controllertest.Mutations(srv.Requests())); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*apisv1beta1.User).Status.AtProvider.ID = "user-id"
+			return nil
+		}),
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			l := obj.(*apisv1beta1.GroupList)
+			l.Items = []apisv1beta1.Group{{
+				ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+				Status:     apisv1beta1.GroupStatus{AtProvider: apisv1beta1.GroupObservation{ID: "group-id"}},
+			}}
+			return nil
+		}),
+	}
+
+	cr := &apisv1beta1.UserGroupBinding{
+		Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+			UserIDRef:       &xpv1.Reference{Name: "jdoe"},
+			GroupIDSelector: &xpv1.Selector{MatchLabels: map[string]string{"team": "dev"}},
+		}},
+	}
+	if err := cr.ResolveReferences(context.Background(), kube); err != nil {
+		t.Fatalf("cr.ResolveReferences(...): unexpected error: %v", err)
+	}
+
+	want := apisv1beta1.UserGroupBindingParameters{
+		UserID:          "user-id",
+		UserIDRef:       &xpv1.Reference{Name: "jdoe"},
+		GroupID:         "group-id",
+		GroupIDRef:      &xpv1.Reference{Name: "developers"},
+		GroupIDSelector: &xpv1.Selector{MatchLabels: map[string]string{"team": "dev"}},
+	}
+	if diff := cmp.Diff(want, cr.Spec.ForProvider); diff != "" {
+		t.Errorf("cr.ResolveReferences(...): -want IDs resolved from the referenced and selected resources, +got:\n%s\n", diff)
+	}
+}
+
+func TestResolveReferencesFromStatus(t *testing.T) {
+	unavailable := kerrors.NewServiceUnavailable("etcd is unavailable")
+	resolve := xpv1.ResolvePolicyAlways
+	always := &xpv1.Policy{Resolve: &resolve}
+
+	type want struct {
+		params apisv1beta1.UserGroupBindingParameters
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *apisv1beta1.UserGroupBinding
+		want   want
+	}{
+		"ResolvedBefore": {
+			reason: "The IDs recorded in the status should be used if the referenced resources cannot be read.",
+			cr: binding(func(cr *apisv1beta1.UserGroupBinding) {
+				cr.Spec.ForProvider = apisv1beta1.UserGroupBindingParameters{
+					UserIDRef:  &xpv1.Reference{Name: "jdoe", Policy: always},
+					GroupIDRef: &xpv1.Reference{Name: "admins", Policy: always},
+				}
+				cr.Status.AtProvider.ResolvedUserID = "jdoe-id"
+				cr.Status.AtProvider.ResolvedGroupID = "admins-id"
+			}),
+			want: want{params: apisv1beta1.UserGroupBindingParameters{
+				UserID:     "jdoe-id",
+				UserIDRef:  &xpv1.Reference{Name: "jdoe", Policy: always},
+				GroupID:    "admins-id",
+				GroupIDRef: &xpv1.Reference{Name: "admins", Policy: always},
+			}},
+		},
+		"Unresolved": {
+			reason: "An error should be returned if the references never resolved.",
+			cr: binding(func(cr *apisv1beta1.UserGroupBinding) {
+				cr.Spec.ForProvider = apisv1beta1.UserGroupBindingParameters{
+					UserIDRef: &xpv1.Reference{Name: "jdoe"},
+					GroupID:   "admins-id",
+				}
+			}),
+			want: want{
+				params: apisv1beta1.UserGroupBindingParameters{UserIDRef: &xpv1.Reference{Name: "jdoe"}, GroupID: "admins-id"},
+				err:    true,
+			},
+		},
+		"UsernameNotRecorded": {
+			reason: "Users looked up by name should not be pinned to the ID recorded in the status.",
+			cr: binding(func(cr *apisv1beta1.UserGroupBinding) {
+				cr.Spec.ForProvider = apisv1beta1.UserGroupBindingParameters{
+					Username:   "jdoe",
+					GroupIDRef: &xpv1.Reference{Name: "admins", Policy: always},
+				}
+				cr.Status.AtProvider.ResolvedUserID = "jdoe-id"
+				cr.Status.AtProvider.ResolvedGroupID = "admins-id"
+			}),
+			want: want{params: apisv1beta1.UserGroupBindingParameters{
+				Username:   "jdoe",
+				GroupID:    "admins-id",
+				GroupIDRef: &xpv1.Reference{Name: "admins", Policy: always},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: test.NewMockGetFn(unavailable)}
+			r := clients.NewReferenceResolver(kube, resolvedIDs, event.NewNopRecorder())
+
+			err := r.ResolveReferences(context.Background(), tc.cr)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nr.ResolveReferences(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.params, tc.cr.Spec.ForProvider); diff != "" {
+				t.Errorf("\n%s\nr.ResolveReferences(...): -want parameters, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManagementPolicies(t *testing.T) {
+	type want struct {
+		calls []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		policies xpv1.ManagementPolicies
+		member   bool
+		ldap     bool
+		deleted  bool
+		want     want
+	}{
+		"ObserveOnlyNotCreated": {
+			reason:   "An Observe only binding should never add the user to the group.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			member:   false,
+			want:     want{calls: nil},
+		},
+		"ObserveOnlyNotRemoved": {
+			reason:   "Deleting an Observe only binding should leave a manually created membership in place.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			member:   true,
+			deleted:  true,
+			want:     want{calls: nil},
+		},
+		"CreateOnlyNotRemoved": {
+			reason:   "Deleting a binding without the Delete policy should leave the membership in place.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate},
+			member:   true,
+			deleted:  true,
+			want:     want{calls: nil},
+		},
+		"CreateOnlyCreated": {
+			reason:   "A binding with the Create policy should add the user to the group.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate},
+			member:   false,
+			want:     want{calls: []string{"POST /api/users/user-id/groups/group-id"}},
+		},
+		"LDAPMembershipNotRemoved": {
+			reason:   "Deleting a binding should leave a membership synchronised from LDAP in place.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionAll},
+			member:   true,
+			ldap:     true,
+			deleted:  true,
+			want:     want{calls: nil},
+		},
+		"FullyManagedRemoved": {
+			reason:   "Deleting a fully managed binding should remove the membership.",
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionAll},
+			member:   true,
+			deleted:  true,
+			want:     want{calls: []string{"DELETE /api/users/user-id/groups/group-id"}},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method != http.MethodGet:
+					calls = append(calls, r.Method+" "+r.URL.Path)
+				case r.URL.Path == "/api/users/user-id":
+					u := pocketid.User{ID: "user-id", Username: "jdoe"}
+					if tc.member {
+						u.UserGroups = []string{"devs"}
+					}
+					_ = json.NewEncoder(w).Encode(u)
+				case r.URL.Path == "/api/groups/group-id":
+					g := pocketid.Group{ID: "group-id", GroupName: "devs"}
+					if tc.ldap {
+						g.LdapID = "cn=devs"
+					}
+					_ = json.NewEncoder(w).Encode(g)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			cr := &apisv1beta1.UserGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding"},
+				Spec: apisv1beta1.UserGroupBindingSpec{
+					ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: tc.policies, DeletionPolicy: xpv1.DeletionDelete},
+					ForProvider: apisv1beta1.UserGroupBindingParameters{
+						UserID:  "user-id",
+						GroupID: "group-id",
+					},
+				},
+			}
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			kube := test.NewMockClient()
+			kube.MockGet = test.NewMockGetFn(nil, func(obj client.Object) error {
+				cr.DeepCopyInto(obj.(*apisv1beta1.UserGroupBinding))
+				return nil
+			})
+
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(apisv1beta1.UserGroupBindingGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					return &external{
+						service:  pocketid.NewClient(pocketid.Config{Endpoint: srv.URL}),
+						kube:     kube,
+						recorder: event.NewNopRecorder(),
+					}, nil
+				})),
+				managed.WithManagementPolicies(),
+			)
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "binding"}}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Pocket ID calls, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBindingsForUser(t *testing.T) {
+	bindings := func(obj client.ObjectList) error {
+		l := obj.(*apisv1beta1.UserGroupBindingList)
+		l.Items = []apisv1beta1.UserGroupBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-ref"},
+				Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+					UserIDRef: &xpv1.Reference{Name: "jdoe"},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-selector"},
+				Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+					UserIDSelector: &xpv1.Selector{MatchLabels: map[string]string{"team": "dev"}},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-id"},
+				Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+					UserID: "some-id",
+				}},
+			},
+		}
+		return nil
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		user   client.Object
+		want   []reconcile.Request
+	}{
+		"ReferencedByName": {
+			reason: "A binding whose userIdRef names the user should be enqueued.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(nil, bindings)},
+			user:   &apisv1beta1.User{ObjectMeta: metav1.ObjectMeta{Name: "jdoe"}},
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "by-ref"}}},
+		},
+		"SelectedByLabels": {
+			reason: "A binding whose userIdSelector matches the user's labels should be enqueued.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(nil, bindings)},
+			user:   &apisv1beta1.User{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"team": "dev"}}},
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "by-selector"}}},
+		},
+		"Unrelated": {
+			reason: "No binding should be enqueued for a user nothing refers to.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(nil, bindings)},
+			user:   &apisv1beta1.User{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+			want:   nil,
+		},
+		"ListError": {
+			reason: "Nothing should be enqueued when bindings cannot be listed.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			user:   &apisv1beta1.User{ObjectMeta: metav1.ObjectMeta{Name: "jdoe"}},
+			want:   nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := clients.EnqueueReferencing(tc.kube, newList, userIDReference)(context.Background(), tc.user)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nclients.EnqueueReferencing(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events receives the events of Pocket ID servers, reconciling the
+// managed resources of the objects they are about right away rather than at
+// their next poll.
+package events
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+// AnnotationKeyReconcileRequestedAt is the annotation set to the time of the
+// last event about the Pocket ID object of a managed resource. Changing it
+// triggers the reconciliation of the resource.
+const AnnotationKeyReconcileRequestedAt = "pocketid.crossplane.io/reconcile-requested-at"
+
+// Path the receiver is served at.
+const Path = "/events"
+
+// maxEventSize is the size of the largest event accepted.
+const maxEventSize = 64 << 10
+
+const (
+	errList  = "cannot list managed resources"
+	errPatch = "cannot request the reconciliation of a managed resource"
+)
+
+// An Event of a Pocket ID server, such as
+// {"type": "user.updated", "data": {"id": "..."}}.
+type Event struct {
+	// Type of the event. It is only logged.
+	Type string `json:"type"`
+
+	// Data of the event.
+	Data EventData `json:"data"`
+}
+
+// EventData identifies the Pocket ID object an event is about.
+type EventData struct {
+	// ID of the user, group or OIDC client the event is about.
+	ID string `json:"id"`
+}
+
+// A Receiver requests the reconciliation of the managed resources of the
+// Pocket ID objects it receives events about. Bindings are reconciled on the
+// events of both their user or client and their group.
+type Receiver struct {
+	kube     client.Client
+	token    string
+	insecure bool
+	log      logging.Logger
+	now      func() time.Time
+}
+
+// A ReceiverOption configures a Receiver.
+type ReceiverOption func(*Receiver)
+
+// Insecure makes a receiver without a token accept any event. Anyone reaching
+// it can then have managed resources reconciled at will.
+func Insecure() ReceiverOption {
+	return func(r *Receiver) {
+		r.insecure = true
+	}
+}
+
+// NewReceiver returns a receiver accepting the events sent with the supplied
+// bearer token. A receiver without a token accepts no event unless it is
+// Insecure.
+func NewReceiver(kube client.Client, token string, log logging.Logger, o ...ReceiverOption) *Receiver {
+	r := &Receiver{kube: kube, token: token, log: log, now: time.Now}
+	for _, fn := range o {
+		fn(r)
+	}
+	return r
+}
+
+// ServeHTTP accepts an event, answering 202 Accepted once the reconciliation
+// of the managed resources it is about is requested.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !r.authorized(req) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	e := Event{}
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxEventSize)).Decode(&e); err != nil || e.Data.ID == "" {
+		http.Error(w, "want an event such as {\"type\": \"user.updated\", \"data\": {\"id\": \"...\"}}", http.StatusBadRequest)
+		return
+	}
+
+	n, err := r.Reconcile(req.Context(), e.Data.ID)
+	if err != nil {
+		r.log.Info("Cannot handle Pocket ID event", "type", e.Type, "id", e.Data.ID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	r.log.Debug("Handled Pocket ID event", "type", e.Type, "id", e.Data.ID, "resources", n)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authorized reports whether the supplied request was sent with the token of
+// the receiver.
+func (r *Receiver) authorized(req *http.Request) bool {
+	if r.token == "" {
+		return r.insecure
+	}
+	return subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), []byte("Bearer "+r.token)) == 1
+}
+
+// Reconcile requests the reconciliation of the managed resources of the
+// Pocket ID object with the supplied ID, returning how many there are.
+func (r *Receiver) Reconcile(ctx context.Context, id string) (int, error) {
+	n := 0
+	for _, l := range []client.ObjectList{
+		&v1beta1.UserList{},
+		&v1alpha1.AdminUserList{},
+		&v1beta1.GroupList{},
+		&v1beta1.OIDCClientList{},
+		&v1beta1.UserGroupBindingList{},
+		&v1beta1.OIDCClientGroupBindingList{},
+	} {
+		if err := r.kube.List(ctx, l); err != nil {
+			return n, errors.Wrap(err, errList)
+		}
+		items, err := kmeta.ExtractList(l)
+		if err != nil {
+			return n, errors.Wrap(err, errList)
+		}
+		for _, o := range items {
+			if !slices.Contains(ids(o), id) {
+				continue
+			}
+			mg := o.(client.Object)
+			p := client.MergeFrom(mg.DeepCopyObject().(client.Object))
+			meta.AddAnnotations(mg, map[string]string{AnnotationKeyReconcileRequestedAt: r.now().UTC().Format(time.RFC3339Nano)})
+			if err := r.kube.Patch(ctx, mg, p); err != nil {
+				return n, errors.Wrap(err, errPatch)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// ids returns the IDs of the Pocket ID objects a managed resource is about.
+func ids(o runtime.Object) []string {
+	switch cr := o.(type) {
+	case *v1beta1.User:
+		return []string{cr.Status.AtProvider.ID}
+	case *v1alpha1.AdminUser:
+		return []string{cr.Status.AtProvider.ID}
+	case *v1beta1.Group:
+		return []string{cr.Status.AtProvider.ID}
+	case *v1beta1.OIDCClient:
+		return []string{cr.Status.AtProvider.ID}
+	case *v1beta1.UserGroupBinding:
+		return []string{cr.Status.AtProvider.ResolvedUserID, cr.Status.AtProvider.ResolvedGroupID}
+	case *v1beta1.OIDCClientGroupBinding:
+		return []string{cr.Status.AtProvider.ResolvedClientID, cr.Status.AtProvider.ResolvedGroupID}
+	}
+	return nil
+}
+
+// A server serves a receiver until the manager it is added to stops.
+type server struct {
+	*http.Server
+}
+
+// NewServer returns a runnable serving the supplied receiver at Path on the
+// supplied address.
+func NewServer(addr string, r *Receiver) manager.Runnable {
+	mux := http.NewServeMux()
+	mux.Handle(Path, r)
+	return server{Server: &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}}
+}
+
+func (s server) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		_ = s.Shutdown(context.Background())
+	}()
+	if err := s.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, "cannot serve Pocket ID events")
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false: events are received by every replica of
+// the provider, whichever the load balancer in front of them picks.
+func (s server) NeedLeaderElection() bool {
+	return false
+}
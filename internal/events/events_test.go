@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+func TestReceiver(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	list := func(obj client.ObjectList) error {
+		switch l := obj.(type) {
+		case *v1beta1.UserList:
+			u := v1beta1.User{}
+			u.SetName("alice")
+			u.Status.AtProvider.ID = "u1"
+			l.Items = []v1beta1.User{u}
+		case *v1beta1.UserGroupBindingList:
+			b := v1beta1.UserGroupBinding{}
+			b.SetName("alice-admins")
+			b.Status.AtProvider.ResolvedUserID, b.Status.AtProvider.ResolvedGroupID = "u1", "g1"
+			l.Items = []v1beta1.UserGroupBinding{b}
+		}
+		return nil
+	}
+
+	type args struct {
+		method string
+		auth   string
+		body   string
+		list   test.ObjectListFn
+	}
+	type want struct {
+		status  int
+		patched []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		// noToken starts the receiver without a token.
+		noToken  bool
+		insecure bool
+		args     args
+		want     want
+	}{
+		"UserEvent": {
+			reason: "An event about a user should reconcile the user and its bindings.",
+			args:   args{method: http.MethodPost, auth: "Bearer s3cr3t", body: `{"type": "user.updated", "data": {"id": "u1"}}`, list: list},
+			want:   want{status: http.StatusAccepted, patched: []string{"alice", "alice-admins"}},
+		},
+		"GroupEvent": {
+			reason: "An event about a group should reconcile the bindings to the group.",
+			args:   args{method: http.MethodPost, auth: "Bearer s3cr3t", body: `{"type": "group.deleted", "data": {"id": "g1"}}`, list: list},
+			want:   want{status: http.StatusAccepted, patched: []string{"alice-admins"}},
+		},
+		"UnknownObject": {
+			reason: "An event about an object that is not managed should be accepted and ignored.",
+			args:   args{method: http.MethodPost, auth: "Bearer s3cr3t", body: `{"type": "user.created", "data": {"id": "u2"}}`, list: list},
+			want:   want{status: http.StatusAccepted},
+		},
+		"WrongToken": {
+			reason: "Events sent without the token should be rejected.",
+			args:   args{method: http.MethodPost, auth: "Bearer guess", body: `{"data": {"id": "u1"}}`, list: list},
+			want:   want{status: http.StatusUnauthorized},
+		},
+		"MissingToken": {
+			reason: "Events sent without any token should be rejected.",
+			args:   args{method: http.MethodPost, body: `{"data": {"id": "u1"}}`, list: list},
+			want:   want{status: http.StatusUnauthorized},
+		},
+		"NoTokenConfigured": {
+			reason:  "Events should be rejected by a receiver without a token unless it is insecure.",
+			noToken: true,
+			args:    args{method: http.MethodPost, body: `{"data": {"id": "u1"}}`, list: list},
+			want:    want{status: http.StatusUnauthorized},
+		},
+		"Insecure": {
+			reason:   "Events should be accepted without a token by an insecure receiver.",
+			noToken:  true,
+			insecure: true,
+			args:     args{method: http.MethodPost, body: `{"data": {"id": "u1"}}`, list: list},
+			want:     want{status: http.StatusAccepted, patched: []string{"alice", "alice-admins"}},
+		},
+		"WrongMethod": {
+			reason: "Events should only be posted.",
+			args:   args{method: http.MethodGet, auth: "Bearer s3cr3t", list: list},
+			want:   want{status: http.StatusMethodNotAllowed},
+		},
+		"MissingID": {
+			reason: "Events not identifying an object should be rejected.",
+			args:   args{method: http.MethodPost, auth: "Bearer s3cr3t", body: `{"type": "user.updated"}`, list: list},
+			want:   want{status: http.StatusBadRequest},
+		},
+		"ListError": {
+			reason: "Events whose resources cannot be listed should fail so that they may be sent again.",
+			args: args{method: http.MethodPost, auth: "Bearer s3cr3t", body: `{"data": {"id": "u1"}}`, list: func(client.ObjectList) error {
+				return errBoom
+			}},
+			want: want{status: http.StatusInternalServerError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patched []string
+			kube := &test.MockClient{
+				MockList: test.NewMockListFn(nil, tc.args.list),
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					if got := obj.GetAnnotations()[AnnotationKeyReconcileRequestedAt]; got != now.Format(time.RFC3339Nano) {
+						t.Errorf("Patch(...): want reconcile requested at %s, got %q", now, got)
+					}
+					patched = append(patched, obj.GetName())
+					return nil
+				},
+			}
+			token, o := "s3cr3t", []ReceiverOption{}
+			if tc.noToken {
+				token = ""
+			}
+			if tc.insecure {
+				o = append(o, Insecure())
+			}
+			r := NewReceiver(kube, token, logging.NewNopLogger(), o...)
+			r.now = func() time.Time { return now }
+
+			req := httptest.NewRequest(tc.args.method, Path, strings.NewReader(tc.args.body))
+			req.Header.Set("Authorization", tc.args.auth)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if diff := cmp.Diff(tc.want.status, w.Code); diff != "" {
+				t.Errorf("\n%s\nr.ServeHTTP(...): -want status, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.patched, patched); diff != "" {
+				t.Errorf("\n%s\nr.ServeHTTP(...): -want reconciled, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
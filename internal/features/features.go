@@ -29,4 +29,9 @@ const (
 	// Management Policies. See the below design for more details.
 	// https://github.com/crossplane/crossplane/blob/master/design/design-doc-observe-only-resources.md
 	EnableAlphaManagementPolicies feature.Flag = "EnableAlphaManagementPolicies"
+
+	// EnableAlphaOrphanDetection enables alpha support for periodically
+	// detecting the users, groups and OIDC clients created by the provider
+	// whose managed resource no longer exists.
+	EnableAlphaOrphanDetection feature.Flag = "EnableAlphaOrphanDetection"
 )
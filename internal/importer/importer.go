@@ -0,0 +1,309 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importer generates the managed resources adopting the users, groups,
+// OIDC clients and group memberships of an existing Pocket ID instance, with
+// their external names set so that the provider observes the existing objects
+// rather than creating new ones.
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+const (
+	errListUsers   = "cannot list users"
+	errListGroups  = "cannot list groups"
+	errListClients = "cannot list OIDC clients"
+	errMarshal     = "cannot marshal managed resource"
+)
+
+// maxNameLength is the maximum length of the name of a managed resource.
+const maxNameLength = 253
+
+// Options of an import.
+type Options struct {
+	// ProviderConfigName is the ProviderConfig the managed resources use.
+	ProviderConfigName string
+
+	// DeletionPolicy of the managed resources, the default of Crossplane if
+	// unset.
+	DeletionPolicy xpv1.DeletionPolicy
+
+	// SkipLDAP skips the users and groups synchronised from LDAP, along with
+	// their memberships, as LDAP remains their source of truth.
+	SkipLDAP bool
+}
+
+// Import returns the managed resources adopting the users, groups and OIDC
+// clients of the supplied Pocket ID instance, followed by the bindings of its
+// group memberships. Administrators are adopted as AdminUsers.
+func Import(ctx context.Context, svc pocketid.Service, o Options) ([]resource.Managed, error) {
+	groups, err := svc.ListGroups(ctx, pocketid.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListGroups)
+	}
+	users, err := svc.ListUsers(ctx, pocketid.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListUsers)
+	}
+	clients, err := svc.ListOIDCClients(ctx, pocketid.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListClients)
+	}
+
+	i := &importer{opts: o, names: map[string]map[string]bool{}, groups: map[string]importedGroup{}}
+	var mgs []resource.Managed
+	for _, g := range groups {
+		if o.SkipLDAP && g.LdapID != "" {
+			continue
+		}
+		mgs = append(mgs, i.group(g))
+	}
+	for _, u := range users {
+		if o.SkipLDAP && u.LdapID != "" {
+			continue
+		}
+		if u.IsAdmin {
+			mgs = append(mgs, i.adminUser(u))
+			continue
+		}
+		mgs = append(mgs, i.user(u))
+	}
+	for _, c := range clients {
+		mgs = append(mgs, i.oidcClient(c))
+	}
+	mgs = append(mgs, i.bindings...)
+	return mgs, nil
+}
+
+// Write the supplied managed resources to the supplied writer as a stream of
+// YAML documents, without their status.
+func Write(w io.Writer, mgs []resource.Managed) error {
+	for _, mg := range mgs {
+		b, err := json.Marshal(mg)
+		if err != nil {
+			return errors.Wrap(err, errMarshal)
+		}
+		m := map[string]any{}
+		if err := json.Unmarshal(b, &m); err != nil {
+			return errors.Wrap(err, errMarshal)
+		}
+		delete(m, "status")
+		if md, ok := m["metadata"].(map[string]any); ok {
+			delete(md, "creationTimestamp")
+		}
+		y, err := yaml.Marshal(m)
+		if err != nil {
+			return errors.Wrap(err, errMarshal)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// An importedGroup is a group adopted by a managed resource.
+type importedGroup struct {
+	id   string
+	name string
+}
+
+// An importer builds managed resources, keeping their names unique by kind
+// and collecting the bindings of the memberships of the objects it adopts.
+type importer struct {
+	opts     Options
+	names    map[string]map[string]bool
+	groups   map[string]importedGroup
+	bindings []resource.Managed
+}
+
+func (i *importer) group(g pocketid.Group) resource.Managed {
+	cr := &apisv1beta1.Group{}
+	cr.SetGroupVersionKind(apisv1beta1.GroupGroupVersionKind)
+	cr.SetName(i.name(apisv1beta1.GroupKind, g.GroupName))
+	cr.Spec.ForProvider = apisv1beta1.GroupParameters{
+		Name:         g.GroupName,
+		FriendlyName: g.FriendlyName,
+		CustomClaims: customClaims(g.CustomClaims),
+	}
+	i.adopt(cr, &cr.Spec.ResourceSpec, g.GroupName)
+	i.groups[g.GroupName] = importedGroup{id: g.ID, name: cr.GetName()}
+	return cr
+}
+
+func (i *importer) user(u pocketid.User) resource.Managed {
+	cr := &apisv1beta1.User{}
+	cr.SetGroupVersionKind(apisv1beta1.UserGroupVersionKind)
+	cr.SetName(i.name(apisv1beta1.UserKind, u.Username))
+	cr.Spec.ForProvider = apisv1beta1.UserParameters{
+		Username:     u.Username,
+		Email:        u.Email,
+		FirstName:    u.FirstName,
+		LastName:     u.LastName,
+		Locale:       u.Locale,
+		Disabled:     u.Disabled,
+		CustomClaims: customClaims(u.CustomClaims),
+	}
+	i.adopt(cr, &cr.Spec.ResourceSpec, u.Username)
+	i.userBindings(u, apisv1beta1.UserGroupBindingParameters{UserIDRef: &xpv1.Reference{Name: cr.GetName()}}, cr.GetName())
+	return cr
+}
+
+func (i *importer) adminUser(u pocketid.User) resource.Managed {
+	cr := &apisv1alpha1.AdminUser{}
+	cr.SetGroupVersionKind(apisv1alpha1.AdminUserGroupVersionKind)
+	cr.SetName(i.name(apisv1alpha1.AdminUserKind, u.Username))
+	cr.Spec.ForProvider = apisv1alpha1.AdminUserParameters{
+		Username:     u.Username,
+		Email:        u.Email,
+		FirstName:    u.FirstName,
+		LastName:     u.LastName,
+		Locale:       u.Locale,
+		Disabled:     u.Disabled,
+		CustomClaims: customClaims(u.CustomClaims),
+	}
+	i.adopt(cr, &cr.Spec.ResourceSpec, u.Username)
+	// UserGroupBindings only refer to Users, so AdminUsers are bound by name.
+	i.userBindings(u, apisv1beta1.UserGroupBindingParameters{Username: u.Username}, cr.GetName())
+	return cr
+}
+
+func (i *importer) oidcClient(c pocketid.OIDCClient) resource.Managed {
+	cr := &apisv1beta1.OIDCClient{}
+	cr.SetGroupVersionKind(apisv1beta1.OIDCClientGroupVersionKind)
+	cr.SetName(i.name(apisv1beta1.OIDCClientKind, c.ClientName))
+	cr.Spec.ForProvider = apisv1beta1.OIDCClientParameters{
+		Name:               c.ClientName,
+		CallbackURLs:       c.RedirectURIs,
+		LogoutCallbackURLs: c.PostLogoutURIs,
+		LaunchURL:          c.LaunchURL,
+		IsPublic:           c.IsPublic,
+		PkceEnabled:        c.RequirePKCE,
+	}
+	i.adopt(cr, &cr.Spec.ResourceSpec, c.ClientName)
+
+	for _, name := range c.GroupNames {
+		g, ok := i.groups[name]
+		if !ok {
+			continue
+		}
+		b := &apisv1beta1.OIDCClientGroupBinding{}
+		b.SetGroupVersionKind(apisv1beta1.OIDCClientGroupBindingGroupVersionKind)
+		b.SetName(i.name(apisv1beta1.OIDCClientGroupBindingKind, cr.GetName()+"-"+g.name))
+		b.Spec.ForProvider = apisv1beta1.OIDCClientGroupBindingParameters{
+			ClientIDRef: &xpv1.Reference{Name: cr.GetName()},
+			GroupIDRef:  &xpv1.Reference{Name: g.name},
+		}
+		i.adopt(b, &b.Spec.ResourceSpec, c.ID+":"+g.id)
+		i.bindings = append(i.bindings, b)
+	}
+	return cr
+}
+
+// userBindings collects the UserGroupBindings of the memberships of the
+// supplied user, binding it as the supplied parameters do.
+func (i *importer) userBindings(u pocketid.User, p apisv1beta1.UserGroupBindingParameters, userName string) {
+	for _, name := range u.UserGroups {
+		g, ok := i.groups[name]
+		if !ok {
+			continue
+		}
+		b := &apisv1beta1.UserGroupBinding{}
+		b.SetGroupVersionKind(apisv1beta1.UserGroupBindingGroupVersionKind)
+		b.SetName(i.name(apisv1beta1.UserGroupBindingKind, userName+"-"+g.name))
+		b.Spec.ForProvider = p
+		b.Spec.ForProvider.GroupIDRef = &xpv1.Reference{Name: g.name}
+		i.adopt(b, &b.Spec.ResourceSpec, u.ID+":"+g.id)
+		i.bindings = append(i.bindings, b)
+	}
+}
+
+// adopt sets the external name, ProviderConfig and deletion policy of the
+// supplied managed resource.
+func (i *importer) adopt(mg resource.Managed, spec *xpv1.ResourceSpec, externalName string) {
+	meta.SetExternalName(mg, externalName)
+	if i.opts.ProviderConfigName != "" {
+		spec.ProviderConfigReference = &xpv1.Reference{Name: i.opts.ProviderConfigName}
+	}
+	spec.DeletionPolicy = i.opts.DeletionPolicy
+}
+
+// name returns a valid name for a managed resource of the supplied kind named
+// after the supplied Pocket ID object, unique among those of its kind.
+func (i *importer) name(kind, s string) string {
+	base := sanitize(s)
+	if base == "" {
+		base = strings.ToLower(kind)
+	}
+	if i.names[kind] == nil {
+		i.names[kind] = map[string]bool{}
+	}
+	name := base
+	for n := 2; i.names[kind][name]; n++ {
+		suffix := fmt.Sprintf("-%d", n)
+		name = strings.TrimRight(base[:min(len(base), maxNameLength-len(suffix))], "-.") + suffix
+	}
+	i.names[kind][name] = true
+	return name
+}
+
+// sanitize returns the supplied string as a DNS subdomain, lowercased with the
+// characters it may not contain replaced by dashes.
+func sanitize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.':
+			b.WriteRune(r)
+		case !strings.HasSuffix(b.String(), "-"):
+			b.WriteRune('-')
+		}
+	}
+	name := b.String()
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return strings.Trim(name, "-.")
+}
+
+// customClaims returns the supplied custom claims without the owner claim set
+// by the provider, or nil if there are none.
+func customClaims(claims map[string]string) map[string]string {
+	c := maps.Clone(claims)
+	delete(c, pocketid.OwnerClaim)
+	if len(c) == 0 {
+		return nil
+	}
+	return c
+}
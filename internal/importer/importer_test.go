@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+	pocketidfake "github.com/crossplane/provider-pocketid/internal/clients/pocketid/fake"
+)
+
+func TestImport(t *testing.T) {
+	// An imported managed resource, as kind/name=external-name.
+	type imported = string
+
+	type want struct {
+		imported []imported
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		opts   Options
+		fail   string
+		want   want
+	}{
+		"Imported": {
+			reason: "Every object and membership should be adopted by a managed resource named after it.",
+			want: want{imported: []imported{
+				"Group/app-admins=App Admins",
+				"Group/directory=directory",
+				"AdminUser/admin=admin",
+				"User/jane.doe=Jane.Doe",
+				"User/jane.doe-2=jane.doe",
+				"AdminUser/root=root",
+				"OIDCClient/my-app=My App",
+				"UserGroupBinding/jane.doe-app-admins=jdoe-id:admins-id",
+				"UserGroupBinding/jane.doe-directory=jdoe-id:ldap-id",
+				"UserGroupBinding/root-app-admins=root-id:admins-id",
+				"OIDCClientGroupBinding/my-app-app-admins=app-id:admins-id",
+			}},
+		},
+		"SkipLDAP": {
+			reason: "Groups synchronised from LDAP and their memberships should be skipped if asked to.",
+			opts:   Options{SkipLDAP: true},
+			want: want{imported: []imported{
+				"Group/app-admins=App Admins",
+				"AdminUser/admin=admin",
+				"User/jane.doe=Jane.Doe",
+				"User/jane.doe-2=jane.doe",
+				"AdminUser/root=root",
+				"OIDCClient/my-app=My App",
+				"UserGroupBinding/jane.doe-app-admins=jdoe-id:admins-id",
+				"UserGroupBinding/root-app-admins=root-id:admins-id",
+				"OIDCClientGroupBinding/my-app-app-admins=app-id:admins-id",
+			}},
+		},
+		"ListError": {
+			reason: "Errors listing objects should be returned.",
+			fail:   "/api/users",
+			want:   want{err: errors.Wrap(&pocketid.APIError{StatusCode: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError)}, errListUsers)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := pocketidfake.NewServer()
+			defer srv.Close()
+			srv.AddGroup(pocketid.Group{ID: "admins-id", GroupName: "App Admins", CustomClaims: map[string]string{pocketid.OwnerClaim: "uid"}})
+			srv.AddGroup(pocketid.Group{ID: "ldap-id", GroupName: "directory", LdapID: "cn=directory"})
+			srv.AddUser(pocketid.User{ID: "jdoe-id", Username: "Jane.Doe"})
+			srv.AddUser(pocketid.User{ID: "other-id", Username: "jane.doe"})
+			srv.AddUser(pocketid.User{ID: "root-id", Username: "root", IsAdmin: true})
+			srv.AddOIDCClient(pocketid.OIDCClient{ID: "app-id", ClientName: "My App"})
+			srv.AddUserToGroup("jdoe-id", "admins-id")
+			srv.AddUserToGroup("jdoe-id", "ldap-id")
+			srv.AddUserToGroup("root-id", "admins-id")
+			srv.AddClientToGroup("app-id", "admins-id")
+			if tc.fail != "" {
+				srv.Fail(http.MethodGet, tc.fail, http.StatusInternalServerError, 0)
+			}
+			svc, err := pocketid.NewClientFromConfig(srv.Config())
+			if err != nil {
+				t.Fatalf("pocketid.NewClientFromConfig(...): %v", err)
+			}
+
+			mgs, err := Import(context.Background(), svc, tc.opts)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nImport(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			var got []imported
+			for _, mg := range mgs {
+				got = append(got, mg.GetObjectKind().GroupVersionKind().Kind+"/"+mg.GetName()+"="+meta.GetExternalName(mg))
+			}
+			if diff := cmp.Diff(tc.want.imported, got); diff != "" {
+				t.Errorf("\n%s\nImport(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	i := &importer{
+		opts:   Options{ProviderConfigName: "default", DeletionPolicy: xpv1.DeletionOrphan},
+		names:  map[string]map[string]bool{},
+		groups: map[string]importedGroup{},
+	}
+	g := i.group(pocketid.Group{ID: "admins-id", GroupName: "admins", FriendlyName: "Admins", CustomClaims: map[string]string{pocketid.OwnerClaim: "uid"}})
+	g.(*apisv1beta1.Group).Status.AtProvider.ID = "admins-id"
+
+	b := &bytes.Buffer{}
+	if err := Write(b, []resource.Managed{g}); err != nil {
+		t.Fatalf("Write(...): %v", err)
+	}
+
+	want := `---
+apiVersion: pocketid.crossplane.io/v1beta1
+kind: Group
+metadata:
+  annotations:
+    crossplane.io/external-name: admins
+  name: admins
+spec:
+  deletionPolicy: Orphan
+  forProvider:
+    friendlyName: Admins
+    name: admins
+  providerConfigRef:
+    name: default
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("Write(...): -want, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inuse finds the bindings that still use a User, Group or OIDCClient
+// so that it is not deleted from Pocket ID before they are, and requeues the
+// Users, Groups and OIDCClients waiting for their bindings to be gone.
+package inuse
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+const (
+	errListUserGroupBindings   = "cannot list UserGroupBindings"
+	errListClientGroupBindings = "cannot list OIDCClientGroupBindings"
+)
+
+// UsersOfUser returns the bindings that use the supplied User.
+func UsersOfUser(ctx context.Context, kube client.Reader, cr *apisv1beta1.User) ([]string, error) {
+	l := &apisv1beta1.UserGroupBindingList{}
+	if err := kube.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListUserGroupBindings)
+	}
+
+	var users []string
+	for _, b := range l.Items {
+		p := b.Spec.ForProvider
+		if uses(cr.GetName(), cr.Status.AtProvider.ID, p.UserIDRef, p.UserID, b.Status.AtProvider.ResolvedUserID) {
+			users = append(users, apisv1beta1.UserGroupBindingKind+"/"+b.GetName())
+		}
+	}
+	return users, nil
+}
+
+// UsersOfGroup returns the bindings that use the supplied Group.
+func UsersOfGroup(ctx context.Context, kube client.Reader, cr *apisv1beta1.Group) ([]string, error) {
+	ugb := &apisv1beta1.UserGroupBindingList{}
+	if err := kube.List(ctx, ugb); err != nil {
+		return nil, errors.Wrap(err, errListUserGroupBindings)
+	}
+
+	var users []string
+	for _, b := range ugb.Items {
+		p := b.Spec.ForProvider
+		if uses(cr.GetName(), cr.Status.AtProvider.ID, p.GroupIDRef, p.GroupID, b.Status.AtProvider.ResolvedGroupID) {
+			users = append(users, apisv1beta1.UserGroupBindingKind+"/"+b.GetName())
+		}
+	}
+
+	cgb := &apisv1beta1.OIDCClientGroupBindingList{}
+	if err := kube.List(ctx, cgb); err != nil {
+		return nil, errors.Wrap(err, errListClientGroupBindings)
+	}
+
+	for _, b := range cgb.Items {
+		p := b.Spec.ForProvider
+		if uses(cr.GetName(), cr.Status.AtProvider.ID, p.GroupIDRef, p.GroupID, b.Status.AtProvider.ResolvedGroupID) {
+			users = append(users, apisv1beta1.OIDCClientGroupBindingKind+"/"+b.GetName())
+		}
+	}
+	return users, nil
+}
+
+// UsersOfOIDCClient returns the bindings that use the supplied OIDCClient.
+func UsersOfOIDCClient(ctx context.Context, kube client.Reader, cr *apisv1beta1.OIDCClient) ([]string, error) {
+	l := &apisv1beta1.OIDCClientGroupBindingList{}
+	if err := kube.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListClientGroupBindings)
+	}
+
+	var users []string
+	for _, b := range l.Items {
+		p := b.Spec.ForProvider
+		if uses(cr.GetName(), cr.Status.AtProvider.ID, p.ClientIDRef, p.ClientID, b.Status.AtProvider.ResolvedClientID) {
+			users = append(users, apisv1beta1.OIDCClientGroupBindingKind+"/"+b.GetName())
+		}
+	}
+	return users, nil
+}
+
+// BindingGone returns a predicate accepting only the deletion of bindings, once
+// their finalizer is removed, which may release the resources they used.
+func BindingGone() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(ctrlevent.CreateEvent) bool { return false },
+		UpdateFunc:  func(ctrlevent.UpdateEvent) bool { return false },
+		DeleteFunc:  func(ctrlevent.DeleteEvent) bool { return true },
+		GenericFunc: func(ctrlevent.GenericEvent) bool { return false },
+	}
+}
+
+// ReleasedUsers returns a MapFunc that enqueues the deleted Users the supplied
+// UserGroupBinding used, so that their deletion is retried as soon as it is
+// gone rather than after their backoff.
+func ReleasedUsers(kube client.Reader) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		b, ok := o.(*apisv1beta1.UserGroupBinding)
+		if !ok {
+			return nil
+		}
+		l := &apisv1beta1.UserList{}
+		if err := kube.List(ctx, l); err != nil {
+			// The users will still be retried after their backoff.
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		p := b.Spec.ForProvider
+		for _, u := range l.Items {
+			if meta.WasDeleted(&u) && uses(u.GetName(), u.Status.AtProvider.ID, p.UserIDRef, p.UserID, b.Status.AtProvider.ResolvedUserID) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: u.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
+
+// ReleasedGroups returns a MapFunc that enqueues the deleted Groups the
+// supplied UserGroupBinding or OIDCClientGroupBinding used, so that their
+// deletion is retried as soon as it is gone rather than after their backoff.
+func ReleasedGroups(kube client.Reader) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		var ref *xpv1.Reference
+		var specID, resolvedID string
+		switch b := o.(type) {
+		case *apisv1beta1.UserGroupBinding:
+			ref, specID, resolvedID = b.Spec.ForProvider.GroupIDRef, b.Spec.ForProvider.GroupID, b.Status.AtProvider.ResolvedGroupID
+		case *apisv1beta1.OIDCClientGroupBinding:
+			ref, specID, resolvedID = b.Spec.ForProvider.GroupIDRef, b.Spec.ForProvider.GroupID, b.Status.AtProvider.ResolvedGroupID
+		default:
+			return nil
+		}
+		l := &apisv1beta1.GroupList{}
+		if err := kube.List(ctx, l); err != nil {
+			// The groups will still be retried after their backoff.
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, g := range l.Items {
+			if meta.WasDeleted(&g) && uses(g.GetName(), g.Status.AtProvider.ID, ref, specID, resolvedID) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: g.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
+
+// ReleasedOIDCClients returns a MapFunc that enqueues the deleted OIDCClients
+// the supplied OIDCClientGroupBinding used, so that their deletion is retried
+// as soon as it is gone rather than after their backoff.
+func ReleasedOIDCClients(kube client.Reader) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		b, ok := o.(*apisv1beta1.OIDCClientGroupBinding)
+		if !ok {
+			return nil
+		}
+		l := &apisv1beta1.OIDCClientList{}
+		if err := kube.List(ctx, l); err != nil {
+			// The OIDC clients will still be retried after their backoff.
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		p := b.Spec.ForProvider
+		for _, c := range l.Items {
+			if meta.WasDeleted(&c) && uses(c.GetName(), c.Status.AtProvider.ID, p.ClientIDRef, p.ClientID, b.Status.AtProvider.ResolvedClientID) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: c.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
+
+// NewInUseError returns an error explaining that the named resource cannot be
+// deleted while the supplied bindings use it.
+func NewInUseError(what string, users []string) error {
+	return errors.Errorf("cannot delete %s while it is used by %s", what, strings.Join(users, ", "))
+}
+
+// uses reports whether a binding refers to the resource with the supplied
+// name and external ID, either by reference, by ID or through the ID it last
+// resolved to.
+func uses(name, id string, ref *xpv1.Reference, specID, resolvedID string) bool {
+	if ref != nil && ref.Name == name {
+		return true
+	}
+	return id != "" && (specID == id || resolvedID == id)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inuse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1beta1 "github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+func TestUsersOfGroup(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	group := &apisv1beta1.Group{ObjectMeta: metav1.ObjectMeta{Name: "developers"}}
+	group.Status.AtProvider.ID = "group-id"
+
+	list := func(obj client.ObjectList) error {
+		switch l := obj.(type) {
+		case *apisv1beta1.UserGroupBindingList:
+			l.Items = []apisv1beta1.UserGroupBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "by-ref"},
+					Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+						GroupIDRef: &xpv1.Reference{Name: "developers"},
+					}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "other"},
+					Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+						GroupID: "other-id",
+					}},
+				},
+			}
+		case *apisv1beta1.OIDCClientGroupBindingList:
+			b := apisv1beta1.OIDCClientGroupBinding{ObjectMeta: metav1.ObjectMeta{Name: "by-selector"}}
+			b.Status.AtProvider.ResolvedGroupID = "group-id"
+			l.Items = []apisv1beta1.OIDCClientGroupBinding{b}
+		}
+		return nil
+	}
+
+	type want struct {
+		users []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		want   want
+	}{
+		"InUse": {
+			reason: "Bindings referencing the group by name or by resolved ID should be returned.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(nil, list)},
+			want: want{
+				users: []string{"UserGroupBinding/by-ref", "OIDCClientGroupBinding/by-selector"},
+			},
+		},
+		"ListError": {
+			reason: "Errors listing bindings should be returned.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want: want{
+				err: errors.Wrap(errBoom, errListUserGroupBindings),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := UsersOfGroup(context.Background(), tc.kube, group)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUsersOfGroup(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.users, got); diff != "" {
+				t.Errorf("\n%s\nUsersOfGroup(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReleasedGroups(t *testing.T) {
+	now := metav1.Now()
+	deleted := func(name, id string) apisv1beta1.Group {
+		g := apisv1beta1.Group{ObjectMeta: metav1.ObjectMeta{Name: name, DeletionTimestamp: &now}}
+		g.Status.AtProvider.ID = id
+		return g
+	}
+	kube := &test.MockClient{MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		l := obj.(*apisv1beta1.GroupList)
+		l.Items = []apisv1beta1.Group{
+			deleted("developers", "developers-id"),
+			deleted("admins", "admins-id"),
+			{ObjectMeta: metav1.ObjectMeta{Name: "ops"}},
+		}
+		return nil
+	})}
+
+	cases := map[string]struct {
+		reason  string
+		binding client.Object
+		want    []reconcile.Request
+	}{
+		"ByReference": {
+			reason: "A deleted group referenced by the binding should be enqueued.",
+			binding: &apisv1beta1.UserGroupBinding{Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+				GroupIDRef: &xpv1.Reference{Name: "developers"},
+			}}},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "developers"}}},
+		},
+		"ByResolvedID": {
+			reason: "A deleted group the binding last resolved to should be enqueued.",
+			binding: &apisv1beta1.OIDCClientGroupBinding{Status: apisv1beta1.OIDCClientGroupBindingStatus{AtProvider: apisv1beta1.OIDCClientGroupBindingObservation{
+				ResolvedGroupID: "admins-id",
+			}}},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "admins"}}},
+		},
+		"NotDeleted": {
+			reason: "Groups that are not being deleted should not be enqueued.",
+			binding: &apisv1beta1.UserGroupBinding{Spec: apisv1beta1.UserGroupBindingSpec{ForProvider: apisv1beta1.UserGroupBindingParameters{
+				GroupIDRef: &xpv1.Reference{Name: "ops"},
+			}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ReleasedGroups(kube)(context.Background(), tc.binding)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nReleasedGroups(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
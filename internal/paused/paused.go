@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package paused honours the crossplane.io/paused annotation outside of the
+// managed reconciler, which already skips paused managed resources: in the
+// controllers of ProviderConfigs and Snapshots, and in the side effects run in
+// the background.
+package paused
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Unpaused returns a predicate accepting the update events of objects whose
+// crossplane.io/paused annotation was removed or set to anything but true, so
+// that they are reconciled right away rather than once their poll interval
+// has elapsed.
+func Unpaused() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld != nil && e.ObjectNew != nil && meta.IsPaused(e.ObjectOld) && !meta.IsPaused(e.ObjectNew)
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// A CheckFn reports whether the reconciliation of the supplied object is
+// paused.
+type CheckFn func(ctx context.Context, obj client.Object) bool
+
+// Check returns a function reporting whether the supplied object is paused as
+// last read by the supplied reader, typically the cache of the manager, rather
+// than as it was when it was copied. Objects that cannot be read are reported
+// as their copy is.
+func Check(kube client.Reader) CheckFn {
+	return func(ctx context.Context, obj client.Object) bool {
+		cur := obj.DeepCopyObject().(client.Object)
+		if err := kube.Get(ctx, client.ObjectKeyFromObject(obj), cur); err != nil {
+			return meta.IsPaused(obj)
+		}
+		return meta.IsPaused(cur)
+	}
+}
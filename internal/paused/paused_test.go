@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paused
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+)
+
+func providerConfig(annotations map[string]string) *apisv1alpha1.ProviderConfig {
+	return &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "default", Annotations: annotations}}
+}
+
+func TestUnpaused(t *testing.T) {
+	paused := map[string]string{meta.AnnotationKeyReconciliationPaused: "true"}
+
+	cases := map[string]struct {
+		reason string
+		old    map[string]string
+		new    map[string]string
+		want   bool
+	}{
+		"Unpaused": {
+			reason: "Removing the pause annotation should be accepted.",
+			old:    paused,
+			want:   true,
+		},
+		"SetToFalse": {
+			reason: "Setting the pause annotation to false should be accepted.",
+			old:    paused,
+			new:    map[string]string{meta.AnnotationKeyReconciliationPaused: "false"},
+			want:   true,
+		},
+		"StillPaused": {
+			reason: "Updates of paused objects should be rejected.",
+			old:    paused,
+			new:    map[string]string{meta.AnnotationKeyReconciliationPaused: "true", "other": "annotation"},
+		},
+		"Paused": {
+			reason: "Pausing an object should be rejected.",
+			new:    paused,
+		},
+		"NeverPaused": {
+			reason: "Updates of objects that were not paused should be rejected.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Unpaused().Update(event.UpdateEvent{ObjectOld: providerConfig(tc.old), ObjectNew: providerConfig(tc.new)})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUnpaused().Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	paused := map[string]string{meta.AnnotationKeyReconciliationPaused: "true"}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		obj    client.Object
+		want   bool
+	}{
+		"Paused": {
+			reason: "An object paused since it was copied should be reported paused.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.SetAnnotations(paused)
+				return nil
+			})},
+			obj:  providerConfig(nil),
+			want: true,
+		},
+		"Unpaused": {
+			reason: "An object unpaused since it was copied should not be reported paused.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.SetAnnotations(nil)
+				return nil
+			})},
+			obj: providerConfig(paused),
+		},
+		"GetError": {
+			reason: "An object that cannot be read should be reported as its copy is.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			obj:    providerConfig(paused),
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Check(tc.kube)(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package readonly observes managed resources without changing Pocket ID, for
+// ProviderConfigs in ReadOnly mode or when the provider runs in dry run.
+package readonly
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+)
+
+// Event reasons.
+const (
+	ReasonWouldCreate event.Reason = "WouldCreate"
+	ReasonWouldUpdate event.Reason = "WouldUpdate"
+	ReasonWouldDelete event.Reason = "WouldDelete"
+)
+
+// Messages of the events reporting what would be changed in Pocket ID, by
+// reason.
+var (
+	readOnlyMessages = map[event.Reason]string{
+		ReasonWouldCreate: "ReadOnly mode, not creating the external resource",
+		ReasonWouldUpdate: "ReadOnly mode, not updating the external resource",
+		ReasonWouldDelete: "ReadOnly mode, not deleting the external resource",
+	}
+	dryRunMessages = map[event.Reason]string{
+		ReasonWouldCreate: "Dry run, would have created the external resource",
+		ReasonWouldUpdate: "Dry run, would have updated the external resource",
+		ReasonWouldDelete: "Dry run, would have deleted the external resource",
+	}
+)
+
+// dryRunLogger logs what would be changed in Pocket ID when the provider runs
+// in dry run, and is nil otherwise.
+var dryRunLogger logging.Logger
+
+// DryRun makes every ExternalClient wrapped afterwards only observe Pocket ID,
+// whatever the mode of its ProviderConfig, logging what it would change to
+// the supplied logger. It is meant for rehearsing changes, such as large
+// migrations, against production Pocket ID servers.
+func DryRun(l logging.Logger) {
+	dryRunLogger = l
+}
+
+// DryRunning reports whether the provider runs in dry run.
+func DryRunning() bool {
+	return dryRunLogger != nil
+}
+
+// Wrap returns an ExternalClient that only observes through the supplied one
+// when the ProviderConfig is in ReadOnly mode or the provider runs in dry
+// run, and the supplied one otherwise.
+func Wrap(pc *apisv1alpha1.ProviderConfig, e managed.ExternalClient, r event.Recorder) managed.ExternalClient {
+	if DryRunning() {
+		return &external{ExternalClient: e, recorder: r, log: dryRunLogger, messages: dryRunMessages}
+	}
+	if pc.Spec.Mode != apisv1alpha1.ProviderConfigModeReadOnly {
+		return e
+	}
+	return &external{ExternalClient: e, recorder: r, log: logging.NewNopLogger(), messages: readOnlyMessages}
+}
+
+// An external reports what its ExternalClient would change in Pocket ID
+// instead of changing it. It tells the managed reconciler that the external
+// resource is up to date so that Create and Update are never called, and
+// that it is gone once the managed resource is deleted so that its finalizer
+// is removed without calling Delete.
+type external struct {
+	managed.ExternalClient
+	recorder event.Recorder
+	log      logging.Logger
+	messages map[event.Reason]string
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return o, err
+	}
+
+	switch {
+	case meta.WasDeleted(mg):
+		if o.ResourceExists && mg.GetDeletionPolicy() != xpv1.DeletionOrphan {
+			e.report(mg, ReasonWouldDelete, "")
+		}
+		o.ResourceExists = false
+	case !o.ResourceExists:
+		e.report(mg, ReasonWouldCreate, "")
+		o.ResourceExists = true
+		o.ResourceUpToDate = true
+	case !o.ResourceUpToDate:
+		e.report(mg, ReasonWouldUpdate, o.Diff)
+		o.ResourceUpToDate = true
+	}
+	return o, nil
+}
+
+// report what would be changed in the external resource of the supplied
+// managed resource, and how if a diff is supplied.
+func (e *external) report(mg resource.Managed, reason event.Reason, diff string) {
+	msg := e.messages[reason]
+	e.log.Info(msg, "name", mg.GetName(), "external-name", meta.GetExternalName(mg), "diff", diff)
+	if diff != "" {
+		msg += ":\n" + diff
+	}
+	e.recorder.Event(mg, event.Normal(reason, msg))
+}
+
+func (e *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, nil
+}
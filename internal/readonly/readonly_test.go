@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readonly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	apisv1alpha1 "github.com/crossplane/provider-pocketid/apis/v1alpha1"
+)
+
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event)      { r.reasons = append(r.reasons, e.Reason) }
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+type mockExternal struct {
+	managed.ExternalClient
+	o       managed.ExternalObservation
+	changed bool
+}
+
+func (m *mockExternal) Observe(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+	return m.o, nil
+}
+
+func (m *mockExternal) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	m.changed = true
+	return managed.ExternalCreation{}, nil
+}
+
+func (m *mockExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	m.changed = true
+	return managed.ExternalUpdate{}, nil
+}
+
+func (m *mockExternal) Delete(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+	m.changed = true
+	return managed.ExternalDelete{}, nil
+}
+
+func TestWrap(t *testing.T) {
+	readOnly := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Mode: apisv1alpha1.ProviderConfigModeReadOnly}}
+	readWrite := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Mode: apisv1alpha1.ProviderConfigModeReadWrite}}
+	now := metav1.Now()
+
+	type want struct {
+		o       managed.ExternalObservation
+		reasons []event.Reason
+		changed bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		pc     *apisv1alpha1.ProviderConfig
+		dryRun bool
+		mg     resource.Managed
+		o      managed.ExternalObservation
+		want   want
+	}{
+		"ReadWrite": {
+			reason: "Resources using a ReadWrite ProviderConfig should be changed.",
+			pc:     readWrite,
+			mg:     &fake.Managed{},
+			o:      managed.ExternalObservation{},
+			want:   want{o: managed.ExternalObservation{}, changed: true},
+		},
+		"DryRun": {
+			reason: "Resources using a ReadWrite ProviderConfig should not be changed in dry run.",
+			pc:     readWrite,
+			dryRun: true,
+			mg:     &fake.Managed{},
+			o:      managed.ExternalObservation{ResourceExists: true, Diff: "-name"},
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, Diff: "-name"},
+				reasons: []event.Reason{ReasonWouldUpdate},
+			},
+		},
+		"WouldCreate": {
+			reason: "A missing external resource should be reported rather than created.",
+			pc:     readOnly,
+			mg:     &fake.Managed{},
+			o:      managed.ExternalObservation{},
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				reasons: []event.Reason{ReasonWouldCreate},
+			},
+		},
+		"WouldUpdate": {
+			reason: "An outdated external resource should be reported rather than updated.",
+			pc:     readOnly,
+			mg:     &fake.Managed{},
+			o:      managed.ExternalObservation{ResourceExists: true, Diff: "-name"},
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, Diff: "-name"},
+				reasons: []event.Reason{ReasonWouldUpdate},
+			},
+		},
+		"UpToDate": {
+			reason: "An up to date external resource should not be reported.",
+			pc:     readOnly,
+			mg:     &fake.Managed{},
+			o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"WouldDelete": {
+			reason: "The external resource of a deleted managed resource should be reported rather than deleted.",
+			pc:     readOnly,
+			mg:     &fake.Managed{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+			o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			want: want{
+				o:       managed.ExternalObservation{ResourceUpToDate: true},
+				reasons: []event.Reason{ReasonWouldDelete},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.dryRun {
+				DryRun(logging.NewNopLogger())
+				defer DryRun(nil)
+			}
+
+			r := &recorder{}
+			m := &mockExternal{o: tc.o}
+			e := Wrap(tc.pc, m, r)
+
+			o, err := e.Observe(context.Background(), tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, r.reasons); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+
+			_, _ = e.Create(context.Background(), tc.mg)
+			_, _ = e.Update(context.Background(), tc.mg)
+			_, _ = e.Delete(context.Background(), tc.mg)
+			if m.changed != tc.want.changed {
+				t.Errorf("\n%s\nchanged: want %t, got %t", tc.reason, tc.want.changed, m.changed)
+			}
+		})
+	}
+}
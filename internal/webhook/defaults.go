@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+// managed returns whether resources with the supplied management policies
+// are created or updated, rather than only observed. Defaults are not set on
+// observed resources, whose parameters are only ever compared to Pocket ID.
+// Resources without policies are fully managed, their CRD default.
+func managed(p xpv1.ManagementPolicies) bool {
+	return len(p) == 0 || slices.ContainsFunc(p, func(a xpv1.ManagementAction) bool {
+		return a == xpv1.ManagementActionAll || a == xpv1.ManagementActionCreate || a == xpv1.ManagementActionUpdate
+	})
+}
+
+// trimSlash removes the trailing slashes of the path of a URL, if any.
+func trimSlash(raw string) string {
+	if u, err := url.Parse(raw); err != nil || u.Host == "" {
+		return raw
+	}
+	return strings.TrimRight(raw, "/")
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-pocketid-crossplane-io-v1beta1-user,mutating=true,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=users,versions=v1beta1,name=users.defaults.pocketid.crossplane.io,admissionReviewVersions=v1
+
+// userDefaulter sets the locale of users that set none to the default locale
+// of their ProviderConfig.
+type userDefaulter struct {
+	kube client.Reader
+}
+
+func (d userDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	cr := obj.(*v1beta1.User)
+	ref := cr.GetProviderConfigReference()
+	if cr.Spec.ForProvider.Locale != "" || ref == nil || !managed(cr.GetManagementPolicies()) {
+		return nil
+	}
+	// A missing ProviderConfig is reported by the reconciler, not at
+	// admission, so that it may be created after its users.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := d.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return nil //nolint:nilerr // Defaults are best effort.
+	}
+	cr.Spec.ForProvider.Locale = pc.Spec.DefaultLocale
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-pocketid-crossplane-io-v1beta1-group,mutating=true,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=groups,versions=v1beta1,name=groups.defaults.pocketid.crossplane.io,admissionReviewVersions=v1
+
+// groupDefaulter sets the friendly name of groups that set none to their
+// name.
+type groupDefaulter struct{}
+
+func (groupDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	cr := obj.(*v1beta1.Group)
+	if cr.Spec.ForProvider.FriendlyName == "" && managed(cr.GetManagementPolicies()) {
+		cr.Spec.ForProvider.FriendlyName = cr.Spec.ForProvider.Name
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-pocketid-crossplane-io-v1beta1-oidcclient,mutating=true,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=oidcclients,versions=v1beta1,name=oidcclients.defaults.pocketid.crossplane.io,admissionReviewVersions=v1
+
+// oidcClientDefaulter requires PKCE for public clients and removes the
+// trailing slashes of their launch URL. Callback URLs are left alone, Pocket
+// ID matches them exactly.
+type oidcClientDefaulter struct{}
+
+func (oidcClientDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	cr := obj.(*v1beta1.OIDCClient)
+	if !managed(cr.GetManagementPolicies()) {
+		return nil
+	}
+	if cr.Spec.ForProvider.IsPublic {
+		cr.Spec.ForProvider.PkceEnabled = true
+	}
+	cr.Spec.ForProvider.LaunchURL = trimSlash(cr.Spec.ForProvider.LaunchURL)
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-pocketid-crossplane-io-v1alpha1-providerconfig,mutating=true,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=providerconfigs,versions=v1alpha1,name=providerconfigs.defaults.pocketid.crossplane.io,admissionReviewVersions=v1
+
+// providerConfigDefaulter removes the trailing slashes of the endpoints of
+// ProviderConfigs, which would otherwise be doubled in API paths.
+type providerConfigDefaulter struct{}
+
+func (providerConfigDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	pc := obj.(*v1alpha1.ProviderConfig)
+	pc.Spec.Endpoint = trimSlash(pc.Spec.Endpoint)
+	for i, e := range pc.Spec.FailoverEndpoints {
+		pc.Spec.FailoverEndpoints[i] = trimSlash(e)
+	}
+	return nil
+}
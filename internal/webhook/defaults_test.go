@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+func TestDefault(t *testing.T) {
+	observeOnly := xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+	withLocale := test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.(*v1alpha1.ProviderConfig).Spec.DefaultLocale = "fr-FR"
+		return nil
+	})
+	user := func(locale string, p xpv1.ManagementPolicies) *v1beta1.User {
+		return &v1beta1.User{Spec: v1beta1.UserSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}, ManagementPolicies: p},
+			ForProvider:  v1beta1.UserParameters{Username: "alice", Locale: locale},
+		}}
+	}
+	group := func(friendlyName string, p xpv1.ManagementPolicies) *v1beta1.Group {
+		return &v1beta1.Group{Spec: v1beta1.GroupSpec{
+			ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: p},
+			ForProvider:  v1beta1.GroupParameters{Name: "admins", FriendlyName: friendlyName},
+		}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		d      admission.CustomDefaulter
+		obj    runtime.Object
+		want   runtime.Object
+	}{
+		"UserLocaleFromProviderConfig": {
+			reason: "A user without locale should get the default locale of its ProviderConfig.",
+			d:      userDefaulter{kube: &test.MockClient{MockGet: withLocale}},
+			obj:    user("", nil),
+			want:   user("fr-FR", nil),
+		},
+		"UserLocaleKept": {
+			reason: "A user's own locale should be kept.",
+			d:      userDefaulter{kube: &test.MockClient{MockGet: withLocale}},
+			obj:    user("en-US", nil),
+			want:   user("en-US", nil),
+		},
+		"UserProviderConfigMissing": {
+			reason: "A user whose ProviderConfig does not exist yet should be admitted as is.",
+			d:      userDefaulter{kube: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "default"))}},
+			obj:    user("", nil),
+			want:   user("", nil),
+		},
+		"ObservedUserLocale": {
+			reason: "An observed user should not get a default locale.",
+			d:      userDefaulter{kube: &test.MockClient{MockGet: withLocale}},
+			obj:    user("", observeOnly),
+			want:   user("", observeOnly),
+		},
+		"GroupFriendlyName": {
+			reason: "A group without friendly name should be named after its name.",
+			d:      groupDefaulter{},
+			obj:    group("", nil),
+			want:   group("admins", nil),
+		},
+		"ObservedGroupFriendlyName": {
+			reason: "An observed group should not get a default friendly name.",
+			d:      groupDefaulter{},
+			obj:    group("", observeOnly),
+			want:   group("", observeOnly),
+		},
+		"PublicClient": {
+			reason: "A public client should require PKCE, and its launch URL should lose its trailing slash.",
+			d:      oidcClientDefaulter{},
+			obj: &v1beta1.OIDCClient{Spec: v1beta1.OIDCClientSpec{ForProvider: v1beta1.OIDCClientParameters{
+				IsPublic:     true,
+				LaunchURL:    "https://app.example.com/",
+				CallbackURLs: []string{"https://app.example.com/"},
+			}}},
+			want: &v1beta1.OIDCClient{Spec: v1beta1.OIDCClientSpec{ForProvider: v1beta1.OIDCClientParameters{
+				IsPublic:     true,
+				PkceEnabled:  true,
+				LaunchURL:    "https://app.example.com",
+				CallbackURLs: []string{"https://app.example.com/"},
+			}}},
+		},
+		"ProviderConfigEndpoints": {
+			reason: "The trailing slashes of endpoints should be removed, but Unix socket paths kept.",
+			d:      providerConfigDefaulter{},
+			obj: &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+				Endpoint:          "https://id.example.com/",
+				FailoverEndpoints: []string{"https://id.example.net/pocket-id//", "unix:///var/run/pocket-id.sock"},
+			}},
+			want: &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+				Endpoint:          "https://id.example.com",
+				FailoverEndpoints: []string{"https://id.example.net/pocket-id", "unix:///var/run/pocket-id.sock"},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.d.Default(context.Background(), tc.obj); err != nil {
+				t.Fatalf("\n%s\nDefault(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, tc.obj); diff != "" {
+				t.Errorf("\n%s\nDefault(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+	"github.com/crossplane/provider-pocketid/internal/clients/pocketid"
+)
+
+var forProvider = field.NewPath("spec", "forProvider")
+
+// validate returns an Invalid error listing the supplied errors, if any.
+func validate(gk schema.GroupKind, mg resource.Managed, errs field.ErrorList) (admission.Warnings, error) {
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return nil, kerrors.NewInvalid(gk, mg.GetName(), errs)
+}
+
+// validateUpdate returns the errors of the parameters of an updated resource
+// if they changed. Resources being deleted are not validated so that the
+// provider can always remove their finalizer, and neither are unchanged
+// parameters so that resources created before a rule was introduced can still
+// be reconciled.
+func validateUpdate(oldParams, newParams any, deleting bool, errs func() field.ErrorList) field.ErrorList {
+	if deleting || equality.Semantic.DeepEqual(oldParams, newParams) {
+		return nil
+	}
+	return errs()
+}
+
+// immutable returns an error if a parameter changed once the resource was
+// bound to an existing Pocket ID object through its external name.
+func immutable(path *field.Path, old resource.Managed, oldValue, newValue string) field.ErrorList {
+	if meta.GetExternalName(old) == "" || oldValue == newValue {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(path, "cannot be changed once the resource is created")}
+}
+
+// exactlyOne returns an error unless exactly one of the supplied ways to
+// identify an object is set.
+func exactlyOne(path *field.Path, byID bool, byName bool, names string) field.ErrorList {
+	if byID != byName {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, nil, "exactly one of "+names+" must be specified")}
+}
+
+// validateURLs returns an error for each URL lacking a scheme. Callback URLs
+// may use the custom schemes of native applications.
+func validateURLs(path *field.Path, urls []string) field.ErrorList {
+	var errs field.ErrorList
+	for i, raw := range urls {
+		if u, err := url.Parse(raw); err != nil || u.Scheme == "" {
+			errs = append(errs, field.Invalid(path.Index(i), raw, "must be an absolute URL"))
+		}
+	}
+	return errs
+}
+
+// validateHTTPURL returns an error unless a URL, if any, is an http(s) URL.
+func validateHTTPURL(path *field.Path, raw string) field.ErrorList {
+	if raw == "" {
+		return nil
+	}
+	if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return field.ErrorList{field.Invalid(path, raw, "must be an http(s) URL")}
+	}
+	return nil
+}
+
+// validateLogoURL returns an error unless a logo URL, if any, is an http(s)
+// URL, a ConfigMap reference or a data: URI whose content fits in Pocket ID.
+func validateLogoURL(path *field.Path, raw string) field.ErrorList {
+	switch {
+	case raw == "":
+		return nil
+	case strings.HasPrefix(raw, "data:"):
+		if _, _, err := pocketid.ParseDataURI(raw); err != nil {
+			// The data URI itself is not echoed back, it can be megabytes long.
+			return field.ErrorList{field.Invalid(path, "data:...", err.Error())}
+		}
+		return nil
+	case strings.HasPrefix(raw, "cm://"):
+		parts := strings.Split(strings.TrimPrefix(raw, "cm://"), "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return field.ErrorList{field.Invalid(path, raw, "must reference a ConfigMap key as cm://namespace/name/key")}
+		}
+		return nil
+	}
+	return validateHTTPURL(path, raw)
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-pocketid-crossplane-io-v1beta1-user,mutating=false,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=users,versions=v1beta1,name=users.pocketid.crossplane.io,admissionReviewVersions=v1
+
+type userValidator struct{}
+
+func (userValidator) ValidateCreate(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (userValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, n := oldObj.(*v1beta1.User), newObj.(*v1beta1.User)
+	return validate(v1beta1.UserGroupVersionKind.GroupKind(), n, immutable(forProvider.Child("username"), o, o.Spec.ForProvider.Username, n.Spec.ForProvider.Username))
+}
+
+func (userValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-pocketid-crossplane-io-v1beta1-group,mutating=false,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=groups,versions=v1beta1,name=groups.pocketid.crossplane.io,admissionReviewVersions=v1
+
+type groupValidator struct{}
+
+func (groupValidator) ValidateCreate(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (groupValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, n := oldObj.(*v1beta1.Group), newObj.(*v1beta1.Group)
+	return validate(v1beta1.GroupGroupVersionKind.GroupKind(), n, immutable(forProvider.Child("name"), o, o.Spec.ForProvider.Name, n.Spec.ForProvider.Name))
+}
+
+func (groupValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-pocketid-crossplane-io-v1beta1-oidcclient,mutating=false,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=oidcclients,versions=v1beta1,name=oidcclients.pocketid.crossplane.io,admissionReviewVersions=v1
+
+type oidcClientValidator struct{}
+
+func (oidcClientValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr := obj.(*v1beta1.OIDCClient)
+	return validate(v1beta1.OIDCClientGroupVersionKind.GroupKind(), cr, validateOIDCClient(cr.Spec.ForProvider))
+}
+
+func (oidcClientValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, n := oldObj.(*v1beta1.OIDCClient), newObj.(*v1beta1.OIDCClient)
+	return validate(v1beta1.OIDCClientGroupVersionKind.GroupKind(), n, validateUpdate(o.Spec.ForProvider, n.Spec.ForProvider, meta.WasDeleted(n), func() field.ErrorList {
+		return validateOIDCClient(n.Spec.ForProvider)
+	}))
+}
+
+func (oidcClientValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateOIDCClient(p v1beta1.OIDCClientParameters) field.ErrorList {
+	errs := validateURLs(forProvider.Child("callbackURLs"), p.CallbackURLs)
+	errs = append(errs, validateURLs(forProvider.Child("logoutCallbackURLs"), p.LogoutCallbackURLs)...)
+	errs = append(errs, validateHTTPURL(forProvider.Child("launchURL"), p.LaunchURL)...)
+	errs = append(errs, validateLogoURL(forProvider.Child("logoUrl"), p.LogoURL)...)
+	// Public clients cannot keep a secret, PKCE is what binds the code
+	// exchange to the client that started the authorization.
+	if p.IsPublic && !p.PkceEnabled {
+		errs = append(errs, field.Invalid(forProvider.Child("pkceEnabled"), false, "must be true for public clients"))
+	}
+	return errs
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-pocketid-crossplane-io-v1beta1-usergroupbinding,mutating=false,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=usergroupbindings,versions=v1beta1,name=usergroupbindings.pocketid.crossplane.io,admissionReviewVersions=v1
+
+type userGroupBindingValidator struct{}
+
+func (userGroupBindingValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr := obj.(*v1beta1.UserGroupBinding)
+	return validate(v1beta1.UserGroupBindingGroupVersionKind.GroupKind(), cr, validateUserGroupBinding(cr.Spec.ForProvider))
+}
+
+func (userGroupBindingValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, n := oldObj.(*v1beta1.UserGroupBinding), newObj.(*v1beta1.UserGroupBinding)
+	return validate(v1beta1.UserGroupBindingGroupVersionKind.GroupKind(), n, validateUpdate(o.Spec.ForProvider, n.Spec.ForProvider, meta.WasDeleted(n), func() field.ErrorList {
+		// Changing the user or group would bind another one, leaving the
+		// membership of the first in place.
+		errs := validateUserGroupBinding(n.Spec.ForProvider)
+		errs = append(errs, immutable(forProvider.Child("username"), o, o.Spec.ForProvider.Username, n.Spec.ForProvider.Username)...)
+		return append(errs, immutable(forProvider.Child("groupName"), o, o.Spec.ForProvider.GroupName, n.Spec.ForProvider.GroupName)...)
+	}))
+}
+
+func (userGroupBindingValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateUserGroupBinding(p v1beta1.UserGroupBindingParameters) field.ErrorList {
+	errs := exactlyOne(forProvider, p.UserID != "" || p.UserIDRef != nil || p.UserIDSelector != nil, p.Username != "", "userId, userIdRef, userIdSelector or username")
+	return append(errs, exactlyOne(forProvider, p.GroupID != "" || p.GroupIDRef != nil || p.GroupIDSelector != nil, p.GroupName != "", "groupId, groupIdRef, groupIdSelector or groupName")...)
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-pocketid-crossplane-io-v1beta1-oidcclientgroupbinding,mutating=false,failurePolicy=ignore,sideEffects=None,groups=pocketid.crossplane.io,resources=oidcclientgroupbindings,versions=v1beta1,name=oidcclientgroupbindings.pocketid.crossplane.io,admissionReviewVersions=v1
+
+type oidcClientGroupBindingValidator struct{}
+
+func (oidcClientGroupBindingValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr := obj.(*v1beta1.OIDCClientGroupBinding)
+	return validate(v1beta1.OIDCClientGroupBindingGroupVersionKind.GroupKind(), cr, validateOIDCClientGroupBinding(cr.Spec.ForProvider))
+}
+
+func (oidcClientGroupBindingValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, n := oldObj.(*v1beta1.OIDCClientGroupBinding), newObj.(*v1beta1.OIDCClientGroupBinding)
+	return validate(v1beta1.OIDCClientGroupBindingGroupVersionKind.GroupKind(), n, validateUpdate(o.Spec.ForProvider, n.Spec.ForProvider, meta.WasDeleted(n), func() field.ErrorList {
+		// The binding keeps observing the client and group of its external
+		// name, whatever their names become.
+		errs := validateOIDCClientGroupBinding(n.Spec.ForProvider)
+		errs = append(errs, immutable(forProvider.Child("clientName"), o, o.Spec.ForProvider.ClientName, n.Spec.ForProvider.ClientName)...)
+		return append(errs, immutable(forProvider.Child("groupName"), o, o.Spec.ForProvider.GroupName, n.Spec.ForProvider.GroupName)...)
+	}))
+}
+
+func (oidcClientGroupBindingValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateOIDCClientGroupBinding(p v1beta1.OIDCClientGroupBindingParameters) field.ErrorList {
+	errs := exactlyOne(forProvider, p.ClientID != "" || p.ClientIDRef != nil || p.ClientIDSelector != nil, p.ClientName != "", "clientId, clientIdRef, clientIdSelector or clientName")
+	return append(errs, exactlyOne(forProvider, p.GroupID != "" || p.GroupIDRef != nil || p.GroupIDSelector != nil, p.GroupName != "", "groupId, groupIdRef, groupIdSelector or groupName")...)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+func TestValidate(t *testing.T) {
+	client := func(p v1beta1.OIDCClientParameters) *v1beta1.OIDCClient {
+		p.Name = "app"
+		if p.CallbackURLs == nil {
+			p.CallbackURLs = []string{"https://app.example.com/callback"}
+		}
+		return &v1beta1.OIDCClient{Spec: v1beta1.OIDCClientSpec{ForProvider: p}}
+	}
+	created := func(name string) metav1.ObjectMeta {
+		o := metav1.ObjectMeta{}
+		meta.SetExternalName(&o, name)
+		return o
+	}
+	now := metav1.Now()
+	deleted := metav1.ObjectMeta{DeletionTimestamp: &now}
+
+	type args struct {
+		v   admission.CustomValidator
+		old runtime.Object
+		obj runtime.Object
+	}
+
+	cases := map[string]struct {
+		reason  string
+		args    args
+		invalid bool
+	}{
+		"ValidClient": {
+			reason: "A client with a custom scheme callback, a ConfigMap logo and PKCE should be admitted.",
+			args: args{v: oidcClientValidator{}, obj: client(v1beta1.OIDCClientParameters{
+				CallbackURLs: []string{"com.example.app:/oauth"},
+				LogoURL:      "cm://apps/logos/app.png",
+				IsPublic:     true,
+				PkceEnabled:  true,
+			})},
+		},
+		"RelativeCallbackURL": {
+			reason:  "Callback URLs must be absolute.",
+			args:    args{v: oidcClientValidator{}, obj: client(v1beta1.OIDCClientParameters{CallbackURLs: []string{"/callback"}})},
+			invalid: true,
+		},
+		"LaunchURLNotHTTP": {
+			reason:  "The launch URL must be an http(s) URL.",
+			args:    args{v: oidcClientValidator{}, obj: client(v1beta1.OIDCClientParameters{LaunchURL: "ftp://app.example.com"})},
+			invalid: true,
+		},
+		"PublicWithoutPKCE": {
+			reason:  "Public clients must use PKCE.",
+			args:    args{v: oidcClientValidator{}, obj: client(v1beta1.OIDCClientParameters{IsPublic: true})},
+			invalid: true,
+		},
+		"MalformedConfigMapLogo": {
+			reason:  "ConfigMap logos must reference a namespace, a name and a key.",
+			args:    args{v: oidcClientValidator{}, obj: client(v1beta1.OIDCClientParameters{LogoURL: "cm://logos/app.png"})},
+			invalid: true,
+		},
+		"OversizedDataLogo": {
+			reason:  "Logos embedded as data: URIs must fit in Pocket ID's 2MB limit.",
+			args:    args{v: oidcClientValidator{}, obj: client(v1beta1.OIDCClientParameters{LogoURL: "data:image/svg+xml," + strings.Repeat("a", 2<<20+1)})},
+			invalid: true,
+		},
+		"UnchangedInvalidClient": {
+			reason: "Invalid parameters that did not change should not block reconciling existing clients.",
+			args: args{
+				v:   oidcClientValidator{},
+				old: client(v1beta1.OIDCClientParameters{IsPublic: true}),
+				obj: client(v1beta1.OIDCClientParameters{IsPublic: true}),
+			},
+		},
+		"DeletedClient": {
+			reason: "Clients being deleted should never be rejected.",
+			args: args{
+				v:   oidcClientValidator{},
+				old: client(v1beta1.OIDCClientParameters{}),
+				obj: &v1beta1.OIDCClient{ObjectMeta: deleted, Spec: v1beta1.OIDCClientSpec{ForProvider: v1beta1.OIDCClientParameters{IsPublic: true}}},
+			},
+		},
+		"RenamedUser": {
+			reason: "A user's username cannot change once it is created.",
+			args: args{
+				v:   userValidator{},
+				old: &v1beta1.User{ObjectMeta: created("alice"), Spec: v1beta1.UserSpec{ForProvider: v1beta1.UserParameters{Username: "alice"}}},
+				obj: &v1beta1.User{ObjectMeta: created("alice"), Spec: v1beta1.UserSpec{ForProvider: v1beta1.UserParameters{Username: "bob"}}},
+			},
+			invalid: true,
+		},
+		"RenamedPendingGroup": {
+			reason: "A group's name can change until it is created.",
+			args: args{
+				v:   groupValidator{},
+				old: &v1beta1.Group{Spec: v1beta1.GroupSpec{ForProvider: v1beta1.GroupParameters{Name: "admins"}}},
+				obj: &v1beta1.Group{Spec: v1beta1.GroupSpec{ForProvider: v1beta1.GroupParameters{Name: "administrators"}}},
+			},
+		},
+		"RenamedBindingGroup": {
+			reason: "A binding's group name cannot change once it is created.",
+			args: args{
+				v:   userGroupBindingValidator{},
+				old: &v1beta1.UserGroupBinding{ObjectMeta: created("alice-admins"), Spec: v1beta1.UserGroupBindingSpec{ForProvider: v1beta1.UserGroupBindingParameters{Username: "alice", GroupName: "admins"}}},
+				obj: &v1beta1.UserGroupBinding{ObjectMeta: created("alice-admins"), Spec: v1beta1.UserGroupBindingSpec{ForProvider: v1beta1.UserGroupBindingParameters{Username: "alice", GroupName: "developers"}}},
+			},
+			invalid: true,
+		},
+		"RenamedBindingClient": {
+			reason: "A binding's client name cannot change once it is created.",
+			args: args{
+				v:   oidcClientGroupBindingValidator{},
+				old: &v1beta1.OIDCClientGroupBinding{ObjectMeta: created("client-id:group-id"), Spec: v1beta1.OIDCClientGroupBindingSpec{ForProvider: v1beta1.OIDCClientGroupBindingParameters{ClientName: "app", GroupName: "admins"}}},
+				obj: &v1beta1.OIDCClientGroupBinding{ObjectMeta: created("client-id:group-id"), Spec: v1beta1.OIDCClientGroupBindingSpec{ForProvider: v1beta1.OIDCClientGroupBindingParameters{ClientName: "other-app", GroupName: "admins"}}},
+			},
+			invalid: true,
+		},
+		"BindingByReference": {
+			reason: "A binding identifying its user by reference and its group by name should be admitted.",
+			args: args{v: userGroupBindingValidator{}, obj: &v1beta1.UserGroupBinding{Spec: v1beta1.UserGroupBindingSpec{ForProvider: v1beta1.UserGroupBindingParameters{
+				UserIDRef: &xpv1.Reference{Name: "alice"},
+				GroupName: "admins",
+			}}}},
+		},
+		"ResolvedBindingReference": {
+			reason: "A binding whose reference was resolved to an ID should still be admitted.",
+			args: args{
+				v: userGroupBindingValidator{},
+				old: &v1beta1.UserGroupBinding{ObjectMeta: created("alice-admins"), Spec: v1beta1.UserGroupBindingSpec{ForProvider: v1beta1.UserGroupBindingParameters{
+					UserIDRef: &xpv1.Reference{Name: "alice"},
+					GroupName: "admins",
+				}}},
+				obj: &v1beta1.UserGroupBinding{ObjectMeta: created("alice-admins"), Spec: v1beta1.UserGroupBindingSpec{ForProvider: v1beta1.UserGroupBindingParameters{
+					UserID:    "u1",
+					UserIDRef: &xpv1.Reference{Name: "alice"},
+					GroupName: "admins",
+				}}},
+			},
+		},
+		"BindingByIDAndName": {
+			reason: "A binding cannot identify its user both by ID and by username.",
+			args: args{v: userGroupBindingValidator{}, obj: &v1beta1.UserGroupBinding{Spec: v1beta1.UserGroupBindingSpec{ForProvider: v1beta1.UserGroupBindingParameters{
+				UserID:    "u1",
+				Username:  "alice",
+				GroupName: "admins",
+			}}}},
+			invalid: true,
+		},
+		"ClientBindingWithoutGroup": {
+			reason: "A client binding must identify its group.",
+			args: args{v: oidcClientGroupBindingValidator{}, obj: &v1beta1.OIDCClientGroupBinding{Spec: v1beta1.OIDCClientGroupBindingSpec{ForProvider: v1beta1.OIDCClientGroupBindingParameters{
+				ClientName: "app",
+			}}}},
+			invalid: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			if tc.args.old == nil {
+				_, err = tc.args.v.ValidateCreate(context.Background(), tc.args.obj)
+			} else {
+				_, err = tc.args.v.ValidateUpdate(context.Background(), tc.args.old, tc.args.obj)
+			}
+			if got := kerrors.IsInvalid(err); got != tc.invalid {
+				t.Errorf("\n%s\nValidate(...): want invalid %t, got error %v", tc.reason, tc.invalid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook serves the admission and conversion webhooks of the Pocket
+// ID managed resources.
+package webhook
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/provider-pocketid/apis/v1alpha1"
+	"github.com/crossplane/provider-pocketid/apis/v1beta1"
+)
+
+// Options configure the webhooks served besides the conversion webhooks.
+type Options struct {
+	// Validate rejects invalid managed resources at admission.
+	Validate bool
+}
+
+// Setup registers the conversion webhooks through which the API server
+// converts the v1alpha1 managed resources to and from their v1beta1 storage
+// version, the defaulting webhooks, and the validating webhooks if enabled by
+// the supplied options.
+func Setup(mgr ctrl.Manager, o Options) error {
+	hooks := []struct {
+		obj       runtime.Object
+		defaulter admission.CustomDefaulter
+		validator admission.CustomValidator
+	}{
+		{obj: &v1beta1.User{}, defaulter: userDefaulter{kube: mgr.GetClient()}, validator: userValidator{}},
+		{obj: &v1beta1.Group{}, defaulter: groupDefaulter{}, validator: groupValidator{}},
+		{obj: &v1beta1.OIDCClient{}, defaulter: oidcClientDefaulter{}, validator: oidcClientValidator{}},
+		{obj: &v1beta1.UserGroupBinding{}, validator: userGroupBindingValidator{}},
+		{obj: &v1beta1.OIDCClientGroupBinding{}, validator: oidcClientGroupBindingValidator{}},
+		{obj: &v1alpha1.ProviderConfig{}, defaulter: providerConfigDefaulter{}},
+	}
+	for _, h := range hooks {
+		b := ctrl.NewWebhookManagedBy(mgr).For(h.obj)
+		if h.defaulter != nil {
+			b = b.WithDefaulter(h.defaulter)
+		}
+		if o.Validate && h.validator != nil {
+			b = b.WithValidator(h.validator)
+		}
+		if err := b.Complete(); err != nil {
+			return errors.Wrapf(err, "cannot setup webhooks for %T", h.obj)
+		}
+	}
+	return nil
+}